@@ -0,0 +1,588 @@
+// Package config loads the runtime configuration of the backend server.
+//
+// Values are resolved in the following order, later sources overriding
+// earlier ones:
+//
+//  1. built-in defaults (matching docker-compose.yml)
+//  2. an optional YAML file (path given by CONFIG_FILE)
+//  3. environment variables
+//
+// The result is validated by [Load] so that the server fails fast on
+// misconfiguration instead of at the first request.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+	_ "time/tzdata" // コンテナにタイムゾーン情報がなくても動作させる
+
+	"github.com/go-sql-driver/mysql"
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the root configuration.
+type Config struct {
+	Server   ServerConfig   `yaml:"server"`
+	DB       DBConfig       `yaml:"db"`
+	Auth     AuthConfig     `yaml:"auth"`
+	Mail     MailConfig     `yaml:"mail"`
+	Log      LogConfig      `yaml:"log"`
+	Trace    TraceConfig    `yaml:"trace"`
+	Schema   SchemaConfig   `yaml:"schema"`
+	Import   ImportConfig   `yaml:"import"`
+	Calendar CalendarConfig `yaml:"calendar"`
+	Compat   CompatConfig   `yaml:"compat"`
+}
+
+// ServerConfig configures the HTTP server.
+type ServerConfig struct {
+	// Port is the TCP port the server listens on.
+	Port int `yaml:"port"`
+	// ReadHeaderTimeout bounds the time to read request headers.
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout"`
+	// ReadTimeout bounds the time to read the whole request.
+	ReadTimeout time.Duration `yaml:"read_timeout"`
+	// WriteTimeout bounds the time to write the response.
+	WriteTimeout time.Duration `yaml:"write_timeout"`
+	// IdleTimeout bounds how long keep-alive connections stay open.
+	IdleTimeout time.Duration `yaml:"idle_timeout"`
+	// ShutdownTimeout bounds how long in-flight requests are drained on SIGTERM.
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+	// APIPrefix is the path the API is served under, so that the other
+	// paths are left to the frontend. Probes and /metrics stay at the root.
+	APIPrefix string `yaml:"api_prefix"`
+}
+
+// DBConfig configures the MySQL connection and pool.
+type DBConfig struct {
+	// DSN, when set, replaces the connection fields below. TimeZone and the
+	// options the server relies on are still applied (see FormatDSN).
+	DSN      string `yaml:"dsn"`
+	Host     string `yaml:"host"`
+	Port     int    `yaml:"port"`
+	User     string `yaml:"user"`
+	Password string `yaml:"password"`
+	Name     string `yaml:"name"`
+	// TimeZone is the location used to interpret DATETIME values.
+	TimeZone string `yaml:"time_zone"`
+
+	MaxOpenConns    int           `yaml:"max_open_conns"`
+	MaxIdleConns    int           `yaml:"max_idle_conns"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime"`
+	ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time"`
+	// ConnectRetries is the number of pings attempted at startup while the
+	// database container is still booting.
+	ConnectRetries int `yaml:"connect_retries"`
+	// ConnectInterval is the wait between startup pings.
+	ConnectInterval time.Duration `yaml:"connect_interval"`
+}
+
+// AuthConfig configures login sessions.
+type AuthConfig struct {
+	// SessionTTL is how long a session stays valid after login.
+	SessionTTL time.Duration `yaml:"session_ttl"`
+	// CookieSecure sends the session cookie over HTTPS only. Enable it
+	// whenever the server is reached through TLS.
+	CookieSecure bool `yaml:"cookie_secure"`
+	// MaxFailedLogins is the number of consecutive failed logins after
+	// which an account is locked.
+	MaxFailedLogins int `yaml:"max_failed_logins"`
+	// LockoutDuration is how long a locked account stays locked.
+	LockoutDuration time.Duration `yaml:"lockout_duration"`
+	// TOTPIssuer is the issuer shown in authenticator apps.
+	TOTPIssuer string `yaml:"totp_issuer"`
+	// PasswordResetTTL is how long an e-mailed reset token stays valid.
+	PasswordResetTTL time.Duration `yaml:"password_reset_ttl"`
+	// PasswordResetURL is the frontend page the reset token is appended to
+	// as the token query parameter.
+	PasswordResetURL string `yaml:"password_reset_url"`
+}
+
+// MailConfig configures outgoing e-mail.
+type MailConfig struct {
+	// Driver is "smtp" or "log" (write the recipients and subjects of
+	// messages to the server log without sending them). It defaults to smtp
+	// so that a deployment never drops mail silently.
+	Driver   string `yaml:"driver"`
+	Host     string `yaml:"host"`
+	Port     int    `yaml:"port"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+	// From is the sender used when a mail template does not set one.
+	From string `yaml:"from"`
+}
+
+// LogConfig configures the server log.
+type LogConfig struct {
+	// Level is the minimum level written: debug, info, warn or error.
+	Level string `yaml:"level"`
+	// Format is "json" or "text" (easier to read in a terminal).
+	Format string `yaml:"format"`
+}
+
+// TraceConfig configures OpenTelemetry tracing of HTTP requests and SQL.
+type TraceConfig struct {
+	// Exporter is "none" (tracing disabled), "stdout" or "otlp".
+	Exporter string `yaml:"exporter"`
+	// OTLPEndpoint is the host:port of the OTLP/HTTP collector.
+	OTLPEndpoint string `yaml:"otlp_endpoint"`
+	// OTLPInsecure sends spans to the collector over plain HTTP.
+	OTLPInsecure bool `yaml:"otlp_insecure"`
+	// ServiceName is reported as the service.name resource attribute.
+	ServiceName string `yaml:"service_name"`
+	// SampleRatio is the fraction of new traces that are recorded. Requests
+	// that arrive with a sampled parent are always recorded.
+	SampleRatio float64 `yaml:"sample_ratio"`
+}
+
+// SchemaConfig locates the table definitions served by the master
+// maintenance API.
+type SchemaConfig struct {
+	// File is the path of docs/schema.yaml, relative to the working
+	// directory.
+	File string `yaml:"file"`
+}
+
+// ImportConfig configures the bulk import of master tables from CSV and
+// Excel files.
+type ImportConfig struct {
+	// MaxBytes is the largest file accepted.
+	MaxBytes int `yaml:"max_bytes"`
+	// AsyncRows is the number of data rows above which a file is imported
+	// by a background job instead of within the request.
+	AsyncRows int `yaml:"async_rows"`
+	// ChunkSize is the number of rows committed together by a chunked
+	// import.
+	ChunkSize int `yaml:"chunk_size"`
+	// JobTTL is how long the result of a finished job can be polled.
+	JobTTL time.Duration `yaml:"job_ttl"`
+}
+
+// CalendarConfig configures the iCalendar feeds and the event stream of the
+// schedule.
+type CalendarConfig struct {
+	// FeedBaseURL is the externally reachable URL of the API, which the
+	// feed URLs handed to users start with.
+	FeedBaseURL string `yaml:"feed_base_url"`
+	// StreamHeartbeat is the interval of the heartbeats of /events/stream.
+	// It must be shorter than the idle timeouts of the proxies in between.
+	StreamHeartbeat time.Duration `yaml:"stream_heartbeat"`
+	// StreamHistory is the number of recent changes kept for clients
+	// reconnecting to /events/stream with Last-Event-ID.
+	StreamHistory int `yaml:"stream_history"`
+}
+
+// CompatConfig configures /api/{name}.php, which serves the PHP endpoints
+// called by the frontend while they are moved to Go.
+type CompatConfig struct {
+	// AllowOrigin is the origin allowed by CORS, the Vite dev server by
+	// default. Empty sends no CORS headers.
+	AllowOrigin string `yaml:"allow_origin"`
+	// PHPURL is the URL of the PHP backend the endpoints not ported yet are
+	// passed to. Empty answers them with 404.
+	PHPURL string `yaml:"php_url"`
+}
+
+// Default returns the configuration used when nothing is overridden.
+func Default() Config {
+	return Config{
+		Server: ServerConfig{
+			Port:              8080,
+			ReadHeaderTimeout: 5 * time.Second,
+			ReadTimeout:       15 * time.Second,
+			WriteTimeout:      30 * time.Second,
+			IdleTimeout:       60 * time.Second,
+			ShutdownTimeout:   20 * time.Second,
+			APIPrefix:         "/api",
+		},
+		DB: DBConfig{
+			Host:            "db",
+			Port:            3306,
+			User:            "root",
+			Password:        "rootpassword",
+			Name:            "app_db",
+			TimeZone:        "Asia/Tokyo",
+			MaxOpenConns:    20,
+			MaxIdleConns:    10,
+			ConnMaxLifetime: 30 * time.Minute,
+			ConnMaxIdleTime: 5 * time.Minute,
+			ConnectRetries:  10,
+			ConnectInterval: 2 * time.Second,
+		},
+		Auth: AuthConfig{
+			SessionTTL:       12 * time.Hour,
+			MaxFailedLogins:  5,
+			LockoutDuration:  15 * time.Minute,
+			TOTPIssuer:       "backend-go",
+			PasswordResetTTL: time.Hour,
+			PasswordResetURL: "http://localhost:5173/reset-password",
+		},
+		Mail: MailConfig{
+			Driver: "smtp",
+			Host:   "localhost",
+			Port:   25,
+			From:   "noreply@example.com",
+		},
+		Log: LogConfig{
+			Level:  "info",
+			Format: "json",
+		},
+		Trace: TraceConfig{
+			Exporter:     "none",
+			OTLPEndpoint: "localhost:4318",
+			OTLPInsecure: true,
+			ServiceName:  "backend-go",
+			SampleRatio:  1,
+		},
+		Schema: SchemaConfig{
+			File: "../docs/schema.yaml",
+		},
+		Import: ImportConfig{
+			MaxBytes:  20 << 20,
+			AsyncRows: 1000,
+			ChunkSize: 500,
+			JobTTL:    time.Hour,
+		},
+		Calendar: CalendarConfig{
+			FeedBaseURL:     "http://localhost:8081/api",
+			StreamHeartbeat: 25 * time.Second,
+			StreamHistory:   1000,
+		},
+		Compat: CompatConfig{
+			AllowOrigin: "http://localhost:5173",
+		},
+	}
+}
+
+// Load builds the configuration from defaults, the YAML file at path (if not
+// empty) and environment variables, and validates the result.
+func Load(path string) (*Config, error) {
+	cfg := Default()
+
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("config: read %s: %w", path, err)
+		}
+		if err := yaml.Unmarshal(data, &cfg); err != nil {
+			return nil, fmt.Errorf("config: parse %s: %w", path, err)
+		}
+	}
+
+	if err := cfg.applyEnv(os.LookupEnv); err != nil {
+		return nil, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// applyEnv overrides fields from environment variables.
+func (c *Config) applyEnv(lookup func(string) (string, bool)) error {
+	var errs []error
+
+	str := func(key string, dst *string) {
+		if v, ok := lookup(key); ok {
+			*dst = v
+		}
+	}
+	num := func(key string, dst *int) {
+		if v, ok := lookup(key); ok {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("config: %s: %q is not an integer", key, v))
+				return
+			}
+			*dst = n
+		}
+	}
+	boolean := func(key string, dst *bool) {
+		if v, ok := lookup(key); ok {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("config: %s: %q is not a boolean", key, v))
+				return
+			}
+			*dst = b
+		}
+	}
+	float := func(key string, dst *float64) {
+		if v, ok := lookup(key); ok {
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("config: %s: %q is not a number", key, v))
+				return
+			}
+			*dst = f
+		}
+	}
+	dur := func(key string, dst *time.Duration) {
+		if v, ok := lookup(key); ok {
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("config: %s: %q is not a duration", key, v))
+				return
+			}
+			*dst = d
+		}
+	}
+
+	num("PORT", &c.Server.Port)
+	dur("SERVER_READ_HEADER_TIMEOUT", &c.Server.ReadHeaderTimeout)
+	dur("SERVER_READ_TIMEOUT", &c.Server.ReadTimeout)
+	dur("SERVER_WRITE_TIMEOUT", &c.Server.WriteTimeout)
+	dur("SERVER_IDLE_TIMEOUT", &c.Server.IdleTimeout)
+	dur("SERVER_SHUTDOWN_TIMEOUT", &c.Server.ShutdownTimeout)
+	str("SERVER_API_PREFIX", &c.Server.APIPrefix)
+
+	str("DB_DSN", &c.DB.DSN)
+	str("DB_HOST", &c.DB.Host)
+	num("DB_PORT", &c.DB.Port)
+	str("DB_USER", &c.DB.User)
+	str("DB_PASSWORD", &c.DB.Password)
+	str("DB_NAME", &c.DB.Name)
+	str("DB_TIME_ZONE", &c.DB.TimeZone)
+	num("DB_MAX_OPEN_CONNS", &c.DB.MaxOpenConns)
+	num("DB_MAX_IDLE_CONNS", &c.DB.MaxIdleConns)
+	dur("DB_CONN_MAX_LIFETIME", &c.DB.ConnMaxLifetime)
+	dur("DB_CONN_MAX_IDLE_TIME", &c.DB.ConnMaxIdleTime)
+	num("DB_CONNECT_RETRIES", &c.DB.ConnectRetries)
+	dur("DB_CONNECT_INTERVAL", &c.DB.ConnectInterval)
+
+	dur("AUTH_SESSION_TTL", &c.Auth.SessionTTL)
+	boolean("AUTH_COOKIE_SECURE", &c.Auth.CookieSecure)
+	num("AUTH_MAX_FAILED_LOGINS", &c.Auth.MaxFailedLogins)
+	dur("AUTH_LOCKOUT_DURATION", &c.Auth.LockoutDuration)
+	str("AUTH_TOTP_ISSUER", &c.Auth.TOTPIssuer)
+	dur("AUTH_PASSWORD_RESET_TTL", &c.Auth.PasswordResetTTL)
+	str("AUTH_PASSWORD_RESET_URL", &c.Auth.PasswordResetURL)
+
+	str("MAIL_DRIVER", &c.Mail.Driver)
+	str("SMTP_HOST", &c.Mail.Host)
+	num("SMTP_PORT", &c.Mail.Port)
+	str("SMTP_USERNAME", &c.Mail.Username)
+	str("SMTP_PASSWORD", &c.Mail.Password)
+	str("MAIL_FROM", &c.Mail.From)
+
+	str("LOG_LEVEL", &c.Log.Level)
+	str("LOG_FORMAT", &c.Log.Format)
+
+	str("TRACE_EXPORTER", &c.Trace.Exporter)
+	str("TRACE_OTLP_ENDPOINT", &c.Trace.OTLPEndpoint)
+	boolean("TRACE_OTLP_INSECURE", &c.Trace.OTLPInsecure)
+	str("TRACE_SERVICE_NAME", &c.Trace.ServiceName)
+	float("TRACE_SAMPLE_RATIO", &c.Trace.SampleRatio)
+
+	str("SCHEMA_FILE", &c.Schema.File)
+
+	num("IMPORT_MAX_BYTES", &c.Import.MaxBytes)
+	num("IMPORT_ASYNC_ROWS", &c.Import.AsyncRows)
+	num("IMPORT_CHUNK_SIZE", &c.Import.ChunkSize)
+	dur("IMPORT_JOB_TTL", &c.Import.JobTTL)
+
+	str("CALENDAR_FEED_BASE_URL", &c.Calendar.FeedBaseURL)
+	dur("CALENDAR_STREAM_HEARTBEAT", &c.Calendar.StreamHeartbeat)
+	num("CALENDAR_STREAM_HISTORY", &c.Calendar.StreamHistory)
+
+	str("COMPAT_ALLOW_ORIGIN", &c.Compat.AllowOrigin)
+	str("COMPAT_PHP_URL", &c.Compat.PHPURL)
+
+	return errors.Join(errs...)
+}
+
+// Validate reports every invalid field at once.
+func (c *Config) Validate() error {
+	var errs []error
+	fail := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf("config: "+format, args...))
+	}
+
+	if c.Server.Port < 1 || c.Server.Port > 65535 {
+		fail("server.port %d is out of range", c.Server.Port)
+	}
+	for name, d := range map[string]time.Duration{
+		"server.read_header_timeout": c.Server.ReadHeaderTimeout,
+		"server.read_timeout":        c.Server.ReadTimeout,
+		"server.write_timeout":       c.Server.WriteTimeout,
+		"server.idle_timeout":        c.Server.IdleTimeout,
+		"server.shutdown_timeout":    c.Server.ShutdownTimeout,
+	} {
+		if d <= 0 {
+			fail("%s must be positive", name)
+		}
+	}
+	if p := c.Server.APIPrefix; len(p) < 2 || p[0] != '/' || p[len(p)-1] == '/' {
+		fail("server.api_prefix %q must start with / and not end with it", p)
+	}
+
+	if c.DB.DSN == "" {
+		if c.DB.Host == "" {
+			fail("db.host is required")
+		}
+		if c.DB.Port < 1 || c.DB.Port > 65535 {
+			fail("db.port %d is out of range", c.DB.Port)
+		}
+		if c.DB.User == "" {
+			fail("db.user is required")
+		}
+		if c.DB.Name == "" {
+			fail("db.name is required")
+		}
+	} else if _, err := mysql.ParseDSN(c.DB.DSN); err != nil {
+		fail("db.dsn: %v", err)
+	}
+	if _, err := time.LoadLocation(c.DB.TimeZone); err != nil {
+		fail("db.time_zone %q: %v", c.DB.TimeZone, err)
+	}
+	if c.DB.MaxOpenConns < 1 {
+		fail("db.max_open_conns must be at least 1")
+	}
+	if c.DB.MaxIdleConns < 0 || c.DB.MaxIdleConns > c.DB.MaxOpenConns {
+		fail("db.max_idle_conns must be between 0 and db.max_open_conns")
+	}
+	if c.DB.ConnMaxLifetime < 0 || c.DB.ConnMaxIdleTime < 0 {
+		fail("db connection lifetimes must not be negative")
+	}
+	if c.DB.ConnectRetries < 1 {
+		fail("db.connect_retries must be at least 1")
+	}
+	if c.Auth.SessionTTL <= 0 {
+		fail("auth.session_ttl must be positive")
+	}
+	if c.Auth.MaxFailedLogins < 1 {
+		fail("auth.max_failed_logins must be at least 1")
+	}
+	if c.Auth.LockoutDuration <= 0 || c.Auth.PasswordResetTTL <= 0 {
+		fail("auth.lockout_duration and auth.password_reset_ttl must be positive")
+	}
+	if c.Auth.TOTPIssuer == "" {
+		fail("auth.totp_issuer is required")
+	}
+	if u, err := url.Parse(c.Auth.PasswordResetURL); err != nil || !u.IsAbs() {
+		fail("auth.password_reset_url %q must be an absolute URL", c.Auth.PasswordResetURL)
+	}
+
+	switch c.Mail.Driver {
+	case "log":
+	case "smtp":
+		if c.Mail.Host == "" {
+			fail("mail.host is required for the smtp driver")
+		}
+		if c.Mail.Port < 1 || c.Mail.Port > 65535 {
+			fail("mail.port %d is out of range", c.Mail.Port)
+		}
+	default:
+		fail("mail.driver %q must be smtp or log", c.Mail.Driver)
+	}
+	if c.Mail.From == "" {
+		fail("mail.from is required")
+	}
+
+	if _, err := c.Log.SlogLevel(); err != nil {
+		fail("log.level: %v", err)
+	}
+	if c.Log.Format != "json" && c.Log.Format != "text" {
+		fail("log.format %q must be json or text", c.Log.Format)
+	}
+
+	switch c.Trace.Exporter {
+	case "none", "stdout":
+	case "otlp":
+		if c.Trace.OTLPEndpoint == "" {
+			fail("trace.otlp_endpoint is required for the otlp exporter")
+		}
+	default:
+		fail("trace.exporter %q must be none, stdout or otlp", c.Trace.Exporter)
+	}
+	if c.Trace.ServiceName == "" {
+		fail("trace.service_name is required")
+	}
+	if c.Trace.SampleRatio < 0 || c.Trace.SampleRatio > 1 {
+		fail("trace.sample_ratio must be between 0 and 1")
+	}
+
+	if c.Schema.File == "" {
+		fail("schema.file is required")
+	}
+
+	if c.Import.MaxBytes < 1 || c.Import.AsyncRows < 1 || c.Import.ChunkSize < 1 {
+		fail("import.max_bytes, import.async_rows and import.chunk_size must be at least 1")
+	}
+	if c.Import.JobTTL <= 0 {
+		fail("import.job_ttl must be positive")
+	}
+
+	if u, err := url.Parse(c.Calendar.FeedBaseURL); err != nil || !u.IsAbs() {
+		fail("calendar.feed_base_url %q must be an absolute URL", c.Calendar.FeedBaseURL)
+	}
+	if c.Calendar.StreamHeartbeat <= 0 {
+		fail("calendar.stream_heartbeat must be positive")
+	}
+	if c.Calendar.StreamHistory < 1 {
+		fail("calendar.stream_history must be at least 1")
+	}
+
+	if c.Compat.PHPURL != "" {
+		if u, err := url.Parse(c.Compat.PHPURL); err != nil || !u.IsAbs() {
+			fail("compat.php_url %q must be an absolute URL", c.Compat.PHPURL)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Addr returns the listen address for http.Server.
+func (s ServerConfig) Addr() string {
+	return ":" + strconv.Itoa(s.Port)
+}
+
+// FormatDSN returns the go-sql-driver DSN. Connections always enable
+// clientFoundRows (so RowsAffected reports matched rows) and parseTime, and
+// interpret DATETIME values in TimeZone, also when they come from DSN.
+func (d DBConfig) FormatDSN() string {
+	loc, err := time.LoadLocation(d.TimeZone)
+	if err != nil {
+		loc = time.UTC
+	}
+	mc := mysql.NewConfig()
+	if d.DSN != "" {
+		// 不正な DSN は Validate で弾いている
+		if mc, err = mysql.ParseDSN(d.DSN); err != nil {
+			return d.DSN
+		}
+	} else {
+		mc.User = d.User
+		mc.Passwd = d.Password
+		mc.Net = "tcp"
+		mc.Addr = net.JoinHostPort(d.Host, strconv.Itoa(d.Port))
+		mc.DBName = d.Name
+	}
+	mc.ClientFoundRows = true
+	mc.ParseTime = true
+	mc.Loc = loc
+	return mc.FormatDSN()
+}
+
+// DatabaseName returns the schema name, taken from DSN when it is set.
+func (d DBConfig) DatabaseName() string {
+	if d.DSN != "" {
+		if mc, err := mysql.ParseDSN(d.DSN); err == nil {
+			return mc.DBName
+		}
+	}
+	return d.Name
+}
+
+// SlogLevel parses Level.
+func (l LogConfig) SlogLevel() (slog.Level, error) {
+	var level slog.Level
+	err := level.UnmarshalText([]byte(l.Level))
+	return level, err
+}
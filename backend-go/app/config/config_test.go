@@ -0,0 +1,113 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+func TestApplyEnv(t *testing.T) {
+	env := map[string]string{
+		"PORT":                "9090",
+		"DB_HOST":             "mysql",
+		"DB_MAX_OPEN_CONNS":   "5",
+		"DB_MAX_IDLE_CONNS":   "2",
+		"SERVER_READ_TIMEOUT": "3s",
+		"AUTH_SESSION_TTL":    "30m",
+		"AUTH_COOKIE_SECURE":  "true",
+		"LOG_FORMAT":          "text",
+		"TRACE_EXPORTER":      "otlp",
+		"TRACE_SAMPLE_RATIO":  "0.25",
+	}
+	cfg := Default()
+	if err := cfg.applyEnv(func(k string) (string, bool) { v, ok := env[k]; return v, ok }); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Server.Port != 9090 || cfg.DB.Host != "mysql" || cfg.DB.MaxOpenConns != 5 || cfg.Server.ReadTimeout != 3*time.Second {
+		t.Errorf("env not applied: %+v", cfg)
+	}
+	if cfg.Auth.SessionTTL != 30*time.Minute || !cfg.Auth.CookieSecure {
+		t.Errorf("auth env not applied: %+v", cfg.Auth)
+	}
+	if cfg.Log.Format != "text" || cfg.Trace.Exporter != "otlp" || cfg.Trace.SampleRatio != 0.25 {
+		t.Errorf("log/trace env not applied: %+v %+v", cfg.Log, cfg.Trace)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("unexpected validation error: %v", err)
+	}
+	if dsn := cfg.DB.FormatDSN(); !strings.Contains(dsn, "tcp(mysql:3306)") || !strings.Contains(dsn, "clientFoundRows=true") {
+		t.Errorf("unexpected DSN %s", dsn)
+	}
+}
+
+func TestDefault_Mail(t *testing.T) {
+	// 設定がなければログ出力に落ちず SMTP で送る
+	if cfg := Default(); cfg.Mail.Driver != "smtp" || cfg.Validate() != nil {
+		t.Errorf("unexpected default mail config %+v", cfg.Mail)
+	}
+}
+
+func TestApplyEnv_Invalid(t *testing.T) {
+	cfg := Default()
+	err := cfg.applyEnv(func(k string) (string, bool) {
+		if k == "PORT" {
+			return "abc", true
+		}
+		return "", false
+	})
+	if err == nil {
+		t.Error("expected error for non-numeric PORT")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cfg := Default()
+	cfg.Server.Port = 0
+	cfg.DB.MaxIdleConns = cfg.DB.MaxOpenConns + 1
+	cfg.Log.Level = "verbose"
+	cfg.Trace.Exporter = "jaeger"
+	cfg.Trace.SampleRatio = 2
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+	for _, want := range []string{"server.port", "max_idle_conns", "log.level", "trace.exporter", "sample_ratio"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %s", err, want)
+		}
+	}
+}
+
+func TestFormatDSN_Override(t *testing.T) {
+	d := Default().DB
+	d.DSN = "user:pass@tcp(db:3306)/other_db?sql_mode=ANSI_QUOTES"
+	mc, err := mysql.ParseDSN(d.FormatDSN())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// DSN を指定しても更新件数と日時の扱いは変わらない
+	if mc.Addr != "db:3306" || mc.DBName != "other_db" || mc.Params["sql_mode"] != "ANSI_QUOTES" {
+		t.Errorf("DSN fields were lost: %+v", mc)
+	}
+	if !mc.ClientFoundRows || !mc.ParseTime || mc.Loc.String() != "Asia/Tokyo" {
+		t.Errorf("options not forced: clientFoundRows=%v parseTime=%v loc=%v", mc.ClientFoundRows, mc.ParseTime, mc.Loc)
+	}
+}
+
+func TestDatabaseName(t *testing.T) {
+	tests := []struct {
+		name string
+		db   DBConfig
+		want string
+	}{
+		{"fields", DBConfig{Name: "app_db"}, "app_db"},
+		{"dsn", DBConfig{Name: "ignored", DSN: "user:pass@tcp(db:3306)/other_db?parseTime=true"}, "other_db"},
+	}
+	for _, tt := range tests {
+		if got := tt.db.DatabaseName(); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -1,137 +1,3417 @@
 // Package controllers provides primitives to interact with the openapi HTTP API.
 //
-// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.6.0 DO NOT EDIT.
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.5.1 DO NOT EDIT.
 package controllers
 
 import (
 	"bytes"
 	"compress/gzip"
+	"context"
 	"encoding/base64"
 	"fmt"
 	"net/http"
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/go-chi/chi/v5"
+	"github.com/oapi-codegen/runtime"
+	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
+const (
+	BearerAuthScopes = "bearerAuth.Scopes"
+	CookieAuthScopes = "cookieAuth.Scopes"
+)
+
+// Defines values for AuditLogAction.
+const (
+	AuditCreate AuditLogAction = "create"
+	AuditDelete AuditLogAction = "delete"
+	AuditUpdate AuditLogAction = "update"
+)
+
+// Defines values for ColumnMetaInputType.
+const (
+	InputCheckbox ColumnMetaInputType = "checkbox"
+	InputDate     ColumnMetaInputType = "date"
+	InputDatetime ColumnMetaInputType = "datetime"
+	InputDecimal  ColumnMetaInputType = "decimal"
+	InputNumber   ColumnMetaInputType = "number"
+	InputSelect   ColumnMetaInputType = "select"
+	InputText     ColumnMetaInputType = "text"
+	InputTextarea ColumnMetaInputType = "textarea"
+)
+
+// Defines values for DayType.
+const (
+	DayTypeClosure    DayType = "closure"
+	DayTypeHoliday    DayType = "holiday"
+	DayTypeWeekday    DayType = "weekday"
+	DayTypeWeekend    DayType = "weekend"
+	DayTypeWorkingDay DayType = "working_day"
+)
+
+// Defines values for EventBusinessDay.
+const (
+	EventBusinessDayNext     EventBusinessDay = "next"
+	EventBusinessDayNone     EventBusinessDay = "none"
+	EventBusinessDayPrevious EventBusinessDay = "previous"
+	EventBusinessDaySkip     EventBusinessDay = "skip"
+)
+
+// Defines values for EventChangeType.
+const (
+	EventChangeCreated EventChangeType = "created"
+	EventChangeDeleted EventChangeType = "deleted"
+	EventChangeReset   EventChangeType = "reset"
+	EventChangeUpdated EventChangeType = "updated"
+)
+
+// Defines values for EventKind.
+const (
+	EventKindAll       EventKind = "all"
+	EventKindPartner   EventKind = "partner"
+	EventKindShipper   EventKind = "shipper"
+	EventKindWarehouse EventKind = "warehouse"
+)
+
+// Defines values for EventSource.
+const (
+	EventSourceBilling        EventSource = "billing"
+	EventSourceClosing        EventSource = "closing"
+	EventSourceClosure        EventSource = "closure"
+	EventSourceHoliday        EventSource = "holiday"
+	EventSourceManual         EventSource = "manual"
+	EventSourceOrderDeadline  EventSource = "order_deadline"
+	EventSourceServiceBilling EventSource = "service_billing"
+	EventSourceWorkingDay     EventSource = "working_day"
+)
+
+// Defines values for EventStatus.
+const (
+	EventStatusDone    EventStatus = "done"
+	EventStatusError   EventStatus = "error"
+	EventStatusInfo    EventStatus = "info"
+	EventStatusNone    EventStatus = ""
+	EventStatusWait    EventStatus = "wait"
+	EventStatusWarning EventStatus = "warning"
+)
+
+// Defines values for ImportJobStatus.
+const (
+	ImportFailed    ImportJobStatus = "failed"
+	ImportQueued    ImportJobStatus = "queued"
+	ImportRunning   ImportJobStatus = "running"
+	ImportSucceeded ImportJobStatus = "succeeded"
+)
+
+// Defines values for ImportMasterRecordsParamsCommit.
+const (
+	ImportCommitAll   ImportMasterRecordsParamsCommit = "all"
+	ImportCommitChunk ImportMasterRecordsParamsCommit = "chunk"
+)
+
+// Defines values for ListUsersParamsSort.
+const (
+	UserSortIDAsc      ListUsersParamsSort = "id"
+	UserSortIDDesc     ListUsersParamsSort = "-id"
+	UserSortNameAsc    ListUsersParamsSort = "name"
+	UserSortNameDesc   ListUsersParamsSort = "-name"
+	UserSortUserIDAsc  ListUsersParamsSort = "user_id"
+	UserSortUserIDDesc ListUsersParamsSort = "-user_id"
+)
+
+// AuditChange defines model for AuditChange.
+type AuditChange struct {
+	// After 変更後の値
+	After interface{} `json:"after"`
+
+	// Before 変更前の値
+	Before interface{} `json:"before"`
+}
+
+// AuditLog defines model for AuditLog.
+type AuditLog struct {
+	// Action delete はユーザの無効化を含む
+	Action AuditLogAction `json:"action"`
+
+	// Changes 変更された列（列名 → 変更前・変更後）。登録では変更前、削除では変更後が null
+	Changes    map[string]AuditChange `json:"changes"`
+	ID         int64                  `json:"id"`
+	OccurredAt time.Time              `json:"occurred_at"`
+
+	// RecordID 対象行のID（グループ権限はグループID）
+	RecordID int64 `json:"record_id"`
+
+	// RequestID 操作したリクエストの X-Request-ID
+	RequestID *string `json:"request_id"`
+	Table     string  `json:"table"`
+
+	// UserID 操作ユーザのユーザマスタID
+	UserID *int64 `json:"user_id"`
+
+	// UserLogin 操作時のユーザID
+	UserLogin *string `json:"user_login"`
+}
+
+// AuditLogAction delete はユーザの無効化を含む
+type AuditLogAction string
+
+// AuditLogsResponseGet defines model for AuditLogsResponseGet.
+type AuditLogsResponseGet struct {
+	Limit  int        `json:"limit"`
+	Logs   []AuditLog `json:"logs"`
+	Offset int        `json:"offset"`
+
+	// Total 検索条件に一致する全件数
+	Total int `json:"total"`
+}
+
+// BusinessDay defines model for BusinessDay.
+type BusinessDay struct {
+	// BusinessDay 営業日か
+	BusinessDay bool               `json:"business_day"`
+	Date        openapi_types.Date `json:"date"`
+
+	// DayType 日の種類。weekday は平日、weekend は土日、holiday は祝日、
+	// closure は休業日、working_day は臨時営業日（営業日カレンダーマスタ）
+	DayType DayType `json:"day_type"`
+
+	// Name 祝日名、または営業日カレンダーマスタの名称
+	Name *string `json:"name"`
+}
+
+// BusinessDaysResponse defines model for BusinessDaysResponse.
+type BusinessDaysResponse struct {
+	Days []BusinessDay `json:"days"`
+}
+
+// CalendarFeed defines model for CalendarFeed.
+type CalendarFeed struct {
+	// Token 配信トークン（この応答でだけ返す）
+	Token string `json:"token"`
+
+	// URL 購読用のURL
+	URL string `json:"url"`
+}
+
+// ChangePasswordRequest defines model for ChangePasswordRequest.
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
+
+// ColumnMeta defines model for ColumnMeta.
+type ColumnMeta struct {
+	// Default 既定値。ない場合や CURRENT_TIMESTAMP などの式の場合は null
+	Default interface{} `json:"default"`
+
+	// InputType 入力欄の種類。select は外部キーで options から選ぶ。
+	// number は整数、decimal は小数（scale 桁まで）、textarea は text 型の列
+	InputType ColumnMetaInputType `json:"input_type"`
+
+	// Label 列の論理名
+	Label string `json:"label"`
+
+	// MaxLength 最大文字数（varchar / char の列だけ）
+	MaxLength *int   `json:"max_length"`
+	Name      string `json:"name"`
+
+	// Options 外部キーの参照先の行（名称順）。input_type が select の列だけ
+	Options *[]ColumnOption `json:"options,omitempty"`
+
+	// Precision 全体の桁数（decimal の列だけ）
+	Precision *int `json:"precision"`
+
+	// ReadOnly 自動採番の列と version（入力しない）
+	ReadOnly bool `json:"read_only"`
+
+	// Required 登録時に省略できない（NOT NULL で既定値がない）
+	Required bool `json:"required"`
+
+	// Scale 小数部の桁数（decimal の列だけ）
+	Scale *int `json:"scale"`
+
+	// Type schema.yaml の型
+	Type string `json:"type"`
+}
+
+// ColumnMetaInputType 入力欄の種類。select は外部キーで options から選ぶ。
+// number は整数、decimal は小数（scale 桁まで）、textarea は text 型の列
+type ColumnMetaInputType string
+
+// ColumnOption defines model for ColumnOption.
+type ColumnOption struct {
+	ID int64 `json:"id"`
+
+	// Name 参照先の名称（name 列がなければ code 列、どちらもなければ id）
+	Name string `json:"name"`
+}
+
+// DayType 日の種類。weekday は平日、weekend は土日、holiday は祝日、
+// closure は休業日、working_day は臨時営業日（営業日カレンダーマスタ）
+type DayType string
+
 // ErrorResponse defines model for ErrorResponse.
 type ErrorResponse struct {
 	Code    int    `json:"code"`
 	Message string `json:"message"`
 }
 
-// User defines model for User.
-type User struct {
-	ID   int    `json:"ID"`
-	Name string `json:"Name"`
-}
+// Event defines model for Event.
+type Event struct {
+	AllDay bool `json:"all_day"`
+
+	// BusinessDay 繰り返しの回が休業日（土日・祝日・全倉庫の休業日）に当たるとき。
+	// none はそのまま、skip はその回を除く、previous は前営業日、next は翌営業日に移す
+	BusinessDay *EventBusinessDay `json:"business_day,omitempty"`
+	Description *string           `json:"description"`
+
+	// End 終了日時。終日のイベントではこの日を含まない
+	End *string `json:"end"`
+
+	// Exdates 繰り返しの除外日（繰り返しイベント本体のみ）
+	Exdates *[]openapi_types.Date `json:"exdates,omitempty"`
+
+	// ID 登録したイベントは数値の ID。導出したイベントは「source-マスタのID-日付」の形で、同じ日付には同じ ID になる
+	ID string `json:"id"`
+
+	// Kind 区分（all は全員向け）
+	Kind EventKind `json:"kind"`
+
+	// Overrides 回ごとの変更（繰り返しイベント本体のみ）
+	Overrides *[]EventOverride `json:"overrides,omitempty"`
+
+	// RecurrenceID 繰り返しイベントの回の場合、その回の本来の日付（休業日による移動や変更の前）
+	RecurrenceID *openapi_types.Date `json:"recurrence_id,omitempty"`
+
+	// Rrule 繰り返しの規則（繰り返しイベント本体のみ）
+	Rrule *string `json:"rrule,omitempty"`
+
+	// SeriesID 繰り返しイベントの回の場合、繰り返しイベントの ID
+	SeriesID *int64 `json:"series_id,omitempty"`
+
+	// ShippingID 対象の荷主ID。null は全荷主共通
+	ShippingID *int64 `json:"shipping_id"`
+
+	// Source イベントの出所。manual は画面から登録したイベント（更新・削除できるのはこれだけ）。
+	// closing は締日、billing は請求日（請求マスタごと。営業日でなければ前営業日）、
+	// order_deadline は受注締切時刻（営業日ごと）、service_billing は利用サービスの請求日、
+	// holiday は祝日、closure と working_day は営業日カレンダーマスタの休業日と臨時営業日
+	Source EventSource `json:"source"`
+	Start  string      `json:"start"`
+
+	// Status 状態。画面の色分けに使う（空文字は未設定）
+	Status EventStatus `json:"status"`
+	Title  string      `json:"title"`
+
+	// Version バージョン（ETag と同じ値。更新のたびに加算）。導出したイベントは 0、繰り返しイベントの回は繰り返しイベントのバージョン
+	Version int64 `json:"version"`
+}
+
+// EventBusinessDay 繰り返しの回が休業日（土日・祝日・全倉庫の休業日）に当たるとき。
+// none はそのまま、skip はその回を除く、previous は前営業日、next は翌営業日に移す
+type EventBusinessDay string
+
+// EventChange /events/stream で送るイベントの変更
+type EventChange struct {
+	Event *Event `json:"event,omitempty"`
+
+	// ID 通知番号（SSE の id と同じ）
+	ID int64 `json:"id"`
+
+	// Type created / updated / deleted はイベントの登録・更新・削除（event は変更後、削除では削除前の内容）。
+	// reset は送り直せない通知があったことを表し、event はない
+	Type EventChangeType `json:"type"`
+}
+
+// EventChangeType created / updated / deleted はイベントの登録・更新・削除（event は変更後、削除では削除前の内容）。
+// reset は送り直せない通知があったことを表し、event はない
+type EventChangeType string
+
+// EventKind 区分（all は全員向け）
+type EventKind string
+
+// EventOccurrenceRequest defines model for EventOccurrenceRequest.
+type EventOccurrenceRequest struct {
+	Description *string `json:"description"`
+
+	// End 終了日時
+	End *string `json:"end"`
+
+	// Start 開始日時（EventRequest の start と同じ形式）
+	Start *string `json:"start"`
+
+	// Status 状態。画面の色分けに使う（空文字は未設定）
+	Status *EventStatus `json:"status,omitempty"`
+	Title  *string      `json:"title"`
+}
+
+// EventOverride defines model for EventOverride.
+type EventOverride struct {
+	Description *string `json:"description,omitempty"`
+	End         *string `json:"end,omitempty"`
+
+	// RecurrenceID 変更した回の本来の日付
+	RecurrenceID openapi_types.Date `json:"recurrence_id"`
+	Start        *string            `json:"start,omitempty"`
+
+	// Status 状態。画面の色分けに使う（空文字は未設定）
+	Status *EventStatus `json:"status,omitempty"`
+	Title  *string      `json:"title,omitempty"`
+}
+
+// EventRequest defines model for EventRequest.
+type EventRequest struct {
+	AllDay *bool `json:"all_day,omitempty"`
+
+	// BusinessDay 繰り返しの回が休業日（土日・祝日・全倉庫の休業日）に当たるとき。
+	// none はそのまま、skip はその回を除く、previous は前営業日、next は翌営業日に移す
+	BusinessDay *EventBusinessDay `json:"business_day,omitempty"`
+	Description *string           `json:"description"`
+
+	// End 終了日時（start と同じ形式）。開始日時より前にはできない
+	End *string `json:"end"`
+
+	// Exdates 繰り返しの除外日（本来の日付）
+	Exdates *[]openapi_types.Date `json:"exdates,omitempty"`
+
+	// Kind 区分（all は全員向け）
+	Kind *EventKind `json:"kind,omitempty"`
+
+	// Rrule 繰り返しの規則（RFC 5545 の RRULE）。FREQ は DAILY / WEEKLY / MONTHLY / YEARLY で、
+	// INTERVAL, COUNT, UNTIL, BYDAY（2MO, -1FR など）, BYMONTHDAY（-1 は月末）, BYMONTH, WKST を使える。
+	// 最初の回は start
+	Rrule *string `json:"rrule"`
+
+	// ShippingID 対象の荷主ID。省略時は全荷主共通（荷主側ユーザは自分の荷主）
+	ShippingID *int64 `json:"shipping_id"`
+
+	// Start 開始日時（YYYY-MM-DDThh:mm:ss または RFC 3339）。終日のイベントは YYYY-MM-DD
+	Start string `json:"start"`
+
+	// Status 状態。画面の色分けに使う（空文字は未設定）
+	Status *EventStatus `json:"status,omitempty"`
+	Title  string       `json:"title"`
+}
+
+// EventSource イベントの出所。manual は画面から登録したイベント（更新・削除できるのはこれだけ）。
+// closing は締日、billing は請求日（請求マスタごと。営業日でなければ前営業日）、
+// order_deadline は受注締切時刻（営業日ごと）、service_billing は利用サービスの請求日、
+// holiday は祝日、closure と working_day は営業日カレンダーマスタの休業日と臨時営業日
+type EventSource string
+
+// EventStatus 状態。画面の色分けに使う（空文字は未設定）
+type EventStatus string
+
+// EventsResponse defines model for EventsResponse.
+type EventsResponse struct {
+	// Count イベントの件数
+	Count  int     `json:"count"`
+	Events []Event `json:"events"`
+}
+
+// GroupPermissions defines model for GroupPermissions.
+type GroupPermissions struct {
+	GroupID int64 `json:"group_id"`
+
+	// Permissions 権限コード（昇順）
+	Permissions []string `json:"permissions"`
+
+	// Version グループのバージョン（ETag と同じ値。権限の設定のたびに加算）
+	Version int64 `json:"version"`
+}
+
+// GroupPermissionsRequestPut defines model for GroupPermissionsRequestPut.
+type GroupPermissionsRequestPut struct {
+	// Permissions 付与する権限コード。空配列ですべての権限を外す
+	Permissions []string `json:"permissions"`
+}
+
+// ImportError defines model for ImportError.
+type ImportError struct {
+	// Column エラーの列名。行全体のエラー（重複・参照整合性など）では null
+	Column  *string `json:"column"`
+	Message string  `json:"message"`
+
+	// Row ファイル上の行番号（見出しが1行目）
+	Row int `json:"row"`
+}
+
+// ImportJob defines model for ImportJob.
+type ImportJob struct {
+	CreatedAt time.Time `json:"created_at"`
+
+	// Error 取込を続けられなかった理由（failed のときだけ）
+	Error      *string    `json:"error"`
+	FinishedAt *time.Time `json:"finished_at"`
+	ID         string     `json:"id"`
+
+	// ProcessedRows 検証・書き込みを終えた行数
+	ProcessedRows int `json:"processed_rows"`
+
+	// Result 取込結果（succeeded のときだけ）
+	Result    *ImportResult   `json:"result"`
+	Status    ImportJobStatus `json:"status"`
+	Table     string          `json:"table"`
+	TotalRows int             `json:"total_rows"`
+}
+
+// ImportJobStatus defines model for ImportJob.Status.
+type ImportJobStatus string
+
+// ImportResult defines model for ImportResult.
+type ImportResult struct {
+	DryRun bool `json:"dry_run"`
+
+	// ErrorCount エラーの総数
+	ErrorCount int `json:"error_count"`
+
+	// Errors 行ごとのエラー（行番号順、先頭の 1000 件まで）
+	Errors []ImportError `json:"errors"`
+
+	// Inserted 登録した行数（dry_run では登録できる行数）
+	Inserted int `json:"inserted"`
+
+	// Skipped エラーのため、または同じ単位にエラーの行があったため書き込まなかった行数
+	Skipped int `json:"skipped"`
+
+	// TotalRows データ行の数（見出しと空行を除く）
+	TotalRows int `json:"total_rows"`
+
+	// Updated 更新した行数（dry_run では更新できる行数）
+	Updated int `json:"updated"`
+}
+
+// LoginRequest defines model for LoginRequest.
+type LoginRequest struct {
+	// OtpCode 認証アプリの6桁のコード（二要素認証有効時）
+	OtpCode  *string `json:"otp_code,omitempty"`
+	Password string  `json:"password"`
+
+	// RecoveryCode 認証アプリを使えない場合のリカバリーコード（1回限り）
+	RecoveryCode *string `json:"recovery_code,omitempty"`
+	UserID       string  `json:"user_id"`
+}
+
+// LoginResponse defines model for LoginResponse.
+type LoginResponse struct {
+	ExpiresAt time.Time `json:"expires_at"`
+
+	// Token Authorization: Bearer ヘッダに指定するセッショントークン
+	Token string `json:"token"`
+	User  User   `json:"user"`
+}
+
+// MasterRecord defines model for MasterRecord.
+type MasterRecord map[string]interface{}
+
+// MasterRecordsResponseGet defines model for MasterRecordsResponseGet.
+type MasterRecordsResponseGet struct {
+	Limit   int            `json:"limit"`
+	Offset  int            `json:"offset"`
+	Records []MasterRecord `json:"records"`
+
+	// Total 全件数
+	Total int `json:"total"`
+}
+
+// PasswordConfirmRequest defines model for PasswordConfirmRequest.
+type PasswordConfirmRequest struct {
+	Password string `json:"password"`
+}
+
+// PasswordResetConfirmRequest defines model for PasswordResetConfirmRequest.
+type PasswordResetConfirmRequest struct {
+	NewPassword string `json:"new_password"`
+	Token       string `json:"token"`
+}
+
+// PasswordResetRequest defines model for PasswordResetRequest.
+type PasswordResetRequest struct {
+	Email openapi_types.Email `json:"email"`
+}
+
+// Permission defines model for Permission.
+type Permission struct {
+	// Code 権限コード（x-permission の値）
+	Code string `json:"code"`
+	ID   int64  `json:"id"`
+
+	// Name 権限名
+	Name string `json:"name"`
+}
+
+// PermissionsResponseGet defines model for PermissionsResponseGet.
+type PermissionsResponseGet struct {
+	Permissions []Permission `json:"permissions"`
+}
+
+// RecoveryCodesResponse defines model for RecoveryCodesResponse.
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// Session defines model for Session.
+type Session struct {
+	CreatedAt time.Time `json:"created_at"`
+
+	// Current このリクエストのセッションかどうか
+	Current   bool      `json:"current"`
+	ExpiresAt time.Time `json:"expires_at"`
+	ID        int64     `json:"id"`
+	IPAddress *string   `json:"ip_address,omitempty"`
+	UserAgent *string   `json:"user_agent,omitempty"`
+}
+
+// SessionsResponseGet defines model for SessionsResponseGet.
+type SessionsResponseGet struct {
+	Sessions []Session `json:"sessions"`
+}
+
+// TableMeta defines model for TableMeta.
+type TableMeta struct {
+	// Columns 公開されている列（schema.yaml の順）
+	Columns []ColumnMeta `json:"columns"`
+
+	// Label テーブルの論理名
+	Label string `json:"label"`
+	Name  string `json:"name"`
+}
+
+// TotpCodeRequest defines model for TotpCodeRequest.
+type TotpCodeRequest struct {
+	Code string `json:"code"`
+}
+
+// TotpEnrollment defines model for TotpEnrollment.
+type TotpEnrollment struct {
+	// OtpauthURI otpauth://totp/... 形式の URI
+	OtpauthURI string `json:"otpauth_uri"`
+
+	// QrPng otpauth_uri の QR コード（PNG、Base64）
+	QrPng []byte `json:"qr_png"`
+
+	// Secret 手入力用の Base32 シークレット
+	Secret string `json:"secret"`
+}
+
+// User defines model for User.
+type User struct {
+	// DepartmentID 所属ID
+	DepartmentID int64 `json:"department_id"`
+
+	// DepartmentName 所属名称
+	DepartmentName string `json:"department_name"`
+
+	// Email メールアドレス
+	Email string `json:"email"`
+
+	// GroupID グループID
+	GroupID int64 `json:"group_id"`
+
+	// GroupName グループ名
+	GroupName string `json:"group_name"`
+
+	// ID ID
+	ID int64 `json:"id"`
+
+	// LockedUntil アカウントロック解除日時（ロック中のみ）
+	LockedUntil *time.Time `json:"locked_until,omitempty"`
+
+	// Name ユーザ名
+	Name string `json:"name"`
+
+	// ShippingID 荷主ID（荷主側ユーザのみ。null は倉庫側ユーザ）
+	ShippingID *int64 `json:"shipping_id"`
+
+	// TotpEnabled 二要素認証有効
+	TotpEnabled bool `json:"totp_enabled"`
+
+	// UserID ユーザID
+	UserID string `json:"user_id"`
+
+	// ValidFlag 有効無効
+	ValidFlag bool `json:"valid_flag"`
+
+	// Version バージョン（ETag と同じ値。更新のたびに加算）
+	Version int64 `json:"version"`
+}
+
+// UsersRequestPatch defines model for UsersRequestPatch.
+type UsersRequestPatch struct {
+	DepartmentID *int64               `json:"department_id,omitempty"`
+	Email        *openapi_types.Email `json:"email,omitempty"`
+	GroupID      *int64               `json:"group_id,omitempty"`
+	Name         *string              `json:"name,omitempty"`
+
+	// Password ログインパスワード（bcryptでハッシュ化して保存。省略時は変更しない）
+	Password *string `json:"password,omitempty"`
+
+	// ShippingID 荷主ID。省略・null の場合は変更しない（倉庫側ユーザに戻すには PUT を使う）
+	ShippingID *int64  `json:"shipping_id"`
+	UserID     *string `json:"user_id,omitempty"`
+	ValidFlag  *bool   `json:"valid_flag,omitempty"`
+}
+
+// UsersRequestPost defines model for UsersRequestPost.
+type UsersRequestPost struct {
+	DepartmentID int64               `json:"department_id"`
+	Email        openapi_types.Email `json:"email"`
+	GroupID      int64               `json:"group_id"`
+	Name         string              `json:"name"`
+
+	// Password ログインパスワード（bcryptでハッシュ化して保存。省略時は変更しない）
+	Password *string `json:"password,omitempty"`
+
+	// ShippingID 荷主ID（荷主側ユーザのみ）。荷主側ユーザが登録する場合は省略すると自分の荷主になり、他の荷主は指定できない
+	ShippingID *int64 `json:"shipping_id"`
+	UserID     string `json:"user_id"`
+	ValidFlag  *bool  `json:"valid_flag,omitempty"`
+}
+
+// UsersRequestPut defines model for UsersRequestPut.
+type UsersRequestPut struct {
+	DepartmentID int64               `json:"department_id"`
+	Email        openapi_types.Email `json:"email"`
+	GroupID      int64               `json:"group_id"`
+	Name         string              `json:"name"`
+
+	// Password ログインパスワード（bcryptでハッシュ化して保存。省略時は変更しない）
+	Password *string `json:"password,omitempty"`
+
+	// ShippingID 荷主ID（荷主側ユーザのみ）。省略・null は倉庫側ユーザ。荷主側ユーザが更新する場合は自分の荷主に固定される
+	ShippingID *int64 `json:"shipping_id"`
+	UserID     string `json:"user_id"`
+	ValidFlag  bool   `json:"valid_flag"`
+}
+
+// UsersResponseGet defines model for UsersResponseGet.
+type UsersResponseGet struct {
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+
+	// Total 検索条件に一致する全件数
+	Total int    `json:"total"`
+	Users []User `json:"users"`
+}
+
+// UsersResponsePost defines model for UsersResponsePost.
+type UsersResponsePost = User
+
+// UsersResponsePut defines model for UsersResponsePut.
+type UsersResponsePut = User
+
+// AuditFrom defines model for AuditFrom.
+type AuditFrom = openapi_types.Date
+
+// AuditRecordID defines model for AuditRecordID.
+type AuditRecordID = int64
+
+// AuditTable defines model for AuditTable.
+type AuditTable = string
+
+// AuditTo defines model for AuditTo.
+type AuditTo = openapi_types.Date
+
+// AuditUserID defines model for AuditUserID.
+type AuditUserID = int64
+
+// BusinessDate defines model for BusinessDate.
+type BusinessDate = openapi_types.Date
+
+// IfMatch defines model for IfMatch.
+type IfMatch = string
+
+// Limit defines model for Limit.
+type Limit = int
+
+// MasterTableName defines model for MasterTableName.
+type MasterTableName = string
+
+// Offset defines model for Offset.
+type Offset = int
+
+// ResourceID defines model for ResourceID.
+type ResourceID = int64
+
+// Warehouse defines model for Warehouse.
+type Warehouse = string
+
+// BadRequest defines model for BadRequest.
+type BadRequest = ErrorResponse
+
+// Conflict defines model for Conflict.
+type Conflict = ErrorResponse
+
+// Forbidden defines model for Forbidden.
+type Forbidden = ErrorResponse
+
+// NotFound defines model for NotFound.
+type NotFound = ErrorResponse
+
+// PreconditionRequired defines model for PreconditionRequired.
+type PreconditionRequired = ErrorResponse
+
+// TooManyRequests defines model for TooManyRequests.
+type TooManyRequests = ErrorResponse
+
+// Unauthorized defines model for Unauthorized.
+type Unauthorized = ErrorResponse
+
+// UnprocessableEntity defines model for UnprocessableEntity.
+type UnprocessableEntity = ErrorResponse
+
+// ListAuditLogsParams defines parameters for ListAuditLogs.
+type ListAuditLogsParams struct {
+	// Limit 取得件数
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset 取得開始位置
+	Offset *Offset `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Table テーブル名で絞り込み（users_master、group_permissions、各マスタ）
+	Table *AuditTable `form:"table,omitempty" json:"table,omitempty"`
+
+	// RecordID 対象行のIDで絞り込み（グループ権限はグループID）
+	RecordID *AuditRecordID `form:"record_id,omitempty" json:"record_id,omitempty"`
+
+	// UserID 操作ユーザ（ユーザマスタID）で絞り込み
+	UserID *AuditUserID `form:"user_id,omitempty" json:"user_id,omitempty"`
+
+	// From 操作日の範囲の開始（この日を含む）
+	From *AuditFrom `form:"from,omitempty" json:"from,omitempty"`
+
+	// To 操作日の範囲の終了（この日を含む）
+	To *AuditTo `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// ExportAuditLogsParams defines parameters for ExportAuditLogs.
+type ExportAuditLogsParams struct {
+	// Table テーブル名で絞り込み（users_master、group_permissions、各マスタ）
+	Table *AuditTable `form:"table,omitempty" json:"table,omitempty"`
+
+	// RecordID 対象行のIDで絞り込み（グループ権限はグループID）
+	RecordID *AuditRecordID `form:"record_id,omitempty" json:"record_id,omitempty"`
+
+	// UserID 操作ユーザ（ユーザマスタID）で絞り込み
+	UserID *AuditUserID `form:"user_id,omitempty" json:"user_id,omitempty"`
+
+	// From 操作日の範囲の開始（この日を含む）
+	From *AuditFrom `form:"from,omitempty" json:"from,omitempty"`
+
+	// To 操作日の範囲の終了（この日を含む）
+	To *AuditTo `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// ListBusinessDaysParams defines parameters for ListBusinessDays.
+type ListBusinessDaysParams struct {
+	// From 期間の開始日（この日を含む）
+	From openapi_types.Date `form:"from" json:"from"`
+
+	// To 期間の終了日（この日を含む）。開始日から366日まで
+	To openapi_types.Date `form:"to" json:"to"`
+
+	// Warehouse 倉庫コード。省略時は全倉庫共通の休業日・臨時営業日だけを使う
+	Warehouse *Warehouse `form:"warehouse,omitempty" json:"warehouse,omitempty"`
+}
+
+// GetBusinessDayParams defines parameters for GetBusinessDay.
+type GetBusinessDayParams struct {
+	// Warehouse 倉庫コード。省略時は全倉庫共通の休業日・臨時営業日だけを使う
+	Warehouse *Warehouse `form:"warehouse,omitempty" json:"warehouse,omitempty"`
+}
+
+// AddBusinessDaysParams defines parameters for AddBusinessDays.
+type AddBusinessDaysParams struct {
+	// Days 営業日数
+	Days int `form:"days" json:"days"`
+
+	// Warehouse 倉庫コード。省略時は全倉庫共通の休業日・臨時営業日だけを使う
+	Warehouse *Warehouse `form:"warehouse,omitempty" json:"warehouse,omitempty"`
+}
+
+// GetNextBusinessDayParams defines parameters for GetNextBusinessDay.
+type GetNextBusinessDayParams struct {
+	// Warehouse 倉庫コード。省略時は全倉庫共通の休業日・臨時営業日だけを使う
+	Warehouse *Warehouse `form:"warehouse,omitempty" json:"warehouse,omitempty"`
+}
+
+// GetCalendarFeedParams defines parameters for GetCalendarFeed.
+type GetCalendarFeedParams struct {
+	// Token 配信トークン（アクセスログに残らないよう、パスではなくクエリで渡す）
+	Token string `form:"token" json:"token"`
+
+	// Status 状態で絞り込み（複数指定可）
+	Status *[]EventStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// Kind 区分で絞り込み
+	Kind *EventKind `form:"kind,omitempty" json:"kind,omitempty"`
+}
+
+// ListEventsParams defines parameters for ListEvents.
+type ListEventsParams struct {
+	// From 期間の開始日（この日を含む）
+	From *openapi_types.Date `form:"from,omitempty" json:"from,omitempty"`
+
+	// To 期間の終了日（この日を含む）
+	To *openapi_types.Date `form:"to,omitempty" json:"to,omitempty"`
+
+	// Status 状態で絞り込み（複数指定可）
+	Status *[]EventStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// Kind 区分で絞り込み
+	Kind *EventKind `form:"kind,omitempty" json:"kind,omitempty"`
+
+	// Derived マスタから導出したイベントを含めるか
+	Derived *bool `form:"derived,omitempty" json:"derived,omitempty"`
+}
+
+// StreamEventsParams defines parameters for StreamEvents.
+type StreamEventsParams struct {
+	// Kind 区分で絞り込み
+	Kind *EventKind `form:"kind,omitempty" json:"kind,omitempty"`
+
+	// LastEventID 最後に受け取った通知の id（EventSource が再接続時に自動で送る）
+	LastEventID *string `json:"Last-Event-ID,omitempty"`
+}
+
+// DeleteEventParams defines parameters for DeleteEvent.
+type DeleteEventParams struct {
+	// IfMatch 取得時の ETag。他の利用者が先に更新していれば 412 になる。省略すると 428
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// UpdateEventParams defines parameters for UpdateEvent.
+type UpdateEventParams struct {
+	// IfMatch 取得時の ETag。他の利用者が先に更新していれば 412 になる。省略すると 428
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// CancelEventOccurrenceParams defines parameters for CancelEventOccurrence.
+type CancelEventOccurrenceParams struct {
+	// IfMatch 取得時の ETag。他の利用者が先に更新していれば 412 になる。省略すると 428
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// UpdateEventOccurrenceParams defines parameters for UpdateEventOccurrence.
+type UpdateEventOccurrenceParams struct {
+	// IfMatch 取得時の ETag。他の利用者が先に更新していれば 412 になる。省略すると 428
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// UpdateGroupPermissionsParams defines parameters for UpdateGroupPermissions.
+type UpdateGroupPermissionsParams struct {
+	// IfMatch 取得時の ETag。他の利用者が先に更新していれば 412 になる。省略すると 428
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// ListMasterRecordsParams defines parameters for ListMasterRecords.
+type ListMasterRecordsParams struct {
+	// Limit 取得件数
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset 取得開始位置
+	Offset *Offset `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Sort 並び順の列名（先頭に - を付けると降順）。省略時は id の昇順
+	Sort *string `form:"sort,omitempty" json:"sort,omitempty"`
+}
+
+// ImportMasterRecordsParams defines parameters for ImportMasterRecords.
+type ImportMasterRecordsParams struct {
+	// DryRun true のときは検証だけを行い、何も書き込まない
+	DryRun *bool `form:"dry_run,omitempty" json:"dry_run,omitempty"`
+
+	// Commit all はエラーが1行でもあれば何も書き込まない。
+	// chunk は import.chunk_size 行ごとにコミットし、エラーのある行を含む単位だけを書き込まない
+	Commit *ImportMasterRecordsParamsCommit `form:"commit,omitempty" json:"commit,omitempty"`
+
+	// Sheet Excel のシート名。省略時は先頭のシート
+	Sheet *string `form:"sheet,omitempty" json:"sheet,omitempty"`
+}
+
+// ImportMasterRecordsParamsCommit defines parameters for ImportMasterRecords.
+type ImportMasterRecordsParamsCommit string
+
+// DeleteMasterRecordParams defines parameters for DeleteMasterRecord.
+type DeleteMasterRecordParams struct {
+	// IfMatch 取得時の ETag。他の利用者が先に更新していれば 412 になる。省略すると 428
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// UpdateMasterRecordParams defines parameters for UpdateMasterRecord.
+type UpdateMasterRecordParams struct {
+	// IfMatch 取得時の ETag。他の利用者が先に更新していれば 412 になる。省略すると 428
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// ListUsersParams defines parameters for ListUsers.
+type ListUsersParams struct {
+	// Limit 取得件数
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset 取得開始位置
+	Offset *Offset `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Q ユーザ名・ユーザID・メールアドレスの部分一致検索
+	Q *string `form:"q,omitempty" json:"q,omitempty"`
+
+	// GroupID グループIDで絞り込み
+	GroupID *int64 `form:"group_id,omitempty" json:"group_id,omitempty"`
+
+	// DepartmentID 所属IDで絞り込み
+	DepartmentID *int64 `form:"department_id,omitempty" json:"department_id,omitempty"`
+
+	// ValidFlag 有効無効で絞り込み（省略時は全件）
+	ValidFlag *bool `form:"valid_flag,omitempty" json:"valid_flag,omitempty"`
+
+	// Sort 並び順（先頭に - を付けると降順）
+	Sort *ListUsersParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
+}
+
+// ListUsersParamsSort defines parameters for ListUsers.
+type ListUsersParamsSort string
+
+// DeleteUserParams defines parameters for DeleteUser.
+type DeleteUserParams struct {
+	// IfMatch 取得時の ETag。他の利用者が先に更新していれば 412 になる。省略すると 428
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// PatchUserParams defines parameters for PatchUser.
+type PatchUserParams struct {
+	// IfMatch 取得時の ETag。他の利用者が先に更新していれば 412 になる。省略すると 428
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// UpdateUserParams defines parameters for UpdateUser.
+type UpdateUserParams struct {
+	// IfMatch 取得時の ETag。他の利用者が先に更新していれば 412 になる。省略すると 428
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// LoginJSONRequestBody defines body for Login for application/json ContentType.
+type LoginJSONRequestBody = LoginRequest
+
+// ChangePasswordJSONRequestBody defines body for ChangePassword for application/json ContentType.
+type ChangePasswordJSONRequestBody = ChangePasswordRequest
+
+// RequestPasswordResetJSONRequestBody defines body for RequestPasswordReset for application/json ContentType.
+type RequestPasswordResetJSONRequestBody = PasswordResetRequest
+
+// ConfirmPasswordResetJSONRequestBody defines body for ConfirmPasswordReset for application/json ContentType.
+type ConfirmPasswordResetJSONRequestBody = PasswordResetConfirmRequest
+
+// DisableTotpJSONRequestBody defines body for DisableTotp for application/json ContentType.
+type DisableTotpJSONRequestBody = PasswordConfirmRequest
+
+// RegenerateRecoveryCodesJSONRequestBody defines body for RegenerateRecoveryCodes for application/json ContentType.
+type RegenerateRecoveryCodesJSONRequestBody = TotpCodeRequest
+
+// VerifyTotpEnrollmentJSONRequestBody defines body for VerifyTotpEnrollment for application/json ContentType.
+type VerifyTotpEnrollmentJSONRequestBody = TotpCodeRequest
+
+// CreateEventJSONRequestBody defines body for CreateEvent for application/json ContentType.
+type CreateEventJSONRequestBody = EventRequest
+
+// UpdateEventJSONRequestBody defines body for UpdateEvent for application/json ContentType.
+type UpdateEventJSONRequestBody = EventRequest
+
+// UpdateEventOccurrenceJSONRequestBody defines body for UpdateEventOccurrence for application/json ContentType.
+type UpdateEventOccurrenceJSONRequestBody = EventOccurrenceRequest
+
+// UpdateGroupPermissionsJSONRequestBody defines body for UpdateGroupPermissions for application/json ContentType.
+type UpdateGroupPermissionsJSONRequestBody = GroupPermissionsRequestPut
+
+// CreateMasterRecordJSONRequestBody defines body for CreateMasterRecord for application/json ContentType.
+type CreateMasterRecordJSONRequestBody = MasterRecord
+
+// UpdateMasterRecordJSONRequestBody defines body for UpdateMasterRecord for application/json ContentType.
+type UpdateMasterRecordJSONRequestBody = MasterRecord
+
+// CreateUserJSONRequestBody defines body for CreateUser for application/json ContentType.
+type CreateUserJSONRequestBody = UsersRequestPost
+
+// PatchUserJSONRequestBody defines body for PatchUser for application/json ContentType.
+type PatchUserJSONRequestBody = UsersRequestPatch
+
+// UpdateUserJSONRequestBody defines body for UpdateUser for application/json ContentType.
+type UpdateUserJSONRequestBody = UsersRequestPut
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// 監査ログ一覧取得
+	// (GET /audit)
+	ListAuditLogs(w http.ResponseWriter, r *http.Request, params ListAuditLogsParams)
+	// 監査ログの CSV 出力
+	// (GET /audit/export)
+	ExportAuditLogs(w http.ResponseWriter, r *http.Request, params ExportAuditLogsParams)
+	// 監査ログ取得
+	// (GET /audit/{id})
+	GetAuditLog(w http.ResponseWriter, r *http.Request, id ResourceID)
+	// カレンダー配信URLの失効
+	// (DELETE /auth/calendar-feed)
+	DeleteCalendarFeed(w http.ResponseWriter, r *http.Request)
+	// カレンダー配信URLの発行
+	// (POST /auth/calendar-feed)
+	CreateCalendarFeed(w http.ResponseWriter, r *http.Request)
+	// ログイン
+	// (POST /auth/login)
+	Login(w http.ResponseWriter, r *http.Request)
+	// ログアウト
+	// (POST /auth/logout)
+	Logout(w http.ResponseWriter, r *http.Request)
+	// ログイン中のユーザ取得
+	// (GET /auth/me)
+	GetCurrentUser(w http.ResponseWriter, r *http.Request)
+	// パスワード変更
+	// (PUT /auth/password)
+	ChangePassword(w http.ResponseWriter, r *http.Request)
+	// パスワード再設定メール送信
+	// (POST /auth/password-reset)
+	RequestPasswordReset(w http.ResponseWriter, r *http.Request)
+	// パスワード再設定
+	// (POST /auth/password-reset/confirm)
+	ConfirmPasswordReset(w http.ResponseWriter, r *http.Request)
+	// 有効なセッション一覧
+	// (GET /auth/sessions)
+	ListSessions(w http.ResponseWriter, r *http.Request)
+	// セッション失効
+	// (DELETE /auth/sessions/{id})
+	RevokeSession(w http.ResponseWriter, r *http.Request, id ResourceID)
+	// 二要素認証の登録開始
+	// (POST /auth/totp)
+	StartTotpEnrollment(w http.ResponseWriter, r *http.Request)
+	// 二要素認証の解除
+	// (POST /auth/totp/disable)
+	DisableTotp(w http.ResponseWriter, r *http.Request)
+	// リカバリーコードの再発行
+	// (POST /auth/totp/recovery-codes)
+	RegenerateRecoveryCodes(w http.ResponseWriter, r *http.Request)
+	// 二要素認証の登録確認
+	// (POST /auth/totp/verify)
+	VerifyTotpEnrollment(w http.ResponseWriter, r *http.Request)
+	// 営業日一覧
+	// (GET /business-days)
+	ListBusinessDays(w http.ResponseWriter, r *http.Request, params ListBusinessDaysParams)
+	// 営業日判定
+	// (GET /business-days/{date})
+	GetBusinessDay(w http.ResponseWriter, r *http.Request, date BusinessDate, params GetBusinessDayParams)
+	// 営業日の加算
+	// (GET /business-days/{date}/add)
+	AddBusinessDays(w http.ResponseWriter, r *http.Request, date BusinessDate, params AddBusinessDaysParams)
+	// 翌営業日
+	// (GET /business-days/{date}/next)
+	GetNextBusinessDay(w http.ResponseWriter, r *http.Request, date BusinessDate, params GetNextBusinessDayParams)
+	// iCalendar 配信
+	// (GET /calendar-feed.ics)
+	GetCalendarFeed(w http.ResponseWriter, r *http.Request, params GetCalendarFeedParams)
+	// イベント一覧取得
+	// (GET /events)
+	ListEvents(w http.ResponseWriter, r *http.Request, params ListEventsParams)
+	// イベント登録
+	// (POST /events)
+	CreateEvent(w http.ResponseWriter, r *http.Request)
+	// イベントの変更通知（Server-Sent Events）
+	// (GET /events/stream)
+	StreamEvents(w http.ResponseWriter, r *http.Request, params StreamEventsParams)
+	// イベント削除
+	// (DELETE /events/{id})
+	DeleteEvent(w http.ResponseWriter, r *http.Request, id ResourceID, params DeleteEventParams)
+	// イベント取得
+	// (GET /events/{id})
+	GetEvent(w http.ResponseWriter, r *http.Request, id ResourceID)
+	// イベント更新
+	// (PUT /events/{id})
+	UpdateEvent(w http.ResponseWriter, r *http.Request, id ResourceID, params UpdateEventParams)
+	// 繰り返しイベントの回の取り消し
+	// (DELETE /events/{id}/occurrences/{date})
+	CancelEventOccurrence(w http.ResponseWriter, r *http.Request, id ResourceID, date openapi_types.Date, params CancelEventOccurrenceParams)
+	// 繰り返しイベントの回の変更
+	// (PUT /events/{id}/occurrences/{date})
+	UpdateEventOccurrence(w http.ResponseWriter, r *http.Request, id ResourceID, date openapi_types.Date, params UpdateEventOccurrenceParams)
+	// グループの権限取得
+	// (GET /groups/{id}/permissions)
+	GetGroupPermissions(w http.ResponseWriter, r *http.Request, id ResourceID)
+	// グループの権限設定
+	// (PUT /groups/{id}/permissions)
+	UpdateGroupPermissions(w http.ResponseWriter, r *http.Request, id ResourceID, params UpdateGroupPermissionsParams)
+	// 一括取込ジョブの進捗取得
+	// (GET /import-jobs/{id})
+	GetImportJob(w http.ResponseWriter, r *http.Request, id string)
+	// マスタ一覧取得
+	// (GET /masters/{table})
+	ListMasterRecords(w http.ResponseWriter, r *http.Request, table MasterTableName, params ListMasterRecordsParams)
+	// マスタ登録
+	// (POST /masters/{table})
+	CreateMasterRecord(w http.ResponseWriter, r *http.Request, table MasterTableName)
+	// マスタの一括取込
+	// (POST /masters/{table}/import)
+	ImportMasterRecords(w http.ResponseWriter, r *http.Request, table MasterTableName, params ImportMasterRecordsParams)
+	// マスタ削除
+	// (DELETE /masters/{table}/{id})
+	DeleteMasterRecord(w http.ResponseWriter, r *http.Request, table MasterTableName, id ResourceID, params DeleteMasterRecordParams)
+	// マスタ取得
+	// (GET /masters/{table}/{id})
+	GetMasterRecord(w http.ResponseWriter, r *http.Request, table MasterTableName, id ResourceID)
+	// マスタ更新
+	// (PUT /masters/{table}/{id})
+	UpdateMasterRecord(w http.ResponseWriter, r *http.Request, table MasterTableName, id ResourceID, params UpdateMasterRecordParams)
+	// マスタの入力項目取得
+	// (GET /meta/tables/{table})
+	GetTableMeta(w http.ResponseWriter, r *http.Request, table MasterTableName)
+	// 権限一覧取得
+	// (GET /permissions)
+	ListPermissions(w http.ResponseWriter, r *http.Request)
+	// ユーザ一覧取得
+	// (GET /users)
+	ListUsers(w http.ResponseWriter, r *http.Request, params ListUsersParams)
+	// ユーザ登録
+	// (POST /users)
+	CreateUser(w http.ResponseWriter, r *http.Request)
+	// ユーザ無効化
+	// (DELETE /users/{id})
+	DeleteUser(w http.ResponseWriter, r *http.Request, id ResourceID, params DeleteUserParams)
+	// ユーザ取得
+	// (GET /users/{id})
+	GetUser(w http.ResponseWriter, r *http.Request, id ResourceID)
+	// ユーザ部分更新
+	// (PATCH /users/{id})
+	PatchUser(w http.ResponseWriter, r *http.Request, id ResourceID, params PatchUserParams)
+	// ユーザ更新
+	// (PUT /users/{id})
+	UpdateUser(w http.ResponseWriter, r *http.Request, id ResourceID, params UpdateUserParams)
+	// アカウントロック解除
+	// (DELETE /users/{id}/lock)
+	UnlockUser(w http.ResponseWriter, r *http.Request, id ResourceID)
+	// ユーザの全セッション失効
+	// (DELETE /users/{id}/sessions)
+	RevokeUserSessions(w http.ResponseWriter, r *http.Request, id ResourceID)
+}
+
+// Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
+
+type Unimplemented struct{}
+
+// 監査ログ一覧取得
+// (GET /audit)
+func (_ Unimplemented) ListAuditLogs(w http.ResponseWriter, r *http.Request, params ListAuditLogsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// 監査ログの CSV 出力
+// (GET /audit/export)
+func (_ Unimplemented) ExportAuditLogs(w http.ResponseWriter, r *http.Request, params ExportAuditLogsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// 監査ログ取得
+// (GET /audit/{id})
+func (_ Unimplemented) GetAuditLog(w http.ResponseWriter, r *http.Request, id ResourceID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// カレンダー配信URLの失効
+// (DELETE /auth/calendar-feed)
+func (_ Unimplemented) DeleteCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// カレンダー配信URLの発行
+// (POST /auth/calendar-feed)
+func (_ Unimplemented) CreateCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// ログイン
+// (POST /auth/login)
+func (_ Unimplemented) Login(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// ログアウト
+// (POST /auth/logout)
+func (_ Unimplemented) Logout(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// ログイン中のユーザ取得
+// (GET /auth/me)
+func (_ Unimplemented) GetCurrentUser(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// パスワード変更
+// (PUT /auth/password)
+func (_ Unimplemented) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// パスワード再設定メール送信
+// (POST /auth/password-reset)
+func (_ Unimplemented) RequestPasswordReset(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// パスワード再設定
+// (POST /auth/password-reset/confirm)
+func (_ Unimplemented) ConfirmPasswordReset(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// 有効なセッション一覧
+// (GET /auth/sessions)
+func (_ Unimplemented) ListSessions(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// セッション失効
+// (DELETE /auth/sessions/{id})
+func (_ Unimplemented) RevokeSession(w http.ResponseWriter, r *http.Request, id ResourceID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// 二要素認証の登録開始
+// (POST /auth/totp)
+func (_ Unimplemented) StartTotpEnrollment(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// 二要素認証の解除
+// (POST /auth/totp/disable)
+func (_ Unimplemented) DisableTotp(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// リカバリーコードの再発行
+// (POST /auth/totp/recovery-codes)
+func (_ Unimplemented) RegenerateRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// 二要素認証の登録確認
+// (POST /auth/totp/verify)
+func (_ Unimplemented) VerifyTotpEnrollment(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// 営業日一覧
+// (GET /business-days)
+func (_ Unimplemented) ListBusinessDays(w http.ResponseWriter, r *http.Request, params ListBusinessDaysParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// 営業日判定
+// (GET /business-days/{date})
+func (_ Unimplemented) GetBusinessDay(w http.ResponseWriter, r *http.Request, date BusinessDate, params GetBusinessDayParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// 営業日の加算
+// (GET /business-days/{date}/add)
+func (_ Unimplemented) AddBusinessDays(w http.ResponseWriter, r *http.Request, date BusinessDate, params AddBusinessDaysParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// 翌営業日
+// (GET /business-days/{date}/next)
+func (_ Unimplemented) GetNextBusinessDay(w http.ResponseWriter, r *http.Request, date BusinessDate, params GetNextBusinessDayParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// iCalendar 配信
+// (GET /calendar-feed.ics)
+func (_ Unimplemented) GetCalendarFeed(w http.ResponseWriter, r *http.Request, params GetCalendarFeedParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// イベント一覧取得
+// (GET /events)
+func (_ Unimplemented) ListEvents(w http.ResponseWriter, r *http.Request, params ListEventsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// イベント登録
+// (POST /events)
+func (_ Unimplemented) CreateEvent(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// イベントの変更通知（Server-Sent Events）
+// (GET /events/stream)
+func (_ Unimplemented) StreamEvents(w http.ResponseWriter, r *http.Request, params StreamEventsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// イベント削除
+// (DELETE /events/{id})
+func (_ Unimplemented) DeleteEvent(w http.ResponseWriter, r *http.Request, id ResourceID, params DeleteEventParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// イベント取得
+// (GET /events/{id})
+func (_ Unimplemented) GetEvent(w http.ResponseWriter, r *http.Request, id ResourceID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// イベント更新
+// (PUT /events/{id})
+func (_ Unimplemented) UpdateEvent(w http.ResponseWriter, r *http.Request, id ResourceID, params UpdateEventParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// 繰り返しイベントの回の取り消し
+// (DELETE /events/{id}/occurrences/{date})
+func (_ Unimplemented) CancelEventOccurrence(w http.ResponseWriter, r *http.Request, id ResourceID, date openapi_types.Date, params CancelEventOccurrenceParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// 繰り返しイベントの回の変更
+// (PUT /events/{id}/occurrences/{date})
+func (_ Unimplemented) UpdateEventOccurrence(w http.ResponseWriter, r *http.Request, id ResourceID, date openapi_types.Date, params UpdateEventOccurrenceParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// グループの権限取得
+// (GET /groups/{id}/permissions)
+func (_ Unimplemented) GetGroupPermissions(w http.ResponseWriter, r *http.Request, id ResourceID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// グループの権限設定
+// (PUT /groups/{id}/permissions)
+func (_ Unimplemented) UpdateGroupPermissions(w http.ResponseWriter, r *http.Request, id ResourceID, params UpdateGroupPermissionsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// 一括取込ジョブの進捗取得
+// (GET /import-jobs/{id})
+func (_ Unimplemented) GetImportJob(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// マスタ一覧取得
+// (GET /masters/{table})
+func (_ Unimplemented) ListMasterRecords(w http.ResponseWriter, r *http.Request, table MasterTableName, params ListMasterRecordsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// マスタ登録
+// (POST /masters/{table})
+func (_ Unimplemented) CreateMasterRecord(w http.ResponseWriter, r *http.Request, table MasterTableName) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// マスタの一括取込
+// (POST /masters/{table}/import)
+func (_ Unimplemented) ImportMasterRecords(w http.ResponseWriter, r *http.Request, table MasterTableName, params ImportMasterRecordsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// マスタ削除
+// (DELETE /masters/{table}/{id})
+func (_ Unimplemented) DeleteMasterRecord(w http.ResponseWriter, r *http.Request, table MasterTableName, id ResourceID, params DeleteMasterRecordParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// マスタ取得
+// (GET /masters/{table}/{id})
+func (_ Unimplemented) GetMasterRecord(w http.ResponseWriter, r *http.Request, table MasterTableName, id ResourceID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// マスタ更新
+// (PUT /masters/{table}/{id})
+func (_ Unimplemented) UpdateMasterRecord(w http.ResponseWriter, r *http.Request, table MasterTableName, id ResourceID, params UpdateMasterRecordParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// マスタの入力項目取得
+// (GET /meta/tables/{table})
+func (_ Unimplemented) GetTableMeta(w http.ResponseWriter, r *http.Request, table MasterTableName) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// 権限一覧取得
+// (GET /permissions)
+func (_ Unimplemented) ListPermissions(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// ユーザ一覧取得
+// (GET /users)
+func (_ Unimplemented) ListUsers(w http.ResponseWriter, r *http.Request, params ListUsersParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// ユーザ登録
+// (POST /users)
+func (_ Unimplemented) CreateUser(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// ユーザ無効化
+// (DELETE /users/{id})
+func (_ Unimplemented) DeleteUser(w http.ResponseWriter, r *http.Request, id ResourceID, params DeleteUserParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// ユーザ取得
+// (GET /users/{id})
+func (_ Unimplemented) GetUser(w http.ResponseWriter, r *http.Request, id ResourceID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// ユーザ部分更新
+// (PATCH /users/{id})
+func (_ Unimplemented) PatchUser(w http.ResponseWriter, r *http.Request, id ResourceID, params PatchUserParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// ユーザ更新
+// (PUT /users/{id})
+func (_ Unimplemented) UpdateUser(w http.ResponseWriter, r *http.Request, id ResourceID, params UpdateUserParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// アカウントロック解除
+// (DELETE /users/{id}/lock)
+func (_ Unimplemented) UnlockUser(w http.ResponseWriter, r *http.Request, id ResourceID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// ユーザの全セッション失効
+// (DELETE /users/{id}/sessions)
+func (_ Unimplemented) RevokeUserSessions(w http.ResponseWriter, r *http.Request, id ResourceID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// ListAuditLogs operation middleware
+func (siw *ServerInterfaceWrapper) ListAuditLogs(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListAuditLogsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "table" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "table", r.URL.Query(), &params.Table)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "table", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "record_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "record_id", r.URL.Query(), &params.RecordID)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "record_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "user_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "user_id", r.URL.Query(), &params.UserID)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListAuditLogs(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ExportAuditLogs operation middleware
+func (siw *ServerInterfaceWrapper) ExportAuditLogs(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ExportAuditLogsParams
+
+	// ------------- Optional query parameter "table" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "table", r.URL.Query(), &params.Table)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "table", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "record_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "record_id", r.URL.Query(), &params.RecordID)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "record_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "user_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "user_id", r.URL.Query(), &params.UserID)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ExportAuditLogs(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetAuditLog operation middleware
+func (siw *ServerInterfaceWrapper) GetAuditLog(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id ResourceID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAuditLog(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteCalendarFeed operation middleware
+func (siw *ServerInterfaceWrapper) DeleteCalendarFeed(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteCalendarFeed(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateCalendarFeed operation middleware
+func (siw *ServerInterfaceWrapper) CreateCalendarFeed(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateCalendarFeed(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// Login operation middleware
+func (siw *ServerInterfaceWrapper) Login(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.Login(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// Logout operation middleware
+func (siw *ServerInterfaceWrapper) Logout(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.Logout(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetCurrentUser operation middleware
+func (siw *ServerInterfaceWrapper) GetCurrentUser(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetCurrentUser(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ChangePassword operation middleware
+func (siw *ServerInterfaceWrapper) ChangePassword(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ChangePassword(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RequestPasswordReset operation middleware
+func (siw *ServerInterfaceWrapper) RequestPasswordReset(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RequestPasswordReset(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ConfirmPasswordReset operation middleware
+func (siw *ServerInterfaceWrapper) ConfirmPasswordReset(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ConfirmPasswordReset(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListSessions operation middleware
+func (siw *ServerInterfaceWrapper) ListSessions(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListSessions(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RevokeSession operation middleware
+func (siw *ServerInterfaceWrapper) RevokeSession(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id ResourceID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RevokeSession(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// StartTotpEnrollment operation middleware
+func (siw *ServerInterfaceWrapper) StartTotpEnrollment(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.StartTotpEnrollment(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DisableTotp operation middleware
+func (siw *ServerInterfaceWrapper) DisableTotp(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DisableTotp(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RegenerateRecoveryCodes operation middleware
+func (siw *ServerInterfaceWrapper) RegenerateRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RegenerateRecoveryCodes(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// VerifyTotpEnrollment operation middleware
+func (siw *ServerInterfaceWrapper) VerifyTotpEnrollment(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.VerifyTotpEnrollment(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListBusinessDays operation middleware
+func (siw *ServerInterfaceWrapper) ListBusinessDays(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListBusinessDaysParams
+
+	// ------------- Required query parameter "from" -------------
+
+	if paramValue := r.URL.Query().Get("from"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "from"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Required query parameter "to" -------------
+
+	if paramValue := r.URL.Query().Get("to"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "to"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "warehouse" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "warehouse", r.URL.Query(), &params.Warehouse)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "warehouse", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListBusinessDays(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetBusinessDay operation middleware
+func (siw *ServerInterfaceWrapper) GetBusinessDay(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "date" -------------
+	var date BusinessDate
+
+	err = runtime.BindStyledParameterWithOptions("simple", "date", chi.URLParam(r, "date"), &date, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "date", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetBusinessDayParams
+
+	// ------------- Optional query parameter "warehouse" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "warehouse", r.URL.Query(), &params.Warehouse)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "warehouse", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetBusinessDay(w, r, date, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AddBusinessDays operation middleware
+func (siw *ServerInterfaceWrapper) AddBusinessDays(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "date" -------------
+	var date BusinessDate
+
+	err = runtime.BindStyledParameterWithOptions("simple", "date", chi.URLParam(r, "date"), &date, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "date", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params AddBusinessDaysParams
+
+	// ------------- Required query parameter "days" -------------
+
+	if paramValue := r.URL.Query().Get("days"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "days"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "days", r.URL.Query(), &params.Days)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "days", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "warehouse" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "warehouse", r.URL.Query(), &params.Warehouse)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "warehouse", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AddBusinessDays(w, r, date, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetNextBusinessDay operation middleware
+func (siw *ServerInterfaceWrapper) GetNextBusinessDay(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "date" -------------
+	var date BusinessDate
+
+	err = runtime.BindStyledParameterWithOptions("simple", "date", chi.URLParam(r, "date"), &date, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "date", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetNextBusinessDayParams
+
+	// ------------- Optional query parameter "warehouse" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "warehouse", r.URL.Query(), &params.Warehouse)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "warehouse", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetNextBusinessDay(w, r, date, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetCalendarFeed operation middleware
+func (siw *ServerInterfaceWrapper) GetCalendarFeed(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetCalendarFeedParams
+
+	// ------------- Required query parameter "token" -------------
+
+	if paramValue := r.URL.Query().Get("token"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "token"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "token", r.URL.Query(), &params.Token)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "token", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "kind" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "kind", r.URL.Query(), &params.Kind)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "kind", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetCalendarFeed(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListEvents operation middleware
+func (siw *ServerInterfaceWrapper) ListEvents(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListEventsParams
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "kind" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "kind", r.URL.Query(), &params.Kind)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "kind", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "derived" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "derived", r.URL.Query(), &params.Derived)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "derived", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListEvents(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateEvent operation middleware
+func (siw *ServerInterfaceWrapper) CreateEvent(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateEvent(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// StreamEvents operation middleware
+func (siw *ServerInterfaceWrapper) StreamEvents(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params StreamEventsParams
+
+	// ------------- Optional query parameter "kind" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "kind", r.URL.Query(), &params.Kind)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "kind", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Last-Event-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Last-Event-ID")]; found {
+		var LastEventID string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Last-Event-ID", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Last-Event-ID", valueList[0], &LastEventID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Last-Event-ID", Err: err})
+			return
+		}
+
+		params.LastEventID = &LastEventID
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.StreamEvents(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteEvent operation middleware
+func (siw *ServerInterfaceWrapper) DeleteEvent(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id ResourceID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteEventParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteEvent(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetEvent operation middleware
+func (siw *ServerInterfaceWrapper) GetEvent(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id ResourceID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetEvent(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateEvent operation middleware
+func (siw *ServerInterfaceWrapper) UpdateEvent(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id ResourceID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params UpdateEventParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateEvent(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CancelEventOccurrence operation middleware
+func (siw *ServerInterfaceWrapper) CancelEventOccurrence(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id ResourceID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "date" -------------
+	var date openapi_types.Date
+
+	err = runtime.BindStyledParameterWithOptions("simple", "date", chi.URLParam(r, "date"), &date, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "date", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CancelEventOccurrenceParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CancelEventOccurrence(w, r, id, date, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateEventOccurrence operation middleware
+func (siw *ServerInterfaceWrapper) UpdateEventOccurrence(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id ResourceID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "date" -------------
+	var date openapi_types.Date
+
+	err = runtime.BindStyledParameterWithOptions("simple", "date", chi.URLParam(r, "date"), &date, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "date", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params UpdateEventOccurrenceParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateEventOccurrence(w, r, id, date, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetGroupPermissions operation middleware
+func (siw *ServerInterfaceWrapper) GetGroupPermissions(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id ResourceID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetGroupPermissions(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateGroupPermissions operation middleware
+func (siw *ServerInterfaceWrapper) UpdateGroupPermissions(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id ResourceID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params UpdateGroupPermissionsParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateGroupPermissions(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetImportJob operation middleware
+func (siw *ServerInterfaceWrapper) GetImportJob(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetImportJob(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListMasterRecords operation middleware
+func (siw *ServerInterfaceWrapper) ListMasterRecords(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "table" -------------
+	var table MasterTableName
+
+	err = runtime.BindStyledParameterWithOptions("simple", "table", chi.URLParam(r, "table"), &table, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "table", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListMasterRecordsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", r.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListMasterRecords(w, r, table, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateMasterRecord operation middleware
+func (siw *ServerInterfaceWrapper) CreateMasterRecord(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "table" -------------
+	var table MasterTableName
+
+	err = runtime.BindStyledParameterWithOptions("simple", "table", chi.URLParam(r, "table"), &table, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "table", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateMasterRecord(w, r, table)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ImportMasterRecords operation middleware
+func (siw *ServerInterfaceWrapper) ImportMasterRecords(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "table" -------------
+	var table MasterTableName
+
+	err = runtime.BindStyledParameterWithOptions("simple", "table", chi.URLParam(r, "table"), &table, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "table", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ImportMasterRecordsParams
+
+	// ------------- Optional query parameter "dry_run" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "dry_run", r.URL.Query(), &params.DryRun)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "dry_run", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "commit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "commit", r.URL.Query(), &params.Commit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "commit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sheet" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sheet", r.URL.Query(), &params.Sheet)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sheet", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ImportMasterRecords(w, r, table, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteMasterRecord operation middleware
+func (siw *ServerInterfaceWrapper) DeleteMasterRecord(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "table" -------------
+	var table MasterTableName
+
+	err = runtime.BindStyledParameterWithOptions("simple", "table", chi.URLParam(r, "table"), &table, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "table", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "id" -------------
+	var id ResourceID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteMasterRecordParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteMasterRecord(w, r, table, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetMasterRecord operation middleware
+func (siw *ServerInterfaceWrapper) GetMasterRecord(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "table" -------------
+	var table MasterTableName
+
+	err = runtime.BindStyledParameterWithOptions("simple", "table", chi.URLParam(r, "table"), &table, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "table", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "id" -------------
+	var id ResourceID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetMasterRecord(w, r, table, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateMasterRecord operation middleware
+func (siw *ServerInterfaceWrapper) UpdateMasterRecord(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "table" -------------
+	var table MasterTableName
+
+	err = runtime.BindStyledParameterWithOptions("simple", "table", chi.URLParam(r, "table"), &table, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "table", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "id" -------------
+	var id ResourceID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params UpdateMasterRecordParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateMasterRecord(w, r, table, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetTableMeta operation middleware
+func (siw *ServerInterfaceWrapper) GetTableMeta(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "table" -------------
+	var table MasterTableName
+
+	err = runtime.BindStyledParameterWithOptions("simple", "table", chi.URLParam(r, "table"), &table, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "table", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTableMeta(w, r, table)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListPermissions operation middleware
+func (siw *ServerInterfaceWrapper) ListPermissions(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListPermissions(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListUsers operation middleware
+func (siw *ServerInterfaceWrapper) ListUsers(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListUsersParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "q" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "q", r.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "group_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "group_id", r.URL.Query(), &params.GroupID)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "group_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "department_id" -------------
 
-// UsersRequestDelete defines model for UsersRequestDelete.
-type UsersRequestDelete struct {
-	ID int `json:"ID"`
-}
+	err = runtime.BindQueryParameter("form", true, false, "department_id", r.URL.Query(), &params.DepartmentID)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "department_id", Err: err})
+		return
+	}
 
-// UsersRequestGet defines model for UsersRequestGet.
-type UsersRequestGet = map[string]interface{}
+	// ------------- Optional query parameter "valid_flag" -------------
 
-// UsersRequestPost defines model for UsersRequestPost.
-type UsersRequestPost struct {
-	Name string `json:"Name"`
-}
+	err = runtime.BindQueryParameter("form", true, false, "valid_flag", r.URL.Query(), &params.ValidFlag)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "valid_flag", Err: err})
+		return
+	}
 
-// UsersRequestPut defines model for UsersRequestPut.
-type UsersRequestPut struct {
-	ID   int    `json:"ID"`
-	Name string `json:"Name"`
-}
+	// ------------- Optional query parameter "sort" -------------
 
-// UsersResponseDelete defines model for UsersResponseDelete.
-type UsersResponseDelete = User
+	err = runtime.BindQueryParameter("form", true, false, "sort", r.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort", Err: err})
+		return
+	}
 
-// UsersResponseGet defines model for UsersResponseGet.
-type UsersResponseGet struct {
-	Users *[]User `json:"users,omitempty"`
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListUsers(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-// UsersResponsePost defines model for UsersResponsePost.
-type UsersResponsePost = User
+// CreateUser operation middleware
+func (siw *ServerInterfaceWrapper) CreateUser(w http.ResponseWriter, r *http.Request) {
 
-// UsersResponsePut defines model for UsersResponsePut.
-type UsersResponsePut = User
+	ctx := r.Context()
 
-// DeleteUsersJSONRequestBody defines body for DeleteUsers for application/json ContentType.
-type DeleteUsersJSONRequestBody = UsersRequestDelete
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-// GetUsersJSONRequestBody defines body for GetUsers for application/json ContentType.
-type GetUsersJSONRequestBody = UsersRequestGet
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
 
-// PostUsersJSONRequestBody defines body for PostUsers for application/json ContentType.
-type PostUsersJSONRequestBody = UsersRequestPost
+	r = r.WithContext(ctx)
 
-// PutUsersJSONRequestBody defines body for PutUsers for application/json ContentType.
-type PutUsersJSONRequestBody = UsersRequestPut
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateUser(w, r)
+	}))
 
-// ServerInterface represents all server handlers.
-type ServerInterface interface {
-	// ユーザ削除
-	// (DELETE /users)
-	DeleteUsers(w http.ResponseWriter, r *http.Request)
-	// ユーザ一覧取得
-	// (GET /users)
-	GetUsers(w http.ResponseWriter, r *http.Request)
-	// ユーザ登録
-	// (POST /users)
-	PostUsers(w http.ResponseWriter, r *http.Request)
-	// ユーザ更新
-	// (PUT /users)
-	PutUsers(w http.ResponseWriter, r *http.Request)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-// Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
+// DeleteUser operation middleware
+func (siw *ServerInterfaceWrapper) DeleteUser(w http.ResponseWriter, r *http.Request) {
 
-type Unimplemented struct{}
+	var err error
 
-// ユーザ削除
-// (DELETE /users)
-func (_ Unimplemented) DeleteUsers(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+	// ------------- Path parameter "id" -------------
+	var id ResourceID
 
-// ユーザ一覧取得
-// (GET /users)
-func (_ Unimplemented) GetUsers(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
 
-// ユーザ登録
-// (POST /users)
-func (_ Unimplemented) PostUsers(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+	ctx := r.Context()
 
-// ユーザ更新
-// (PUT /users)
-func (_ Unimplemented) PutUsers(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteUserParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteUser(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-// ServerInterfaceWrapper converts contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler            ServerInterface
-	HandlerMiddlewares []MiddlewareFunc
-	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+// GetUser operation middleware
+func (siw *ServerInterfaceWrapper) GetUser(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id ResourceID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetUser(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-type MiddlewareFunc func(http.Handler) http.Handler
+// PatchUser operation middleware
+func (siw *ServerInterfaceWrapper) PatchUser(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id ResourceID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PatchUserParams
 
-// DeleteUsers operation middleware
-func (siw *ServerInterfaceWrapper) DeleteUsers(w http.ResponseWriter, r *http.Request) {
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteUsers(w, r)
+		siw.Handler.PatchUser(w, r, id, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -141,11 +3421,54 @@ func (siw *ServerInterfaceWrapper) DeleteUsers(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r)
 }
 
-// GetUsers operation middleware
-func (siw *ServerInterfaceWrapper) GetUsers(w http.ResponseWriter, r *http.Request) {
+// UpdateUser operation middleware
+func (siw *ServerInterfaceWrapper) UpdateUser(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id ResourceID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params UpdateUserParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetUsers(w, r)
+		siw.Handler.UpdateUser(w, r, id, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -155,11 +3478,30 @@ func (siw *ServerInterfaceWrapper) GetUsers(w http.ResponseWriter, r *http.Reque
 	handler.ServeHTTP(w, r)
 }
 
-// PostUsers operation middleware
-func (siw *ServerInterfaceWrapper) PostUsers(w http.ResponseWriter, r *http.Request) {
+// UnlockUser operation middleware
+func (siw *ServerInterfaceWrapper) UnlockUser(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id ResourceID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostUsers(w, r)
+		siw.Handler.UnlockUser(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -169,11 +3511,30 @@ func (siw *ServerInterfaceWrapper) PostUsers(w http.ResponseWriter, r *http.Requ
 	handler.ServeHTTP(w, r)
 }
 
-// PutUsers operation middleware
-func (siw *ServerInterfaceWrapper) PutUsers(w http.ResponseWriter, r *http.Request) {
+// RevokeUserSessions operation middleware
+func (siw *ServerInterfaceWrapper) RevokeUserSessions(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id ResourceID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PutUsers(w, r)
+		siw.Handler.RevokeUserSessions(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -297,16 +3658,151 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	}
 
 	r.Group(func(r chi.Router) {
-		r.Delete(options.BaseURL+"/users", wrapper.DeleteUsers)
+		r.Get(options.BaseURL+"/audit", wrapper.ListAuditLogs)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/audit/export", wrapper.ExportAuditLogs)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/audit/{id}", wrapper.GetAuditLog)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/auth/calendar-feed", wrapper.DeleteCalendarFeed)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/calendar-feed", wrapper.CreateCalendarFeed)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/login", wrapper.Login)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/logout", wrapper.Logout)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/auth/me", wrapper.GetCurrentUser)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/auth/password", wrapper.ChangePassword)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/password-reset", wrapper.RequestPasswordReset)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/password-reset/confirm", wrapper.ConfirmPasswordReset)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/auth/sessions", wrapper.ListSessions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/auth/sessions/{id}", wrapper.RevokeSession)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/totp", wrapper.StartTotpEnrollment)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/totp/disable", wrapper.DisableTotp)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/totp/recovery-codes", wrapper.RegenerateRecoveryCodes)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/totp/verify", wrapper.VerifyTotpEnrollment)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/business-days", wrapper.ListBusinessDays)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/business-days/{date}", wrapper.GetBusinessDay)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/business-days/{date}/add", wrapper.AddBusinessDays)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/business-days/{date}/next", wrapper.GetNextBusinessDay)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/calendar-feed.ics", wrapper.GetCalendarFeed)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/events", wrapper.ListEvents)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/events", wrapper.CreateEvent)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/events/stream", wrapper.StreamEvents)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/events/{id}", wrapper.DeleteEvent)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/events/{id}", wrapper.GetEvent)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/events/{id}", wrapper.UpdateEvent)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/events/{id}/occurrences/{date}", wrapper.CancelEventOccurrence)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/events/{id}/occurrences/{date}", wrapper.UpdateEventOccurrence)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/groups/{id}/permissions", wrapper.GetGroupPermissions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/groups/{id}/permissions", wrapper.UpdateGroupPermissions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/import-jobs/{id}", wrapper.GetImportJob)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/masters/{table}", wrapper.ListMasterRecords)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/masters/{table}", wrapper.CreateMasterRecord)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/masters/{table}/import", wrapper.ImportMasterRecords)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/masters/{table}/{id}", wrapper.DeleteMasterRecord)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/masters/{table}/{id}", wrapper.GetMasterRecord)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/masters/{table}/{id}", wrapper.UpdateMasterRecord)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/meta/tables/{table}", wrapper.GetTableMeta)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/permissions", wrapper.ListPermissions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/users", wrapper.ListUsers)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/users", wrapper.CreateUser)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/users/{id}", wrapper.DeleteUser)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/users/{id}", wrapper.GetUser)
+	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/users/{id}", wrapper.PatchUser)
 	})
 	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/users", wrapper.GetUsers)
+		r.Put(options.BaseURL+"/users/{id}", wrapper.UpdateUser)
 	})
 	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/users", wrapper.PostUsers)
+		r.Delete(options.BaseURL+"/users/{id}/lock", wrapper.UnlockUser)
 	})
 	r.Group(func(r chi.Router) {
-		r.Put(options.BaseURL+"/users", wrapper.PutUsers)
+		r.Delete(options.BaseURL+"/users/{id}/sessions", wrapper.RevokeUserSessions)
 	})
 
 	return r
@@ -315,16 +3811,232 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/9RVQWsTQRT+K+XpMSSr9bQ3JVKCoKHgSTyMuy/plOzMOPOmGMqCbS4qgrdKqeBFqoIH",
-	"ERHR4p/ZJtp/ITO7Jl2TaoS6IZcwzLy873vve+/bbYhkoqRAQQbCbTDRBibMH69rLfU6GiWFQXehtFSo",
-	"iaN/jmTsb/EBS1QPIbwSBDWgvkIIgQvCLmpIa5CgMaxbDoWW2GI9Hq9ovG/R0IpimiVIqA2McxjSXHQh",
-	"TWvgwrjGGMI7Oe4k7d1xvLy3iRE5yNsG9TThVtP9ThO8yRI89XIGbKsJRehZiGY9L6aJPSScF38a5m/5",
-	"15BOJZod05aGphnMV+lcRbYtLaTD+TT+W4v/A4FCgzK6dRHuwAkTf7iosQMhXGhMlqxRbFjDD2k6BmJa",
-	"s76n9Gfk2cpWVnjFurtYLjrSZ+HkvcPzWbnabkENtlAbLgWEcKke1AMHKhUKpjiEsFoP6qtQA8Vow/Ns",
-	"jBWKxwPk6mDEpWjFEEI+WB4BcpZo6JqM+7njCULh62dK9Xjk/9fYNI7AL+ucR/bfnCItd4S0RX+Rd9zz",
-	"vRwE582gtEmeQowm0lxR3tBbN8DfdZjt0bmBlz8qM2CPvzwavXiZ7RxkO++y3TfZ4G02OPIzY2ySMN2H",
-	"ELLBYTY4ynY/DR8/Odl/5Xh284Usq7mGVJWUzhDSQsgqdCvwllG0488Pfxy+Hj7bG3577tiqwtHK2jmf",
-	"q0o876mL3MIJgeWT8/v+15OnH7yQdpaOtjoZ7YJVtEsr4ujg42jvvXewnwEAAP//DY8cSBIMAAA=",
+	"H4sIAAAAAAAC/+y9e1MU17ow/lW65rf/+p1BLhpPwqldp4yYbHa8bcC9T97godqZRmY70z3p6TGyU1RN",
+	"9wgMAsGYABqIikFB0EF3Em+gfpe36Rn4i6/w1vOstbpX3+bCLcaQSslMT3evZ631rOd++ToSU1JpRZZk",
+	"LRNp/TrSJ4lxScWPJ7rEi/A3LmViaiKtJRQ50hrZmBsz9aKZv27m10zjhZlfMPM/mzmjNPNLaeqJmV+1",
+	"Rq5t3pov3TJMfVlo7204JWqxPsHUFzZzumlc23j7vanfikQjmViflBJhAOmKmEonpUhrpDtyuDsSiUa0",
+	"/jR8zWhqQr4YGRgYiEbSoiqmJI3CdiwbT2ifqErKD2Dpu/H117Ol6fumXiyvXLVm/m3qxc2pUWthdGut",
+	"YOrfmXoRfjVuWNeXTSO3tTYSiUYS8OiXWUntj0QjspiC4Xvh/TygvYqaErVIayQualIAmFECV4cUU9R4",
+	"e5sfNmvlzcbTObKE7W2mvlD+9TasyZs1U38L0BlPzPwyrGx+urT4cPPWdVNf4S+2t4WDq+KwPYl4MMwJ",
+	"WTt6xAE6IWvSRUl1oO4SLyQlP8hmfgiHnjLzy9b1cT/M2YykZnpSYkaTVDOnX1SVbLonLampRCaTUOSM",
+	"mdOt61fN/G3TeGkab8Ph1xAAHvaUeOWkJF/U+iKtR4+ELneXUiMSlH811l8N1YkEmrIdFDiXkdT2tjC4",
+	"zPwDPD3PABb22V4h3GPPOocAB2u/nQ3/OJtJyFIm0waT8AM5fX999SYbMi1qfc6IdNqq9GU2oUrxSKum",
+	"ZqX6Fqi9F0lCwOmYmLLeTCPlKApAfcycsb46ZepFq/Cw/P3iRm7Q1MeswYKpL1Nyo0+b+gNTv2oaY6b+",
+	"RDjS3CKY+rKpL5nGqJkzyrN6efK+qd+Cr/qicKTlw26ZTYyQOmdqjFbVSZuikZOJVEILm8766rPS5JOQ",
+	"/Uvik/x4calXzCa1SGtLUxTwP5HKpiKtzU3wLSHTb4F7egpPIJ7i0/j2Kid5a61gDT7anBo19UlcPlxH",
+	"fQn+5e409RXhSNMR7oC4EYKd2nCM8JzitKhpkgov+t8vxIZ/NTV81HP+Pyj9+FPg+p7p7c1IoQtMSPv6",
+	"6/Hy62LIMivkBYHrzC9sU+DCdkgZJavGJHKeA5YgEa84f9+BrLKR/xBVqU/JZgK20MqNWK+WTeNn3J4R",
+	"G8PxzKxYg4vkBmvw6WbuB1Mvrq99W7r/GAhdfnVjeLF0y7CmntEr+l1T/9Y0bqy/fmvqQyEr95UNS8iO",
+	"ImYGsGtVyqQVOSMht/5YjHdIX2alDG5iTJE1ScaPYjqdTMREmF3jPzMwxa+5Yf6kSr2R1sj/1+iIKI3k",
+	"10zjCVVV1A46CBnSi+1LprFiGotAVPOF9Rfjpcc/RQaikeOK3JtMxPYRlM3h8Y35YRCNJozy4EJp8hfr",
+	"eqGUWwDg8g/N/BqA9YmiXkjE45K8f3AxCWPJ1Ke31gqlkZz19DYvbYAEd6XB4eUCcE360BghFUAXBqKR",
+	"04r2iZKV4/sHPBGlzPwwcs+3ZBYAylmQg+R4Au7rsA/lfoHlCLz5m2Y+b+Zz9lIBcF2KckqU++lpyOzn",
+	"cXgMO2vMm/mfrfmnpclpUx8rP/sBaf4d+N/QTeOeaSybxgOQ6PMFADz/GCZhrKy/eLy1VuiQNLW/4Viv",
+	"Jqn8/JY3Fn7avDVv6m9MfQHkrIUbpcknhGNw+gT3tHtiPhoI4J+TxazWp6iJf+3n9pVmlzaWxjcW11Ay",
+	"KyBurcByIHqZ+dXS7J3NW9etwjCwzPyqNf/Uuvay9KJg6m/N/Gr56px17SUn3uHhOCenVSUmZTLAKk/I",
+	"WkLr38dzMnjfujZj5eZtagOM//FNa3YRpSfATEKXULBaMvWHCPYAI/iOvnW8T5QvIldKq0paUrUEIe8i",
+	"21LPyPMjpZlfrDeg7Fi5+Ug0ImeTSaJlAI8ciEYuSL2KKoU+OjIe9ugAz3C/YO+JUlDO2yxJufBPKaZF",
+	"mFh+UrkYAH6MjOoFIi4lJU0SQAOzZXS9SLbYGpuydYdINCLJwM6/iMRUiUi92TQVf8lLIue9TDIaudIA",
+	"TzVcFlXgtRl4nKwyewdRJNJx7lsbfdtANBLDzSATiBNqJybPuiZWCW/4DR2IBi4/kwvvWAVgD1Zh2ro+",
+	"LvzfoRuCsz35Vfr5zRgoLTmjfGt1c+zfSAdWnNtyOrEKuK4DYowJsLGRgP1KxGtSZqIRJRbLqqoU7xE1",
+	"n/7RoCVSAUpIlNOVq+jo9SjlNQCrEsIfODDVDeFQ3vFIL6AQ/U8D5RoN7W2+AxEwRY3p844KcyGRTCbk",
+	"i0xbD1oYplFW01zRBuTXXINWIQRSblVw0KRyMSGHavK3DH7EWlbAQyJQSOdxJcppzxwAUUYPopxq4xhW",
+	"2LFzbWUlepNhxPlTSfPTniRTHf2LklQu4i0JTUrVdpxPKmTjyatEVRX74btiq0/+QTRFE5MBiz4/W/7l",
+	"XunHufXVZ6a+vP4itzH8C1GjrcFFW6UNUF74JccZsDGitrJL4QlaNMcu0e9fqwv0x5642O8HmVNsRh3Q",
+	"LihKUhJlJHHU1lHFQAE39veQi5XXvE3s74LbBpi25AWpfP/H0vR9MJrldBSO7gDxs+E0ls38IxS1ckhH",
+	"6CEClnd9vLwAC+wc3dLYSmnmLehz0/frxn06U9cCcvOk8FfZEBuR/TsTF/trR1V+j33Y6gO8PxMI13Ex",
+	"KclxUf1EkuJ+eDTlkhRASjYHx9ffzvFinW0LtN7Olh9/j+wJ1GJipCZU3U8i1YATs/Hz2sbS4/L3i6Ze",
+	"PNdx0rV3fZqWbm1sTCoxMdmnZLTWD5s+bG6M0Sk09EpS/FAilvlvBPvPh79safjPr078z7FTZ0+eiFTb",
+	"WgAmSiccuFBIr86KmcxXispr4e4VQ7Ioaz1peqProNgXo7zm/58taMlgX5sDVkqWvtrBGz+sNncf0J4R",
+	"A9dDSWZT8ilJEwPQmNmE/AbRe1bxB5ChcwaVmO+CDm8aV4Xj5zo6Tpzu6ulqP3Wis+vYqbMCEaIBqdYm",
+	"4F9yq77CRB2fFJyQ01nNJjlB4nvp0VUQPReLm3O3zZyRkZJSTBNQlJrazC+axmNAaX1BUPC5jGDqo6Yx",
+	"sqm/MPVnZs7oluVs6gJobfpKafKX0uQTM6fHpVgiJSbxNU8mUGMrZAArhdIcoVcLKNPpmnRFE1VJhDsF",
+	"+CJYt0fRKjuN1lQm/ZIxUOjFN0eiEbib/oE3IAOVYpcuKFciUUaW4A+V08jEapSW22HZTrMx8VubPTB+",
+	"7SKj258pCPj9uAMHeZQAY3+mEOH3TgoWcGXxghRw/K3CtKkXN5anyteHrOvjruNffn63NH0fRRbfCUmJ",
+	"V3qSFN99WDebs+YXSlPD1uNpsjuXRTXWJ6pCo4B/yBYQikVoVXVRi3EqB7xYUskk5Is9sA1ExvFBSbEq",
+	"SE/j0a/I6ZHFjbkxUBqQk23eHSLagYPpAsj+Nh4784hEa+Mi5ByfIYAECD1pVYolMoF6HUgwr9EBNKeT",
+	"dXVOQv0LqkpivEeRkwEiycbwkjU6Wfpmrjy5xF69KFyWVIALze9wtm0t3MVuOMFF5YxYHgEDlS3iamWu",
+	"jgVTH2fvK5w+0yWcPnfyJLhfHSKmj1UeEIlAwLohkYD93qWlC6Z4ZIcP9Ysp8tbbo67TdCFxMSFrVfki",
+	"4jk7rvRmF6Xlbuc3MWpzgXDuQbHOxz9qVlmDxUX+9JBzs7VWgFsFXFmyad9ST1dMiZPrIFo+NPU50xgx",
+	"DcN1TyIeKMEEKUahAiATcoOchDxL+kqSLsXFfmQmL3+GH3M6XJPkOF6bvUOu9SnJBL2PiMdmTu+WgQZl",
+	"VbS1OC6LnP6Vol4ipAkf8Hgw4ARVE6a31kZcLIqCGYlGKHCRaISCBLyJgAG/OiPXyI3oQv3DHoC7QMah",
+	"F/5iD0cvHLdHZY+QwUFABpbjNvH5RTcl7qboR5qagpAuBQbIi+5bI+3yZTGZiAtUmRW4KIuq0heM67w2",
+	"CHlOXKb2TTfEYjLJdDg/8fFqeRWNn/B+jz7hwtKvq5tJJDmIsGKkQGkaHWs5o/yrQdAd7ee3mHkcbFne",
+	"UAL9DaGtLprV0tRytKGppaGpuau5ubWpqbWpqRYLjnQFeHIA2y2/fMIiaUDs2Lw1b81PkRPh+omDtjT7",
+	"iDA9DNoY4dlsVYXYy1sToayIGq9cq7RSmnyCXKcotLeZOcN68o01/CrwTjM3RlytDbw23N7WQCISzBza",
+	"g1/fg6WHqJIxU79JfwKn/wq5IrS3OUEArn1oDprdpYQcrwnRPoMbQRq6LKlqIh60MdbMbVP/3tSBRRIr",
+	"5/a2pCosZygMQdujSkQxikmBhrwwcADkmduOwgKM5Uf7Ymn2UenH+wTV11dvbq0VHEKtL5tGwTRGywur",
+	"1uikaVxl9uOiNTLuMY2G4ZiqZpNSFWD14saDCWtkuK41dXb/k44Tf/vzqTOnu/5y8vP/+vhz/NR27PM/",
+	"NwSiRUZSE1JmhytY4U4h2Fjqp9uZvkQ6Dbwo3FgNKzP+fP3FKh4woCsCCQcgV0k4wPZMs+Q81oSTneRW",
+	"eEgTVc3NaHgS2GSTQP+qa6KWre0IdJJb4QAkNK+lu/RwtDT7yHo1XZpdsgbvb4w/DxqNiuJBoTKu8Mat",
+	"tQKEIwmmvkhIDDEFsBikIlr2fjb1Zeva3XJxmug6Feic0FQZNxCLVirc4IGvFkQKkvrIwrH9IrwwarNn",
+	"ezcojXSjopvT2ojiLGqoQOAx8tpWl4isyFIkWpkC4NKM2bQHZEAiWuZXqTyZX7XjYPgIGIypW7aAONwh",
+	"0WCgKKFpRJGJlw/JHXLwN2ZOz1xKpJ3LMK5xA11YE2ZOT6vS5YSSzeA5Gxl35NCcLoOBBLbv7ZhzWV8u",
+	"L6ya+i23vYTMFwaKRCPslWjFulKrEcS7oqfJO72XO8kY3stnnTF9L0IY2J45rl/37jRKl8nJ1FRJTNlR",
+	"vqNehEaGEIl6BEGJyYdVD3uY2LGZ+6F85355csmaeL61VujsPAE6o5CIO2e1Zt9cLTZ/bjGI7T/wWMEv",
+	"oejPPe2bDvEfx4VGgTiQ4RPxIKMW5VlVKnPlVz2x11trBVxZweVr9fhg8TP1sA8NWsWXhGp1y6qUkfBR",
+	"Eq9dBkY+Q4Rastqoihqm/hOSve/gJBk3NuYwnCCn2yOTR1wIT6dn+8fjtoOcKOEZqS60Jyt53H4pd/Fc",
+	"Ou6/2GYPxV3sIKOy3fksIcfdVElEo62H7Y69sgoQSSzarNb6bs66/q1t/WBTJk8j4UQzJR9QlxZVTZbU",
+	"eqYM4B3DV9pfO+1325f+wQ1iXzzLRmNTPRNjcmKoW8CjTPGRnB98cPiD6C6oV7UoQrY84Tn8GPlJXgMs",
+	"GiZFp4JUAB/jmPbre9baREXj1G4JItxCtdDQXc5PUt15F0w5bKG/2j6F7UJQGEQlVYHJ8SDCBKoBtUj2",
+	"9ubt8hpXtk64ZxZKjEMxn7NR2KSgV0xmpOje2yz24piBbyfkOJg5gz9JqMtdQ86wjGTcsSjvldHCp1zW",
+	"Z59IiVfayc2Hjx7168N1K/h16qIdnxwXPvjgyAdIczo6zp08QRYV9E10mbUdaz/5udAo/OPEic/wA1VC",
+	"hUbh8xPHOk5+LhCDRrfcfrrrRMffj52MCsfPnDvdFRXOne5qPxkVPv687djnW2uFllNnokJD8ycdgh2p",
+	"Bz8yVXZrrdDQjD6+2UJpdpn/NSr847POLoHFexdIfkS3DE6mgq3mrxCaSZi2W3EmwP8XQvLnTzrcntuW",
+	"D2pB0ro1WU9wO6/Nbq0V6Ff9Fy4oaQV8LoUh+zUhwl8Nem9NLOfzzz//vOHUqYa2tq6+vtZUqjWTEexI",
+	"DwEQ4/Dhwx/RyLhgI+KK4Lwk1Gi4ZxpzZUZVmcS6tcdQEttpWxA8OrZHRRh+VRrJmTkjJcpZ4pUuf7+6",
+	"+eM94soOsy8C9XBLv5RggYpXpAZaY8x2TCHSU5cnjoHOWTOn09g4uLaxPFp6ahDSRD5z5ki07uX4PIoF",
+	"3unCK4M4nN4tK2pcUnvikhhPJoiqaU1Ml35eLD+/axWGwaVRWHU5M3AQ8nRGUi8nYlIPBx5JhjKNX9HR",
+	"8R0AphcJnMyfEuRlcXwsi4LHsVJLTBJn7lv0OGJcMj7ZPupMIcIshT0SjbhXAh3+runtmjeGw7xTDCDu",
+	"2nEbNu7ixzYM3MUzAHGbAzD3UyeBPfAxx8njGZVMiLvodfZwB9WlhvgNI9eelQYxyY0ek+LGyL+R9n0L",
+	"IXuY1QOW0oevSAgBsoWljcXHVvEHt44CH8HHRNQTmUzmKxGD9RJyrwJDK7JU19LjDHhjBLlygo7DXfqH",
+	"PaTrYkJzX2kngHBX2hAmtmaZSh6yrKxVJUChUY1RYqeoPcLNtlhUjG0jUNkvD6Kfn0Iu7VknldY/NZJt",
+	"W7PjOe1+V2A6EEsuA8p6c5iEbvDiWFX3ULhV1ZVaVKzRyEqg0osEcwOtrduwf9oL516UyuZL735Q7eFs",
+	"NkCBqLjU66s31198QwJqPcsOB/rhq83BcXT6L8A9+ktMzmSpV8YNa36K5NDbm+LNyKsSmOdIzHz+XjCe",
+	"8hMJWpT2VFpRNXKwA44eBEwEoQJNRiFxIxgja2zMjdmxOXy2Smj+HJOBiUkrOL4uaPaOJ9z3m6p8FeQO",
+	"mDSNOaQXy+svrpHoJtvouPFglBn6x5rhh5miK+4iDAVhqChbocqOdLLGf1UuBKwwMX/Vlf0gsc3y5dNC",
+	"trlxA/PCvsWIEhJ1MkqsfeXrQ+Xvn26tFXrFRBJNkkVqR68e9+OM3puQE5m+yiBXfUki2KBBE62keI+q",
+	"fJUJDGzfWFxDk+kLUx8nyfUw418NUIr0OxtzY2FcQJUyNDpUTCbP9EZav6jMBsimdZCnBs5HA1e7/Ov1",
+	"0u1Z0M6zsZgkxWtbVZfcz9j4l1kpSwypWZlyVPulQCFxz2qNrUTY/8beSKdiv5d87+TeTq58QscITj9B",
+	"alUh9wQTBeyNq8mBxUpGMEcV9wofMtg76Ig73OFx42X4IeywscBjglP7e9SsHBzUguP1hMohDi0sP58I",
+	"FULgHZmQgiw05oCnmjaJ2rw7BMb/wcLm3cdgomhuamoSMLGDhvjWGnnAU/qgsBA5I6maVCU4hBwwiBwk",
+	"CyYQ4s3ljYHuxu4aCXaJXwKTd7zyUtKcVj71gogV4zfXX6Nxi7sZF5F3a8CzHI14w5PBCjTCjcJeLkJz",
+	"lUluGVkFjnkslh++gp+YmzFs9sx34iduTk2MsGVmt9SwzN5UDPIe7xmzN5336bD9sZHWfQKCDtdJSL4K",
+	"tcYqWrqHxbl5sB+zdSFzOT8NSXN68ShGrxd5OXb91djGA738y11yd2l2BJJ2bxlk4nxBiqaGj85/fXTg",
+	"T0HkaXdzIyCp7LKk9tc4L9tux6cfgAy9hDr7dfiQX+Mn3WzN3EZx8RqZJgfe4ergcXmAdcmW3vQUO8Wu",
+	"YlYG3f0wBU66kk6oUqYuGSckB+gYTSrHXOtW4WNJVD3J7KWxYdQzsGaMsQrXjee0zBWXOhSWPFmNiEJl",
+	"IN8yEWCj/ETp24JWi1R6IVWmwvN/XYJT8MPbzk2slFJIsiVr15ZdswlgKyEpinXkITKI6kpFZClTUC4k",
+	"oaZCSdNuUgWv5lXpyDgpXRlJqwbkbidiccdrJ9SBoX3VrC3XZENnKaXERNI1PXIlWrVgjRss8lQgHLYu",
+	"HB6HXc2o4i2oYuXmvZGKcPIzrV+piWB/1w7zDAhIJEuphtQAGucdmiHgsodUICceg0hNtMF5d1VzWjUz",
+	"RQflt8eVuFTBVuhiy25Aqxi+AogO96IgkDqlMFTahk5PkyEDxE6MUPcXFPDyNpBuH5r6UFj+9HaYcM2Y",
+	"mkj3iPG4KmWCVxrFCPEinV8tSMsrdi6uytapwoZUxuOMVCcSs22uhjH2i4NAw7puwWmrxIIUFI3uL+9m",
+	"jJJiHp4kK7+Vt3ruHQITwK9DMiTdNeVCUiU93rYglPInMFatalE5NYwtX+CqK1oaCEZ43jQl+rXpEEEZ",
+	"NGHDnpBVJZlMBSbQKFoaiiP1ZNWEf6Hpj62NjZqipRsPHTokkFAP0P3PdbQHLeqXak9avhj6LhgIoxv+",
+	"1iHwrOzs6U/NnP6xmJGOHvGY4S/0h0QDSTE1qJZfaWSUpEOSFHoBXnq4RUDyRCTuRyihF6quKh0h6lol",
+	"e4pBy32Oiu3emKq0qGqwAcH1ULBaWq1B/NzLQpgyvs4u/OBbN1u28Z6qOdyNZdQUR2CVjJdBz/OOonCv",
+	"TK3TIW8Lngn/vkAhIziSt9ahk0rskhTvycpa4Hp4Spix+mWkQpkdt8HXNeOTRWrjaiHzZlEoIZOuGP3C",
+	"gl5CwloAQj6zg1R55G7ZdpwL0IgeSYabAsAKNJwESgehdYPcNXv8KRiQftjTmwwqNU2GIwW3Agfdu/yN",
+	"7WZUcG5F7ozwpYboBaaZuKmMn1C4VsizXZWdlUDVbA8lK7hbhcTVcP5qV7KqWJjqdF2zQ1fnKLzWG14R",
+	"0cx/i3Lxis3aLsTU/rQGhtL8BBOT70PVN6w4vP72R+vxTW+EmhMt62TX71DTjkZQCzyD9QWY56cWQmLD",
+	"ZuZXKdlwKpH4IC34KQpYwgqr6H7GGsBnzznRg0PbJjfbNSt6CYWXFgxUOwBKRjvA/z8Q/ldgpCQKMOjX",
+	"MeaFwkJn7LR4ioh7IkxZpvE1M6eTYuXs+gqzJLsCqH/DQ2MHk7kG4s9QSIRMjdyrKhfKHpzBgzPInUEv",
+	"hwoQbMNOKpPdXCfVfzStmVd4BNEEYozyi/FOMK2dHTjXABVO3+47eXa3biSZcO0WNeJDq2JOI6+sy+fj",
+	"Wq4aRYYDq8CBVeDAKnBgFfh9WQUoicseULgDCndA4Q4o3HtD4YiLKasmtP5OkBZp6XAM84LYL/+anT3T",
+	"2SU0gqeoESvAQ6Rk+RZGYrIGCJXDwFAqxR3CUZwV69O0NLrmFeVSQqp9dJbpQpUW4S+algbtSziOL2Kt",
+	"mmLsGzmXzH/sjC+mE59J/aQpCOZxtX5NkzZbIx+LMazNeOxsO7eerZHmQ02HmkjNV0kW04lIa+TwoaZD",
+	"h0mcZB+uZqMI1ebh08VAVx6NQb0Kgcf6MqmlHaLHLQdpbUXXDVhajPVGoD2r7Hdi6iFwL4zna49HWiMn",
+	"ExnNLr4fcTeODInYd25pJL3cBqJVb6RNyWq4k+uuWOvddgfJWh+g/QZrvR07Z9YMuoLZC66OXi1NTbvW",
+	"wiawU0JAJ5sznwFeHmlqCnuhDWEj13AMH2mu/oir3xA+dLj6Q07TroEoXz58f1r7rL8qlGbvYHWeR1wv",
+	"MSCB2VRKVPsjrZHyzE+lO6vEzgTq+IMF0jUPkycuwpFw3QIUlQ9Qi7RG8LC3qpIYx1eTw98oXUkrajgN",
+	"IEPZfIdZBIr8WKZxA/ISfL1mWDbDcjMJlBeOd/59a61wruuThg8h3ODMKQErPo6TzC9rYt5Pa4ipAbI6",
+	"X1yjDVtIZ0MgGTjGipCIRwWuIUhUoMyKfkByHBVIN5CogKkmUcHuBRIVSOBIVCDNh6IC9h6KCk5fEByM",
+	"/Co0kp+xHsRfO8+cRjpPaibpdzAEsEDSdAlw1tpk+fvF8qMHZKawTk7hZppqDpm9j66ynHYfHTyBO7R9",
+	"SvjHIFuadEVrjGUuB3Yk4xsa+miRq7fZcfLGhrZEJq1kElrCe9CdcCVR08RYH0g6/yX0JpIScO8/d5Nj",
+	"diiWuRzcAPmA8u2E8lEqIljDr6xrMzulfV8n4gMc5XMfu08l+8xF9oFpVmKU+7L3R5qOVH/Cbg/5e0CW",
+	"nTDIeoks11t24DzBMK3P3RqGMFfs+1bRf0RVek55FhKsTY5AWt+c6zjpTiSaoF7NnOFjH6RYnqvPjg+d",
+	"j/ghOq0IlBbuAAffB4zylFJxNkAvkqaRHIIRKwOmzaap12Gn+7y1VjiT1ZKKcsnMr36qKBeTkuABCTQu",
+	"1rUIpAjjBlN7b7HqUO4WmCsVeiVh6z/y9DSWjLxl6ovrq/dJhUmc94ob8ZbYID7UIxUdK6Ne867ttmuc",
+	"gM1m9SX/4Oy0Aj6TjQ/AZx+pJLVOXAyVGT5gEsHIT8VysGJ5fOSmcYMm9GPpUa+ZpgaERruxmf8Rb39p",
+	"5mchSdf4CWokeawuAioTc6WplyC5O/YHpx+obS3iuvs7Rqw/Y/VAPGdXPdOwy/K4z/WKKzqAiyLBcT0m",
+	"SlgKNBuiTcXuij/Kv09gabRgbQ3Mm0WNipUvc2WwQL8e6+3gxgOdrqN7I9hDeOVnqBgFUN/17xctLaE/",
+	"2Jz6blOfpJXo9EWvAVsfW3+Rs4o/lG4Zm1Pf2XZrusg5/UjLR0I1QxDt50gVso+V+O613XWlKw+4TaI0",
+	"RGLPRD93suxvbyhp+aj6Q96+1+8YlaMW40jrF+ddNI87gEHsmqdgSlYLJ2HliTfYb7noJ1FEGAC81mfM",
+	"nM6IjXGDVbMLE9BOkjH3SSh7N3kS3Z97SDcKFbcoJVVS2Y6TrDAMLNnDo0uTv3dTY3unNyZAXvVpV/6t",
+	"4uPl0tmKx8nPX+692lgaJxyQmRbpCQo7hCClQkGtFbvWFjuTYUfP3VRzj1hMcOfOmnhNDSRgv3hDSy0P",
+	"+dvjv7uI7UI4u69BdWRuIOXtQ1lEYEQDBDpSqW6Jd4qxOGU+sxNMwmZOd8p+2E9aQ+NUvuSlX9a+nhgF",
+	"nOHtFg4o57mBCRp3lM8cBimxMERKtlJh1SjATzmdVLoy9eXNqXumMYE3/CC0NLVUk+PsHBauBsAenbnA",
+	"OgM1HbmWgFIjsZiUtjXHug/c70I+cp8GB88oMm3m9PW3c3Wcj8YYqWRRyzkB18+0qX9rTUzRqk0u5W7B",
+	"9kP7uQRTtyiB54ssVpXQwkJt4LUYbVOBb5DJ7Tsme8qD/HY85D1hB3Ueh4r4z5cTCHSlVrb9OezBh7c2",
+	"TQ0MkWC1DvZS3A2qp/AHkH7D9oQ4xWvCBtvHtA3L/8bw0gb2vqpCyoJpVId0WbkkddqxRAcm/zpMpK71",
+	"rmjm3wUvkaZo6XA+2XWm6yxtTnG05fCHaNkr+sspcEZSMKF6zIK0GAOtpADVI7B8vL8MBPEfcFIcF9cG",
+	"cDZeltREbz/G1hENjT3P2XBJgh0UpfQaN/UVdqZIst0IZwj1YXCnJqqap4TGHhI5z0i77Y+twbgG7D2Z",
+	"iGnv7MHwbSdtXUa6aVQkiIg88USGVZMNEwor2QH8pnImqKHXIKSEYg0muDYCF2DAHotx74wEd2AFCMRn",
+	"glDVMZn5VBrs8mLBCO13z/B4ySN3JfQdGvc4wGyHrHMbumWRztsOJsc5GyAdXJRkuCC56qntEfZ7yy/t",
+	"s6MluGTcOxCZWidPeA+tcCE4rxdtnK9+FolIst0z6DiegxiMyxdbkcfYMlP4pFZAk5xbLM+/8niBfcfz",
+	"7zilANnn4GwenM3fVtYjPCvsVLIOig1xsT/cFFKavQPBAHrRun4VO1HxHZZH+cNEm0Lri6T9FURXj62U",
+	"Zt5CFyvSI3rmdenJt7Sv1fR92xqOetLK+tq3mHmmd8v19cbKr3p6Y2HM9o9oLl+2ri5ZgwXCjO2aO3bt",
+	"flKC3u5dTdrcsev02dBgb7DocE0lA6K9Q1bSbmgH2YEY3uVZDZZt9GVWUvudZKNeVUlFvAc+yiFala6N",
+	"A9FQkOy2lWEg8Q0riavh8NGj+Bl0xxCANWWn4FZR0p2mu3uaLcPv8rtE996LIDf72PpMdZ7TX0tsm4um",
+	"NX4NiFUxbJzb2bqTNfYf+Q5wbrdxzirMu50FdeJcvTjjbKYmUbtiEMo2ivF4OEfGElc2HRbgOcGZ0Zsx",
+	"CDX8913S7gTbzpOsLUrSbX7dJNj1svBlP6K0bZh60cfqjsXj9XA6GxhSeiaINcTJa8KZQ0q8kkhlU7S/",
+	"cCohk28NfLthLj364LT+AU4rSKGYNf/uHVhZuqLVcGJJn+832IvI7gLtSLv0cKLobJ/LMVffW8Movx3j",
+	"2936DuunknZaunLA2v6gKXgcdvymx8SVUHUoEQtXMQMSsPiCENO+MJNiaUw39TlSbm7jwSiNaHe3qOaU",
+	"RWCT1pNvWNMvVzNrl5Jj3OiWXa3lnSwfPgOBqU3AWs3cYGkWm2kXfoTeZzhYc4upj8JlEs/lVmId49aY",
+	"rx/EEgJI88WZd471VHOH2jBdPyTPYowkSUAoGinVZ1w1czq3cOyG/Cq38wJr/UoAccrXCkeajuAo59rb",
+	"QG5wLSFLHrfmR1iUGVrLGOQk5QmqULgaoo2RRPX1VwUMDFq2JsZLN+/aNTiCtW4IHHZnKVUURUjODL80",
+	"gBoQTrQCi2+8pE59fblUDAigo04uBJva6UlHD9i+hdKLOaTWI6H6L+m1U1HKYbUMjx6pQWGnDaj1hfKv",
+	"t03jGumlCfLe/HBp8glhGtbESjhIdtdGB4ba+ys7zeydZrZH/SUBfeLg2CsMUHQBHQLfpYQcd0FXFajP",
+	"4ImBmtPOKe7Un3u+XVb0O46yCIm58qY+VmIzqJU7vbwrmhgXN4fHqRvMTaBtyxMWXC166k6Qtx9K96Wd",
+	"okvC19j2MCqQHwewFPfre+40sjqK47io3aI1uEifZQZD9w3+ijndMt+P0303VILCHM0HSHBq4lrQZP75",
+	"XWr9xAYu5LM1MV36ebH8/K5VGAaTaGEVLhYegrPR+BVNqN8hQSvyT3XLxGRr5lcrmFUZg2TbtQx8U39D",
+	"iDXywGVTn0e6/8DLKo0brKIzzAQ5wYr1+rvSLHJqUDqH1l/kMNGviBdHkKOAH4ctAW2fCYutr9gMFs2P",
+	"bIlfPgHyAkNPexZLVbOQjKsbpDUjBrcAYNbQIGDGzG2YztNJbN0zbcMPcngF+ClgFOWYnRhfVhQweXLF",
+	"zI3xkLS3NZRmH5V+vE+0cSg0khvHCegZSU1IGZpzCXmAmB0Tk/CKcQMZ93y4IZr0498vE/S+mJwrGZV3",
+	"AMABCw1joVF/iE9dIrROEhPCLD6SmrgsuSGrVh19L5VPcmIOzPm7HpDpIEZI8av6ddHgWIGgGuUCV/pS",
+	"4Mh2WCMBD+detsNw1lenKjQV2ForHGlpCXUNkvoFiGF7FAqA764rDqB5d8euVLbBVaYJqmGGvZHe1oj3",
+	"vOMFl96/iB4O84l0up0zSjqmcmJ+Y0ZTJTEVKu2HC8I0asHMr1ILQX6VRoMaNwTU3nCEBjKAwLLmrtH6",
+	"BtRisNdSPcnU65ZBOGSd3akNyg7kI6lRrqdXBJJWQGpqoJBJ3sSISJj0isVrSFLtCsqWji0i7BGo+//6",
+	"OxtWKh5b169u5n4o37lPCvFtrRXI1/LkkjXxHMCgpiAoYQt/STZsV39aIj/GRU10/4Y2MiitBzfYCYh0",
+	"xNI398vPfsBEiRWhTxJV7YIkagKL6ZojsJLu+2Drnr1T/uEq5CoyoK2hcfIOVPuWhZNiRmvA0RvACGVP",
+	"T1/EqBHiwSLGdTZRAIkiCclKzhnspzEsYnjL1L9hW0HumWHGq0Wk9lCTIyNp/HBFIrHTeovGDWatm7bH",
+	"6JbtlaatYKnydR0+g/XvMbU+QYGTxxS39AVqrSJ6z9D4xq/PrdFJm3kxSDguFRiKD6ejNn3gtxErwfPx",
+	"BgNs3LmEbM2KBDuJvIxJGFgJxY0NcJxHJxkNGOXEdcJUHMBdaBMJsb61BHSortGkxBMld9nBRLxVaP7P",
+	"o03sv+ZuGW9uFWiv3G4ZzlSr8HV3JBHvjrS6bo52I0TdkdZu1lu3OxLtJoS3O9LKHuqONLfgD1hnGC+U",
+	"Ho6WZh9Zr6ZLs0vW4P2N8efdkYGBbtk1+6r7R854EHuxd4p2NibRk/m7EGHlUJmiALODvRO498FOHcjI",
+	"fvJOVm1rrdApqZcltaFTkjWybhmC3DsIi6GcuVqCWxDrXKnCFp2MjWDpOKTIHZON63Mztfeewq6KAUdz",
+	"r5Lk9rw245Hmlr2XzNt7G3DlkPu6C9aDJ4hW6VgsTz4hrGVrrUAaqxHb4UZuEOMkQUNiQg/Ib7SlFH28",
+	"aA0NWsWXtuVzR3rADqTtIy0fVn/2LKSFyHEsG9vBVKffA8Egx20Hono0NBrOUVj30uZSS23fepXGg7Kr",
+	"28OlnZtmtp9UGw0uNMQXhti8O1ieKYIZ53XR1MdLEzPU1Z8ztsmrqCZVsyXnXDou7g63ehdsQL+Dk/zO",
+	"mn8O+PoBX99LWkzWf3dMcCDoN9I+C3JM4iPhw2T/CuYnKG8FZgK0dECRq2cF4hzdvDVvzU9hYCJzYud0",
+	"aoZBL6htuCrf/aX001WWhhOoFxwX5ZiUREw/Y0N+oCEcUJIDSlJL2GcF6zE5is7Rnd6R8rB9ic/v1eaK",
+	"RRIoPdERELnBR0JwVj7ojMWnE6Dzf/u5ZmHSaA1kUS+ur0IjPvBbkBCl/CoNMsivbjz8ufzLE07ypImI",
+	"fDgJlXL1lUqbCK1oihju8saONCH95XDh7CoDDsn1iczcEV9kBeVXqvgQ/MSgsq9igUjVfMwpYQhkQ7E9",
+	"9G3WVOeqP7y0kgC+a2xhj0RxB74DofyAlR6w0veWlXor0G5HUseWnVRSd27NVEpW/RQeOcvdu4ekxDfW",
+	"gdHut1UUnUbHwEwxS8NnvSOXy8W58vWhACR0vmRaU6IsXpT2yIIXBCzGsJIwKiBm7KJTRWZzZghlgQW/",
+	"4NIt28EbpHe1ewTMgvVPDgv6Q5HxW+iDX0RnfJ2Gv4Aj924JHl4AWeHm7L4LH/tBMQ7kkG0u/IFI8ju3",
+	"EwZQVF+h422Qf5BEEqm0omoN/1QuZLxtFr10naDMFBYw5aL4HA8MiWMaYxFRLGcjZ5Bof3q//R4IRMPR",
+	"D/1TudCjadBxvojpAXdJYJDnLd0y/6w7qGrOzN8z80vrL65hgBRkegRGUVnzTzFJsWKKYTtC9Vflwl7K",
+	"WM4gBw0ld6UM1otcafSRNTEFcWwOnhQ3c/8ujU/7fZ12dgONRRwy89dIU7KA05MSM8DQQ+1gAUapRHwn",
+	"uZ+YRE1HbfwaWyGHH01r8BHmLrmaRGBbY28uGhwXUGqumfklqzC9tVZI9yma0gNtcXtovNaSqT8kWVI0",
+	"xSu0+B2kHp1CGEkf4v1p/u7te/3A1H/GpMCiVZi2ro9DGNpgYfPuY4gabQA5EE1P3xICsHlrfPPuEGVd",
+	"aIbDiMIVkrJVLN0c3rw7FJb7o6haWAjh0Q9g7pomqfDg/zb89xfHGv6P2PCvpoaPes7/x58i2wkw3P6J",
+	"cu3K77zL+3uiwzF6E5YWsy16tL1ADIId2Gn8NGD2wPmwLBskIkXrNhizS3M6lBTKr54+0yWcPnfyJETo",
+	"z09t5hdN4zHpbmpNGOXBBZQUi9bjmyAXGjcEso6H+sUUcnir+EP5zUNIoOeqkLv1MZI+SYJ8S9/MlSex",
+	"FigWFODUyAdQDuXq3MaDKafjFJ+niX3dS9P3IKUP+73zvdxxBkjmlqt3RuWP0x6l9LiG2OeUHv/Yf6TM",
+	"nrq1t4NqwIxW+ROHdiJVBYg8VD2BWe0ViTve+fettcK5rk8aPhScVqydfYlereev7Z2uBq0nrsSk5NZa",
+	"4dCVZOYK/lJs3pgbI1FqGw9GWYIsTfEBDQRFEvsNG8tT5etDREjxkEUhpqRSmH8zAjVggHgtWytvrLez",
+	"jviCxjBnmGXqRytMm/nVzeHxjflhh/TlVzeWHmNNmiXsyDRpGnMo+y2jx62lxcwZlyUVtkIgpBLVJqCN",
+	"XLc/j7FgEcj79QLJUbHtBVioZ8XGFgTMLkyDtHys/PBV6dFVVlx1xVEgczq5AQdnb0KfIX4uuqdreARb",
+	"Zo5g5J+OskKpe4GxKhTtpoatx9OUo+GE4Xa8CJNBiEh2ic0zkOvYY/K8hPApPlqRpDJ5k9hYfYCNBxPW",
+	"yDCIpnXzUoIQ7rbL9kqv4CrRCjx23QLASczrghvsBTRuMGRlCAowoJnVnlB+GDf8Lb52zG6SvL76rDT5",
+	"ZGutQBV2MdMvx3pU5asM7UPybJC8yINniECkV9cTAJn28CKdUWwdbYGEJ5AEJDOnY4s6fVH49ESX4DNQ",
+	"4Fk513GyWhM7ol9XUU/cpAD4rIB7TrO/2KIzNQoWBZza668nTcMozbww9XGEmipJYcnvan+PmpWDk9+x",
+	"S3VA9rtP0xGTScF9zMaaEaIFLKFBz24YaLhIsb6sfEngDC94oSeT+Jck8IiElvk7tF+NF+WKzlFl9Rqs",
+	"8Zvrr8e5U+kdvVsOWRogegkteGVgxpFoRJKzKeAt5BtCHDlvLxhVp4C3wI0Nl0UVXo3ciKDAcRziGD7N",
+	"XzlO3hSw0kjmBad7T74ANNytLzIV07knZIaZPkkK1RoPN4fohbUImEpMk/jUs4B4lwsJWURgvINEXW+6",
+	"LMcPKWlJvpJKkkczDUpvbyImxZVYFpjSoUwaVA2cSyp5CP/WPySpwZS5XO+T++rJICjSIWUABwMEJGJh",
+	"Ir01IQuYkAjuUMLx0H8i6onQ0tSExeOcw1V07nUqPmJSHO1puffWxbqpspFzy/4nFQJUJSsxodIuxPft",
+	"6/vm1jm8f3K6m9GOWfPEt4kZzahOUwqfEq/0XOjXpIzAs2mWhvneaiaYWu0YhPdSRamWV1keeQgyv7uz",
+	"FfGv4eBrcFryy8STTaQ+rymXBcoFJ1s2fVQx2dJjvDiIqK5fgd9Ff201W8vv0Ff73vlbGYXwJ1vuzGkU",
+	"FtrlMy/ug1fgIKTrHcGxd8oREN2VELBQf6Rxw7aY+OO8eFsNtc9Qaz5hdz+AlcZn32ePVLTik3CuXWSE",
+	"74L9//dDF/7Apv8DyeEgyqtOjuBPBd2xxiJpYiNqK9VjSWhdq/xq+fni5sxQ+fvVzR/vgWH716um/ra8",
+	"PIqGDVaXnlJq5tP1OXtBpeGMtF4Td4FY9VccvzGnv23qL0rXbm8Y90CZjZPqzuPlhSfE4sw7RWov+oZ2",
+	"GH+B5qAgMOSKpyRN3NNW1vYgB0Fgu63/W4P3rWszJL3uHQu3gENZS9oHBFjtU8qHK4q8pnihP24FLxJq",
+	"GxLIs8043GxGUisjwjm8Y/8j7GyiSrJc7a/tbfhtDr7ll7GxyQgkQmFdw838olWAqu4bw7+U5mfLv9wL",
+	"cY98GeYaaW5qqqGeNx8b3d5WWy1DTL7qScSDK4knZO3okUhwlzH32CQbpdZR41JaVLWUJGu7MTR2O2ZN",
+	"XrzFzN1+qsX11Wfh9cwvi8lEvKc3KV4MMtVXcEfagZc1xlvWGlNpn3gSQcu8f/ilAf+Fo0LWsMH5iK+L",
+	"Rhrwb23uQThQnYqqtbcdy8QiUe57m+S6AH+9N5FrnhuBvB/zXcGbzu9twCeMt4eBnu9H4UVGuUJDMOnv",
+	"pfygdfdpAPHOZlxSgJIJINUkWA72Y48iBelWk0wvZf8LgLtQzQHg3QgZrF/sPQjmo1gfEMxX23ngNE28",
+	"UKdHDEOYjAlT/0FwmBGwEQFDYwSnpz8XbeXtnQbJnk+RGU7CdO0K0sgnrTFMNjp7rOv4X8Ab7x2Hxv04",
+	"UWshnjR6qPfTg/Z+m7RwQQ+cYO82WUAh0xqb2j5lCPd82WxyD8WivbBo/1GtK0wT3LHotKOyA0hPfeh0",
+	"Fi7vAoneY4mNjLS/Dh63yJZ9V6oEHTC3Az/N75cxEhNXgLemTt5IXehBHut3n5rtf6WRA1p2QMsOaNku",
+	"07KdUjG37t+YVGKXKhkAaNttCF7+2Zp/WppEP7BBEoges9j0GxsLP6GFABsi4W3WzG3IWTJuQNMZY5Vl",
+	"qISp7edkgCRYyfjdhq++ozVq7kEdPJpJULB3kWzhjtjj9lUFD1ZmJMfdGoaZNL3ciSMo1mF1yunW2nOr",
+	"8Ix0MmNIfg9XpcBbpnyI2iFdVi4hu++Uwny9Bwi7J6QPIwUWPTtLtvW3Qlt39/WvIxckUZXUY1lwi35x",
+	"HlxxMUW5lJDsK+dtQCtRWnTXunEyv+qZOPVb2+65jaXxjcW1Sl5hvnMjrdnkb+GoL29O3SN0uvTd+Prr",
+	"WWcE78pW9vHaWVS0hCDLk4V0Z1YRi77Y5Yj3vzWkKsWydeeVqd839Qnh/+8hcR+CO02jWHr6Tfn7RTzk",
+	"S1Wnzk80POTED96xs+0CxxTZYudXudes8itjL0hoH029uLF4k5j4KUjlmZ9KdyhSBC78S9N4ihLdfeLl",
+	"p+Fg+vLG3GJ5/hXlvCF9PGtYEk/p1IHzA/9vABJY358fOQEA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
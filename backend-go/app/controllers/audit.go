@@ -0,0 +1,232 @@
+package controllers
+
+import (
+	"backend-go/logging"
+	"backend-go/schema"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"log/slog"
+	"maps"
+	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
+	"time"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// AuditController serves the audit trail written by [recordAudit].
+type AuditController struct {
+	Store Store
+}
+
+// auditCSVHeader is the first row of the CSV export.
+var auditCSVHeader = []string{
+	"id", "occurred_at", "user_id", "user_login", "action", "table",
+	"record_id", "column", "before", "after", "request_id",
+}
+
+// ListAuditLogs returns a page of the audit trail as an
+// [AuditLogsResponseGet], newest first.
+func (c *AuditController) ListAuditLogs(w http.ResponseWriter, r *http.Request, params ListAuditLogsParams) {
+	limit, offset := pageOf(params.Limit, params.Offset)
+	f := auditFilter(params.Table, params.RecordID, params.UserID, params.From, params.To)
+	f.Limit, f.Offset = limit, offset
+	logs, total, err := c.Store.Audit().List(r.Context(), f)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, AuditLogsResponseGet{
+		Logs:   logs,
+		Total:  total,
+		Limit:  limit,
+		Offset: offset,
+	})
+}
+
+// GetAuditLog returns one entry of the audit trail.
+func (c *AuditController) GetAuditLog(w http.ResponseWriter, r *http.Request, id ResourceID) {
+	e, err := c.Store.Audit().Find(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, e)
+}
+
+// ExportAuditLogs writes the entries selected like [ListAuditLogs] as CSV,
+// oldest first, with one row per changed column. Values are written as
+// JSON so that NULL and the empty string can be told apart.
+//
+// The rows are streamed; a failure after the first row ends the response
+// early and is only logged.
+func (c *AuditController) ExportAuditLogs(w http.ResponseWriter, r *http.Request, params ExportAuditLogsParams) {
+	f := auditFilter(params.Table, params.RecordID, params.UserID, params.From, params.To)
+	cw := csv.NewWriter(w)
+	started := false
+	// 見出し行は最初の行の直前に書く（該当なしでも見出し行だけを返す）
+	start := func() error {
+		started = true
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", `attachment; filename="audit.csv"`)
+		// Excel が UTF-8 と判別できるよう BOM を付ける
+		if _, err := w.Write([]byte("\ufeff")); err != nil {
+			return err
+		}
+		return cw.Write(auditCSVHeader)
+	}
+	err := c.Store.Audit().Each(r.Context(), f, func(e AuditLog) error {
+		if !started {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		for _, row := range auditRows(e) {
+			if err := cw.Write(row); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err == nil && !started {
+		err = start()
+	}
+	if err == nil {
+		cw.Flush()
+		err = cw.Error()
+	}
+	if err != nil && !started {
+		writeError(w, err)
+		return
+	}
+	if err != nil && !logging.RecordError(w, err) {
+		slog.Error("audit export failed", "error", err)
+	}
+}
+
+// auditRows returns the CSV rows of an entry in column order.
+func auditRows(e AuditLog) [][]string {
+	userID := ""
+	if e.UserID != nil {
+		userID = strconv.FormatInt(*e.UserID, 10)
+	}
+	var rows [][]string
+	for _, col := range slices.Sorted(maps.Keys(e.Changes)) {
+		ch := e.Changes[col]
+		rows = append(rows, []string{
+			strconv.FormatInt(e.ID, 10),
+			e.OccurredAt.In(tokyo).Format(schema.DateTimeLayout),
+			userID,
+			deref(e.UserLogin),
+			string(e.Action),
+			e.Table,
+			strconv.FormatInt(e.RecordID, 10),
+			col,
+			auditCSVValue(ch.Before),
+			auditCSVValue(ch.After),
+			deref(e.RequestID),
+		})
+	}
+	return rows
+}
+
+// auditCSVValue writes a value of an [AuditChange] as JSON, leaving NULL
+// empty.
+func auditCSVValue(v any) string {
+	if v == nil {
+		return ""
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// auditFilter converts the query parameters of the audit endpoints. Dates
+// are taken in Asia/Tokyo and to includes the whole day.
+func auditFilter(table *string, recordID, userID *int64, from, to *openapi_types.Date) AuditFilter {
+	f := AuditFilter{RecordID: recordID, UserID: userID}
+	if table != nil {
+		f.Table = *table
+	}
+	if from != nil {
+		t := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, tokyo)
+		f.From = &t
+	}
+	if to != nil {
+		t := time.Date(to.Year(), to.Month(), to.Day()+1, 0, 0, 0, 0, tokyo)
+		f.To = &t
+	}
+	return f
+}
+
+// recordAudit records a change of a row made by the caller, in the
+// transaction tx of the change. before and after are the row as returned by
+// the API; nil stands for no row. Only the fields that differ are kept.
+func recordAudit(ctx context.Context, tx Store, action AuditLogAction, table string, id int64, before, after any) error {
+	b, err := auditFields(before)
+	if err != nil {
+		return err
+	}
+	a, err := auditFields(after)
+	if err != nil {
+		return err
+	}
+	changes := map[string]AuditChange{}
+	for name := range b {
+		if _, ok := a[name]; !ok {
+			a[name] = nil
+		}
+	}
+	for name, v := range a {
+		if !reflect.DeepEqual(b[name], v) {
+			changes[name] = AuditChange{Before: b[name], After: v}
+		}
+	}
+
+	e := AuditLog{
+		OccurredAt: time.Now().Truncate(time.Second),
+		Action:     action,
+		Table:      table,
+		RecordID:   id,
+		Changes:    changes,
+	}
+	if au, ok := AuthUserFrom(ctx); ok {
+		e.UserID, e.UserLogin = &au.ID, &au.UserID
+	}
+	if rid := logging.RequestIDFrom(ctx); rid != "" {
+		e.RequestID = &rid
+	}
+	return tx.Audit().Record(ctx, e)
+}
+
+// auditFields returns the JSON fields of a row, with numbers kept as
+// [json.Number] like the values read back from audit_logs.
+func auditFields(row any) (map[string]any, error) {
+	data, err := json.Marshal(row)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]any{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&fields); err != nil {
+		return nil, err
+	}
+	if fields == nil {
+		fields = map[string]any{} // 行がない（null）
+	}
+	return fields, nil
+}
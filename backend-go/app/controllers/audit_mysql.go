@@ -0,0 +1,124 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+)
+
+// auditSelect selects audit_logs rows in the order scanned by [scanAudit].
+// Queries must add the [Scope] filter of audit_logs.
+const auditSelect = `SELECT id, occurred_at, user_id, user_login, action, table_name, record_id, changes, request_id
+FROM audit_logs`
+
+// mysqlAudit is the [AuditRepository] of [MySQLStore].
+type mysqlAudit struct {
+	s *MySQLStore
+}
+
+func (r mysqlAudit) Record(ctx context.Context, e AuditLog) error {
+	changes, err := json.Marshal(e.Changes)
+	if err != nil {
+		return err
+	}
+	_, err = r.s.q.ExecContext(ctx,
+		`INSERT INTO audit_logs (occurred_at, user_id, user_login, shipping_id, action, table_name, record_id, changes, request_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		e.OccurredAt, e.UserID, nullString(e.UserLogin), scopeOf(ctx).ShippingID,
+		e.Action, e.Table, e.RecordID, changes, nullString(e.RequestID))
+	return err
+}
+
+func (r mysqlAudit) List(ctx context.Context, f AuditFilter) ([]AuditLog, int, error) {
+	where, args := auditWhere(ctx, f)
+	var total int
+	if err := r.s.q.QueryRowContext(ctx, "SELECT COUNT(*) FROM audit_logs"+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	logs := []AuditLog{}
+	err := r.query(ctx, auditSelect+where+" ORDER BY id DESC LIMIT ? OFFSET ?",
+		append(args, f.Limit, f.Offset), func(e AuditLog) error {
+			logs = append(logs, e)
+			return nil
+		})
+	if err != nil {
+		return nil, 0, err
+	}
+	return logs, total, nil
+}
+
+func (r mysqlAudit) Find(ctx context.Context, id int64) (AuditLog, error) {
+	where, args := scopeOf(ctx).where("audit_logs", "", []string{"id = ?"}, []any{id})
+	e, err := scanAudit(r.s.q.QueryRowContext(ctx, auditSelect+where, args...))
+	if errors.Is(err, sql.ErrNoRows) {
+		return e, &NotFoundError{Resource: "audit log", ID: id}
+	}
+	return e, err
+}
+
+func (r mysqlAudit) Each(ctx context.Context, f AuditFilter, fn func(AuditLog) error) error {
+	where, args := auditWhere(ctx, f)
+	return r.query(ctx, auditSelect+where+" ORDER BY id", args, fn)
+}
+
+// query calls fn with each row of an [auditSelect] query.
+func (r mysqlAudit) query(ctx context.Context, query string, args []any, fn func(AuditLog) error) error {
+	rows, err := r.s.q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		e, err := scanAudit(rows)
+		if err != nil {
+			return err
+		}
+		if err := fn(e); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// auditWhere returns the WHERE clause of f within the [Scope] of ctx.
+func auditWhere(ctx context.Context, f AuditFilter) (string, []any) {
+	var conds []string
+	var args []any
+	if f.Table != "" {
+		conds = append(conds, "table_name = ?")
+		args = append(args, f.Table)
+	}
+	if f.RecordID != nil {
+		conds = append(conds, "record_id = ?")
+		args = append(args, *f.RecordID)
+	}
+	if f.UserID != nil {
+		conds = append(conds, "user_id = ?")
+		args = append(args, *f.UserID)
+	}
+	if f.From != nil {
+		conds = append(conds, "occurred_at >= ?")
+		args = append(args, *f.From)
+	}
+	if f.To != nil {
+		conds = append(conds, "occurred_at < ?")
+		args = append(args, *f.To)
+	}
+	return scopeOf(ctx).where("audit_logs", "", conds, args)
+}
+
+// scanAudit reads a row selected by [auditSelect].
+func scanAudit(s rowScanner) (AuditLog, error) {
+	var e AuditLog
+	var changes []byte
+	if err := s.Scan(&e.ID, &e.OccurredAt, &e.UserID, &e.UserLogin, &e.Action, &e.Table, &e.RecordID, &changes, &e.RequestID); err != nil {
+		return e, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(changes))
+	dec.UseNumber() // decimal の桁を保つ
+	return e, dec.Decode(&e.Changes)
+}
@@ -0,0 +1,116 @@
+package controllers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+func TestAudit_MasterChanges(t *testing.T) {
+	store := newSeededStore()
+	masters := &MastersController{Store: store, Tables: loadMasterTables(t)}
+	audit := &AuditController{Store: store}
+
+	req, w := newJSONRequest("POST", "/masters/kinds_master", map[string]any{"name": "納品書"})
+	masters.CreateMasterRecord(w, withAuth(req, 7, 10), "kinds_master")
+	var rec map[string]any
+	json.NewDecoder(w.Body).Decode(&rec)
+	id := int64(rec["id"].(float64))
+
+	req, w = newJSONRequest("PUT", "/masters/kinds_master", map[string]any{"name": "納品書（控）"})
+	masters.UpdateMasterRecord(w, withAuth(req, 7, 10), "kinds_master", id, UpdateMasterRecordParams{IfMatch: ifMatchV1})
+	req, w = newJSONRequest("DELETE", "/masters/kinds_master", nil)
+	masters.DeleteMasterRecord(w, withAuth(req, 7, 10), "kinds_master", id, DeleteMasterRecordParams{IfMatch: ptr[IfMatch](`"2"`)})
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("Expected 204, got %d", w.Code)
+	}
+
+	// 412 で失敗した更新は記録しない
+	req, w = newJSONRequest("PUT", "/masters/kinds_master", map[string]any{"name": "x"})
+	masters.UpdateMasterRecord(w, req, "kinds_master", id, UpdateMasterRecordParams{IfMatch: ifMatchV1})
+
+	req, w = newJSONRequest("GET", "/audit", nil)
+	audit.ListAuditLogs(w, req, ListAuditLogsParams{Table: ptr("kinds_master"), RecordID: &id})
+	var resp AuditLogsResponseGet
+	json.NewDecoder(w.Body).Decode(&resp)
+	if resp.Total != 3 {
+		t.Fatalf("unexpected logs %+v", resp)
+	}
+	// 新しい順
+	del, upd, cre := resp.Logs[0], resp.Logs[1], resp.Logs[2]
+	if cre.Action != AuditCreate || del.Action != AuditDelete || upd.Action != AuditUpdate {
+		t.Errorf("unexpected actions %v %v %v", cre.Action, upd.Action, del.Action)
+	}
+	if *upd.UserID != 7 || len(upd.Changes) != 2 ||
+		upd.Changes["name"].Before != "納品書" || upd.Changes["name"].After != "納品書（控）" {
+		t.Errorf("unexpected update %+v", upd)
+	}
+	if cre.Changes["name"].Before != nil || del.Changes["name"].After != nil {
+		t.Errorf("unexpected create/delete %+v %+v", cre, del)
+	}
+
+	req, w = newJSONRequest("GET", "/audit/export", nil)
+	audit.ExportAuditLogs(w, req, ExportAuditLogsParams{Table: ptr("kinds_master")})
+	body := strings.TrimPrefix(w.Body.String(), "\ufeff")
+	rows, err := csv.NewReader(strings.NewReader(body)).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 見出し + 登録（id, name, version）+ 更新（name, version）+ 削除（id, name, version）
+	if len(rows) != 9 || rows[0][0] != "id" || rows[4][7] != "name" || rows[4][8] != `"納品書"` {
+		t.Errorf("unexpected csv %q", rows)
+	}
+}
+
+func TestAudit_Filters(t *testing.T) {
+	store := newSeededStore()
+	users := &UsersController{Store: store}
+	audit := &AuditController{Store: store}
+
+	req, w := newJSONRequest("POST", "/users", UsersRequestPost{
+		GroupID: 1, UserID: "jdoe", Name: "John Doe", Email: "jdoe@example.com", DepartmentID: 3,
+	})
+	users.CreateUser(w, withShipper(req, 2))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	today := time.Now().In(tokyo)
+	cases := []struct {
+		name   string
+		req    *http.Request
+		params ListAuditLogsParams
+		want   int
+	}{
+		{"all", httptestRequest(), ListAuditLogsParams{}, 1},
+		{"user", httptestRequest(), ListAuditLogsParams{UserID: ptr(int64(1))}, 1},
+		{"other user", httptestRequest(), ListAuditLogsParams{UserID: ptr(int64(2))}, 0},
+		{"today", httptestRequest(), ListAuditLogsParams{
+			From: &openapi_types.Date{Time: today}, To: &openapi_types.Date{Time: today}}, 1},
+		{"yesterday", httptestRequest(), ListAuditLogsParams{To: &openapi_types.Date{Time: today.AddDate(0, 0, -1)}}, 0},
+		{"own shipper", withShipper(httptestRequest(), 2), ListAuditLogsParams{}, 1},
+		{"other shipper", withShipper(httptestRequest(), 1), ListAuditLogsParams{}, 0},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			audit.ListAuditLogs(w, tc.req, tc.params)
+			var resp AuditLogsResponseGet
+			json.NewDecoder(w.Body).Decode(&resp)
+			if resp.Total != tc.want {
+				t.Errorf("Expected %d logs, got %+v", tc.want, resp)
+			}
+		})
+	}
+}
+
+func httptestRequest() *http.Request {
+	req, _ := newJSONRequest("GET", "/audit", nil)
+	return req
+}
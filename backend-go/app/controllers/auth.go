@@ -0,0 +1,474 @@
+package controllers
+
+import (
+	"backend-go/logging"
+	"backend-go/mailer"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// SessionCookieName is the cookie that carries the session token. It must
+// match the cookieAuth security scheme in api.yml.
+const SessionCookieName = "session"
+
+// userAgentMaxLen is the length of user_sessions.user_agent.
+const userAgentMaxLen = 255
+
+// AuthController handles login, logout and session management.
+//
+// Sessions are stored server-side in user_sessions so that they can be
+// revoked at any time. Only the SHA-256 of a session token is stored; the
+// token itself is handed to the client once, at login.
+type AuthController struct {
+	Store Store
+	// SessionTTL is how long a session stays valid after login.
+	SessionTTL time.Duration
+	// CookieSecure marks the session cookie as HTTPS only.
+	CookieSecure bool
+	// MaxFailedLogins is the number of consecutive failed logins after which
+	// the account is locked for LockoutDuration.
+	MaxFailedLogins int
+	LockoutDuration time.Duration
+	// TOTPIssuer is the issuer shown in authenticator apps.
+	TOTPIssuer string
+	// PasswordResetTTL is how long an e-mailed reset token stays valid.
+	PasswordResetTTL time.Duration
+	// PasswordResetURL is the frontend page that receives the reset token.
+	PasswordResetURL string
+	// Mailer delivers password reset e-mails.
+	Mailer mailer.Sender
+	// MailFrom is the sender used when the mail template has none.
+	MailFrom string
+}
+
+var (
+	errInvalidCredentials = &UnauthorizedError{Message: "invalid user_id or password"}
+	errInvalidOTP         = &UnauthorizedError{Message: "invalid one-time password"}
+	errOTPRequired        = &UnauthorizedError{Message: "one-time password required"}
+)
+
+// AuthUser is the user authenticated by [AuthController.Authenticate].
+type AuthUser struct {
+	User
+	// SessionID identifies the session the request was made with.
+	SessionID int64
+}
+
+type authUserKey struct{}
+
+// WithAuthUser returns a copy of ctx carrying u.
+func WithAuthUser(ctx context.Context, u *AuthUser) context.Context {
+	return context.WithValue(ctx, authUserKey{}, u)
+}
+
+// AuthUserFrom returns the authenticated user stored in ctx, if any.
+func AuthUserFrom(ctx context.Context) (*AuthUser, bool) {
+	u, ok := ctx.Value(authUserKey{}).(*AuthUser)
+	return u, ok
+}
+
+// requiresAuth reports whether the operation being served declares a
+// security requirement. The generated router stores the scopes of each
+// security scheme in the context before running the handler middlewares.
+func requiresAuth(ctx context.Context) bool {
+	return ctx.Value(BearerAuthScopes) != nil || ctx.Value(CookieAuthScopes) != nil
+}
+
+// sessionToken returns the token from the Authorization header or, failing
+// that, from the session cookie.
+func sessionToken(r *http.Request) string {
+	if scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " "); ok && strings.EqualFold(scheme, "Bearer") {
+		return strings.TrimSpace(token)
+	}
+	if c, err := r.Cookie(SessionCookieName); err == nil {
+		return c.Value
+	}
+	return ""
+}
+
+// CheckCredentials is an [openapi3filter.AuthenticationFunc] for the request
+// validator. It only checks that a credential is present so that anonymous
+// requests are rejected with 401 before their body is validated; the session
+// itself is verified by [AuthController.Authenticate].
+func CheckCredentials(ctx context.Context, input *openapi3filter.AuthenticationInput) error {
+	r := input.RequestValidationInput.Request
+	switch input.SecuritySchemeName {
+	case "bearerAuth":
+		if scheme, _, ok := strings.Cut(r.Header.Get("Authorization"), " "); ok && strings.EqualFold(scheme, "Bearer") {
+			return nil
+		}
+	case "cookieAuth":
+		if _, err := r.Cookie(SessionCookieName); err == nil {
+			return nil
+		}
+	}
+	return input.NewError(errors.New("no credentials"))
+}
+
+// Authenticate is a handler middleware that resolves the session token to a
+// user and stores it in the request context (see [AuthUserFrom]).
+//
+// Operations without a security requirement, such as login, pass through.
+// Expired or revoked sessions and users with valid_flag=false are rejected
+// with 401.
+func (c *AuthController) Authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !requiresAuth(r.Context()) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		token := sessionToken(r)
+		if token == "" {
+			writeError(w, &UnauthorizedError{Message: "authentication required"})
+			return
+		}
+		u, err := c.Store.Sessions().Lookup(r.Context(), hashToken(token), time.Now())
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		logging.SetUser(r.Context(), u.ID, u.UserID)
+		next.ServeHTTP(w, r.WithContext(WithAuthUser(r.Context(), u)))
+	})
+}
+
+// Login verifies a [LoginRequest] and starts a new session.
+//
+// The token is returned in the [LoginResponse] body and as an HttpOnly
+// cookie. Unknown users, wrong passwords, users without a password and
+// disabled users all get the same 401 so that the response does not reveal
+// which user_ids exist. Users with two-factor authentication must also send
+// an otp_code or a recovery_code. Locked accounts get 429.
+func (c *AuthController) Login(w http.ResponseWriter, r *http.Request) {
+	var req LoginRequest // 自動生成された型
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+	ctx := r.Context()
+
+	id, err := c.authenticate(ctx, req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	token, err := newSessionToken()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	now := time.Now()
+	expiresAt := now.Add(c.SessionTTL).Truncate(time.Second)
+
+	// 期限切れのセッションはログインのついでに掃除する
+	if err := c.Store.Sessions().DeleteExpired(ctx, id, now); err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := c.Store.Sessions().Create(ctx, NewSession{
+		UserID:    id,
+		TokenHash: hashToken(token),
+		UserAgent: truncate(r.UserAgent(), userAgentMaxLen),
+		IPAddress: clientIP(r),
+		ExpiresAt: expiresAt,
+	}); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	u, err := c.Store.Users().Find(ctx, id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	http.SetCookie(w, c.sessionCookie(token, expiresAt))
+	writeJSON(w, http.StatusOK, LoginResponse{Token: token, ExpiresAt: expiresAt, User: u})
+}
+
+// authenticate checks the credentials of req and returns the user's ID.
+//
+// Wrong passwords and one-time passwords count as failed attempts; reaching
+// MaxFailedLogins locks the account for LockoutDuration. A successful login
+// resets the count.
+func (c *AuthController) authenticate(ctx context.Context, req LoginRequest) (int64, error) {
+	cred, err := c.Store.Credentials().ByUserID(ctx, req.UserID)
+	var notFound *NotFoundError
+	if errors.As(err, &notFound) {
+		// ユーザが存在しなくてもハッシュ比較を行い、応答時間で存在が分からないようにする
+		checkPassword("", req.Password)
+		return 0, errInvalidCredentials
+	}
+	if err != nil {
+		return 0, err
+	}
+	id := cred.ID
+
+	// ロック中は正しいパスワードでも受け付けない
+	if cred.LockedUntil != nil && cred.LockedUntil.After(time.Now()) {
+		return 0, &TooManyAttemptsError{Until: *cred.LockedUntil}
+	}
+	if !checkPassword(cred.PasswordHash, req.Password) {
+		return 0, c.loginFailed(ctx, id, errInvalidCredentials)
+	}
+	if !cred.ValidFlag {
+		return 0, errInvalidCredentials
+	}
+	if cred.TOTPEnabled {
+		if err := c.verifySecondFactor(ctx, id, cred.TOTPSecret, req); err != nil {
+			if errors.Is(err, errInvalidOTP) {
+				return 0, c.loginFailed(ctx, id, err)
+			}
+			return 0, err
+		}
+	}
+
+	if err := c.Store.Users().Unlock(ctx, id); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// loginFailed counts a failed attempt, locking the account when it reaches
+// MaxFailedLogins, and returns cause.
+func (c *AuthController) loginFailed(ctx context.Context, id int64, cause error) error {
+	lockUntil := time.Now().Add(c.LockoutDuration).Truncate(time.Second)
+	if err := c.Store.Credentials().LoginFailed(ctx, id, c.MaxFailedLogins, lockUntil); err != nil {
+		return err
+	}
+	return cause
+}
+
+// verifySecondFactor checks the otp_code or recovery_code of req. It returns
+// errOTPRequired when neither is given and errInvalidOTP when the code is
+// wrong or already used.
+func (c *AuthController) verifySecondFactor(ctx context.Context, id int64, secret string, req LoginRequest) error {
+	switch {
+	case req.OtpCode != nil:
+		step, ok := verifyTOTP(secret, *req.OtpCode, time.Now())
+		if !ok {
+			return errInvalidOTP
+		}
+		used, err := c.Store.Credentials().UseTOTPStep(ctx, id, step)
+		if err != nil {
+			return err
+		}
+		if !used {
+			return errInvalidOTP
+		}
+	case req.RecoveryCode != nil:
+		used, err := c.Store.Credentials().UseRecoveryCode(ctx, id, hashRecoveryCode(*req.RecoveryCode), time.Now())
+		if err != nil {
+			return err
+		}
+		if !used {
+			return errInvalidOTP
+		}
+	default:
+		return errOTPRequired
+	}
+	return nil
+}
+
+// Logout revokes the current session and clears the session cookie.
+func (c *AuthController) Logout(w http.ResponseWriter, r *http.Request) {
+	au, err := currentUser(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := c.Store.Sessions().Revoke(r.Context(), au.SessionID, au.ID); err != nil {
+		writeError(w, err)
+		return
+	}
+	http.SetCookie(w, c.sessionCookie("", time.Time{}))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetCurrentUser returns the logged-in user.
+func (c *AuthController) GetCurrentUser(w http.ResponseWriter, r *http.Request) {
+	au, err := currentUser(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, au.User)
+}
+
+// ChangePassword replaces the password of the logged-in user after checking
+// the current one, and revokes every other session of the user.
+//
+// A wrong current password is rejected with 422 rather than 401 so that
+// clients do not mistake it for an expired session.
+func (c *AuthController) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	au, err := currentUser(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var req ChangePasswordRequest // 自動生成された型
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+	ctx := r.Context()
+
+	cred, err := c.Store.Credentials().ByID(ctx, au.ID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if !checkPassword(cred.PasswordHash, req.CurrentPassword) {
+		writeError(w, &ValidationError{Message: "current_password is incorrect"})
+		return
+	}
+	newHash, err := hashPassword(req.NewPassword)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	err = c.Store.WithTx(ctx, func(tx Store) error {
+		if err := tx.Credentials().SetPassword(ctx, au.ID, newHash, false); err != nil {
+			return err
+		}
+		return tx.Sessions().RevokeAll(ctx, au.ID, au.SessionID)
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListSessions returns the active sessions of the logged-in user, newest
+// first.
+func (c *AuthController) ListSessions(w http.ResponseWriter, r *http.Request) {
+	au, err := currentUser(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	sessions, err := c.Store.Sessions().ListActive(r.Context(), au.ID, time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	for i := range sessions {
+		sessions[i].Current = sessions[i].ID == au.SessionID
+	}
+	writeJSON(w, http.StatusOK, SessionsResponseGet{Sessions: sessions})
+}
+
+// RevokeSession revokes one of the logged-in user's own sessions. Sessions
+// of other users are reported as 404.
+func (c *AuthController) RevokeSession(w http.ResponseWriter, r *http.Request, id ResourceID) {
+	au, err := currentUser(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := c.Store.Sessions().Revoke(r.Context(), id, au.ID); err != nil {
+		writeError(w, err)
+		return
+	}
+	if id == au.SessionID {
+		http.SetCookie(w, c.sessionCookie("", time.Time{}))
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// sessionCookie builds the session cookie. An empty token deletes it.
+func (c *AuthController) sessionCookie(token string, expires time.Time) *http.Cookie {
+	cookie := &http.Cookie{
+		Name:     SessionCookieName,
+		Value:    token,
+		Path:     "/",
+		Expires:  expires,
+		HttpOnly: true,
+		Secure:   c.CookieSecure,
+		SameSite: http.SameSiteLaxMode,
+	}
+	if token == "" {
+		cookie.MaxAge = -1
+	}
+	return cookie
+}
+
+// currentUser returns the authenticated user of r. It fails only when the
+// route was registered without [AuthController.Authenticate].
+func currentUser(r *http.Request) (*AuthUser, error) {
+	u, ok := AuthUserFrom(r.Context())
+	if !ok {
+		return nil, &UnauthorizedError{Message: "authentication required"}
+	}
+	return u, nil
+}
+
+// newSessionToken returns a random URL-safe token with 256 bits of entropy.
+func newSessionToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashToken returns the value stored in user_sessions.token_hash.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// hashPassword returns the bcrypt hash stored in users_master.password_hash.
+func hashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	return string(hash), err
+}
+
+// dummyHash is compared against when a user has no password, so that login
+// takes the same time whether or not the user exists.
+var dummyHash = sync.OnceValue(func() []byte {
+	hash, _ := bcrypt.GenerateFromPassword([]byte("dummy password"), bcrypt.DefaultCost)
+	return hash
+})
+
+// checkPassword reports whether password matches hash. An empty hash never
+// matches.
+func checkPassword(hash, password string) bool {
+	if hash == "" {
+		bcrypt.CompareHashAndPassword(dummyHash(), []byte(password))
+		return false
+	}
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
+
+// clientIP returns the host part of the remote address.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// truncate shortens s to at most n characters.
+func truncate(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
@@ -0,0 +1,292 @@
+package controllers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// mysqlSessions is the [SessionRepository] of [MySQLStore].
+type mysqlSessions struct {
+	s *MySQLStore
+}
+
+func (r mysqlSessions) Lookup(ctx context.Context, tokenHash string, now time.Time) (*AuthUser, error) {
+	var u AuthUser
+	var err error
+	u.User, err = scanUser(r.s.q.QueryRowContext(ctx,
+		"SELECT s.id, "+userColumns+" "+userFrom+`
+		INNER JOIN user_sessions s ON s.user_id = u.id
+		WHERE s.token_hash = ? AND s.revoked_at IS NULL AND s.expires_at > ? AND u.valid_flag = true`,
+		tokenHash, now), &u.SessionID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, &UnauthorizedError{Message: "session is invalid or expired"}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+func (r mysqlSessions) Create(ctx context.Context, s NewSession) error {
+	_, err := r.s.q.ExecContext(ctx,
+		`INSERT INTO user_sessions (user_id, token_hash, user_agent, ip_address, expires_at)
+		VALUES (?, ?, ?, ?, ?)`,
+		s.UserID, s.TokenHash, nullIfEmpty(s.UserAgent), nullIfEmpty(s.IPAddress), s.ExpiresAt)
+	return err
+}
+
+func (r mysqlSessions) DeleteExpired(ctx context.Context, userID int64, now time.Time) error {
+	_, err := r.s.q.ExecContext(ctx,
+		"DELETE FROM user_sessions WHERE user_id = ? AND expires_at <= ?", userID, now)
+	return err
+}
+
+func (r mysqlSessions) ListActive(ctx context.Context, userID int64, now time.Time) ([]Session, error) {
+	rows, err := r.s.q.QueryContext(ctx,
+		`SELECT id, user_agent, ip_address, created_at, expires_at FROM user_sessions
+		WHERE user_id = ? AND revoked_at IS NULL AND expires_at > ?
+		ORDER BY created_at DESC, id DESC`,
+		userID, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := []Session{}
+	for rows.Next() {
+		var s Session
+		if err := rows.Scan(&s.ID, &s.UserAgent, &s.IPAddress, &s.CreatedAt, &s.ExpiresAt); err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, s)
+	}
+	return sessions, rows.Err()
+}
+
+func (r mysqlSessions) Revoke(ctx context.Context, id, userID int64) error {
+	res, err := r.s.q.ExecContext(ctx,
+		"UPDATE user_sessions SET revoked_at = ? WHERE id = ? AND user_id = ? AND revoked_at IS NULL",
+		time.Now(), id, userID)
+	if err != nil {
+		return err
+	}
+	return requireAffected(res, "session", id)
+}
+
+func (r mysqlSessions) RevokeAll(ctx context.Context, userID, keep int64) error {
+	_, err := r.s.q.ExecContext(ctx,
+		"UPDATE user_sessions SET revoked_at = ? WHERE user_id = ? AND id <> ? AND revoked_at IS NULL",
+		time.Now(), userID, keep)
+	return err
+}
+
+// mysqlCredentials is the [CredentialRepository] of [MySQLStore].
+type mysqlCredentials struct {
+	s *MySQLStore
+}
+
+// credentialsSelect selects the columns scanned by [mysqlCredentials.scan].
+const credentialsSelect = `SELECT id, password_hash, valid_flag, locked_until, totp_enabled, one_time_passwd
+		FROM users_master`
+
+func (r mysqlCredentials) ByUserID(ctx context.Context, userID string) (Credentials, error) {
+	return r.scan(r.s.q.QueryRowContext(ctx, credentialsSelect+" WHERE user_id = ?", userID), userID)
+}
+
+func (r mysqlCredentials) ByID(ctx context.Context, id int64) (Credentials, error) {
+	return r.scan(r.s.q.QueryRowContext(ctx, credentialsSelect+" WHERE id = ?", id), id)
+}
+
+func (r mysqlCredentials) scan(row *sql.Row, key any) (Credentials, error) {
+	var c Credentials
+	var hash sql.NullString
+	err := row.Scan(&c.ID, &hash, &c.ValidFlag, &c.LockedUntil, &c.TOTPEnabled, &c.TOTPSecret)
+	if errors.Is(err, sql.ErrNoRows) {
+		return c, &NotFoundError{Resource: "user", ID: key}
+	}
+	c.PasswordHash = hash.String
+	return c, err
+}
+
+func (r mysqlCredentials) LoginFailed(ctx context.Context, id int64, max int, lockUntil time.Time) error {
+	// MySQL は SET を左から順に評価するため、locked_until を先に更新する
+	_, err := r.s.q.ExecContext(ctx,
+		`UPDATE users_master SET
+		locked_until = IF(failed_login_count + 1 >= ?, ?, locked_until),
+		failed_login_count = IF(failed_login_count + 1 >= ?, 0, failed_login_count + 1)
+		WHERE id = ?`,
+		max, lockUntil, max, id)
+	return err
+}
+
+func (r mysqlCredentials) SetPassword(ctx context.Context, id int64, hash string, unlock bool) error {
+	set := "password_hash = ?"
+	if unlock {
+		set += ", failed_login_count = 0, locked_until = NULL"
+	}
+	_, err := r.s.q.ExecContext(ctx, "UPDATE users_master SET "+set+" WHERE id = ?", hash, id)
+	return err
+}
+
+func (r mysqlCredentials) StartTOTP(ctx context.Context, id int64, secret string) error {
+	// 確認が済むまでは totp_enabled=false のまま保存する（再実行すると上書き）
+	_, err := r.s.q.ExecContext(ctx,
+		"UPDATE users_master SET one_time_passwd = ?, totp_last_step = NULL WHERE id = ? AND totp_enabled = false",
+		secret, id)
+	return err
+}
+
+func (r mysqlCredentials) EnableTOTP(ctx context.Context, id, step int64) error {
+	_, err := r.s.q.ExecContext(ctx,
+		"UPDATE users_master SET totp_enabled = true, totp_last_step = ? WHERE id = ?", step, id)
+	return err
+}
+
+func (r mysqlCredentials) DisableTOTP(ctx context.Context, id int64) error {
+	return r.s.inTx(ctx, func(q dbtx) error {
+		if _, err := q.ExecContext(ctx,
+			"UPDATE users_master SET one_time_passwd = '', totp_enabled = false, totp_last_step = NULL WHERE id = ?",
+			id); err != nil {
+			return err
+		}
+		_, err := q.ExecContext(ctx, "DELETE FROM user_recovery_codes WHERE user_id = ?", id)
+		return err
+	})
+}
+
+func (r mysqlCredentials) UseTOTPStep(ctx context.Context, id, step int64) (bool, error) {
+	res, err := r.s.q.ExecContext(ctx,
+		"UPDATE users_master SET totp_last_step = ? WHERE id = ? AND (totp_last_step IS NULL OR totp_last_step < ?)",
+		step, id, step)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+func (r mysqlCredentials) ReplaceRecoveryCodes(ctx context.Context, id int64, hashes []string) error {
+	return r.s.inTx(ctx, func(q dbtx) error {
+		if _, err := q.ExecContext(ctx, "DELETE FROM user_recovery_codes WHERE user_id = ?", id); err != nil {
+			return err
+		}
+		for _, hash := range hashes {
+			if _, err := q.ExecContext(ctx,
+				"INSERT INTO user_recovery_codes (user_id, code_hash) VALUES (?, ?)", id, hash); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r mysqlCredentials) UseRecoveryCode(ctx context.Context, id int64, hash string, now time.Time) (bool, error) {
+	res, err := r.s.q.ExecContext(ctx,
+		"UPDATE user_recovery_codes SET used_at = ? WHERE user_id = ? AND code_hash = ? AND used_at IS NULL",
+		now, id, hash)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+func (r mysqlCredentials) ResetRecipient(ctx context.Context, email string) (ResetRecipient, error) {
+	var u ResetRecipient
+	err := r.s.q.QueryRowContext(ctx,
+		"SELECT id, name, email FROM users_master WHERE email = ? AND valid_flag = true", email).
+		Scan(&u.ID, &u.Name, &u.Email)
+	if errors.Is(err, sql.ErrNoRows) {
+		return u, &NotFoundError{Resource: "user", ID: email}
+	}
+	return u, err
+}
+
+func (r mysqlCredentials) CreateResetToken(ctx context.Context, userID int64, tokenHash string, now, expiresAt time.Time) error {
+	return r.s.inTx(ctx, func(q dbtx) error {
+		if _, err := q.ExecContext(ctx,
+			"UPDATE password_reset_tokens SET used_at = ? WHERE user_id = ? AND used_at IS NULL", now, userID); err != nil {
+			return err
+		}
+		_, err := q.ExecContext(ctx,
+			"INSERT INTO password_reset_tokens (user_id, token_hash, expires_at) VALUES (?, ?, ?)",
+			userID, tokenHash, expiresAt)
+		return err
+	})
+}
+
+func (r mysqlCredentials) ConsumeResetToken(ctx context.Context, tokenHash string, now time.Time) (int64, bool, error) {
+	// 同じトークンの同時使用を防ぐため行ロックを取る
+	var tokenID, userID int64
+	err := r.s.q.QueryRowContext(ctx,
+		`SELECT t.id, t.user_id FROM password_reset_tokens t
+		INNER JOIN users_master u ON u.id = t.user_id
+		WHERE t.token_hash = ? AND t.used_at IS NULL AND t.expires_at > ? AND u.valid_flag = true
+		FOR UPDATE`,
+		tokenHash, now).Scan(&tokenID, &userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	if _, err := r.s.q.ExecContext(ctx,
+		"UPDATE password_reset_tokens SET used_at = ? WHERE id = ?", now, tokenID); err != nil {
+		return 0, false, err
+	}
+	return userID, true, nil
+}
+
+func (r mysqlCredentials) ReplaceFeedToken(ctx context.Context, userID int64, tokenHash string) error {
+	_, err := r.s.q.ExecContext(ctx,
+		`INSERT INTO calendar_feed_tokens (user_id, token_hash) VALUES (?, ?)
+		ON DUPLICATE KEY UPDATE token_hash = VALUES(token_hash), created_at = CURRENT_TIMESTAMP`,
+		userID, tokenHash)
+	return err
+}
+
+func (r mysqlCredentials) DeleteFeedToken(ctx context.Context, userID int64) (bool, error) {
+	res, err := r.s.q.ExecContext(ctx, "DELETE FROM calendar_feed_tokens WHERE user_id = ?", userID)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+func (r mysqlCredentials) FeedTokenUser(ctx context.Context, tokenHash string) (*AuthUser, error) {
+	u, err := scanUser(r.s.q.QueryRowContext(ctx,
+		userSelect+`
+		INNER JOIN calendar_feed_tokens t ON t.user_id = u.id
+		WHERE t.token_hash = ? AND u.valid_flag = true`, tokenHash))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, &NotFoundError{Resource: "calendar feed", ID: "token"}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &AuthUser{User: u}, nil
+}
+
+// mysqlMailTemplates is the [MailTemplateRepository] of [MySQLStore].
+type mysqlMailTemplates struct {
+	s *MySQLStore
+}
+
+func (r mysqlMailTemplates) Find(ctx context.Context, code string) (MailTemplate, bool, error) {
+	var t MailTemplate
+	err := r.s.q.QueryRowContext(ctx,
+		"SELECT title, body, email_from FROM system_mail_settings_master WHERE code = ? AND valid_flag = true", code).
+		Scan(&t.Title, &t.Body, &t.From)
+	if errors.Is(err, sql.ErrNoRows) {
+		return t, false, nil
+	}
+	return t, err == nil, err
+}
+
+// nullIfEmpty stores empty strings as NULL.
+func nullIfEmpty(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
@@ -0,0 +1,442 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/pquerna/otp/totp"
+)
+
+// 共通ヘルパー: モックDBと認証コントローラーを準備する
+func setupAuth(t *testing.T) (*AuthController, sqlmock.Sqlmock, func()) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %s", err)
+	}
+	ctrl := &AuthController{Store: NewMySQLStore(db), SessionTTL: time.Hour}
+	teardown := func() {
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unmet expectations: %s", err)
+		}
+		db.Close()
+	}
+	return ctrl, mock, teardown
+}
+
+// 共通ヘルパー: 認証済みリクエストを作成する
+func withAuth(req *http.Request, userID, sessionID int64) *http.Request {
+	u := &AuthUser{User: User{ID: userID, Name: "Alice", ValidFlag: true}, SessionID: sessionID}
+	return req.WithContext(WithAuthUser(req.Context(), u))
+}
+
+// 共通ヘルパー: 認証が必要な操作として生成コードと同じコンテキストを設定する
+func securedRequest(req *http.Request) *http.Request {
+	return req.WithContext(context.WithValue(req.Context(), BearerAuthScopes, []string{}))
+}
+
+func mustHash(t *testing.T, password string) string {
+	hash, err := hashPassword(password)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return hash
+}
+
+var loginColumns = []string{"id", "password_hash", "valid_flag", "locked_until", "totp_enabled", "one_time_passwd"}
+
+// 共通ヘルパー: ログイン時のユーザ検索結果
+func loginRow(hash any, valid bool, lockedUntil any, totpEnabled bool, secret string) *sqlmock.Rows {
+	return sqlmock.NewRows(loginColumns).AddRow(1, hash, valid, lockedUntil, totpEnabled, secret)
+}
+
+// 共通ヘルパー: ログイン成功後のセッション発行の期待値
+func expectLoginSucceeded(mock sqlmock.Sqlmock) {
+	mock.ExpectExec("UPDATE users_master SET failed_login_count = 0, locked_until = NULL").
+		WithArgs(int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM user_sessions WHERE user_id").
+		WithArgs(int64(1), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT INTO user_sessions").
+		WithArgs(int64(1), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(10, 1))
+	mock.ExpectQuery("SELECT u.id").WithArgs(int64(1)).WillReturnRows(userRow(1, "Alice"))
+}
+
+func TestAuthController_Login(t *testing.T) {
+	ctrl, mock, teardown := setupAuth(t)
+	defer teardown()
+
+	mock.ExpectQuery("SELECT id, password_hash, valid_flag, locked_until, totp_enabled, one_time_passwd").
+		WithArgs("alice").
+		WillReturnRows(loginRow(mustHash(t, "secret-pass"), true, nil, false, ""))
+	mock.ExpectExec("UPDATE users_master SET failed_login_count = 0, locked_until = NULL").
+		WithArgs(int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM user_sessions WHERE user_id").
+		WithArgs(int64(1), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT INTO user_sessions").
+		WithArgs(int64(1), sqlmock.AnyArg(), "test-agent", "192.0.2.1", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(10, 1))
+	mock.ExpectQuery("SELECT u.id").WithArgs(int64(1)).WillReturnRows(userRow(1, "Alice"))
+
+	req, w := newJSONRequest("POST", "/auth/login", LoginRequest{UserID: "alice", Password: "secret-pass"})
+	req.Header.Set("User-Agent", "test-agent")
+	ctrl.Login(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp LoginResponse
+	json.NewDecoder(w.Body).Decode(&resp)
+	if resp.Token == "" || resp.User.ID != 1 {
+		t.Errorf("unexpected response %+v", resp)
+	}
+	cookies := w.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Name != SessionCookieName || cookies[0].Value != resp.Token || !cookies[0].HttpOnly {
+		t.Errorf("unexpected cookies %+v", cookies)
+	}
+}
+
+func TestAuthController_Login_Rejected(t *testing.T) {
+	tests := []struct {
+		name    string
+		rows    func(t *testing.T) *sqlmock.Rows
+		input   string
+		counted bool // 失敗回数として数えるか
+	}{
+		{"wrong password", func(t *testing.T) *sqlmock.Rows {
+			return loginRow(mustHash(t, "secret-pass"), true, nil, false, "")
+		}, "wrong-pass", true},
+		{"disabled user", func(t *testing.T) *sqlmock.Rows {
+			return loginRow(mustHash(t, "secret-pass"), false, nil, false, "")
+		}, "secret-pass", false},
+		{"no password set", func(t *testing.T) *sqlmock.Rows {
+			return loginRow(nil, true, nil, false, "")
+		}, "", true},
+		{"unknown user", func(t *testing.T) *sqlmock.Rows {
+			return sqlmock.NewRows(loginColumns)
+		}, "secret-pass", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, mock, teardown := setupAuth(t)
+			defer teardown()
+
+			mock.ExpectQuery("FROM users_master WHERE user_id").WillReturnRows(tt.rows(t))
+			if tt.counted {
+				mock.ExpectExec("failed_login_count = IF").WillReturnResult(sqlmock.NewResult(0, 1))
+			}
+
+			req, w := newJSONRequest("POST", "/auth/login", LoginRequest{UserID: "alice", Password: tt.input})
+			ctrl.Login(w, req)
+
+			if w.Code != http.StatusUnauthorized {
+				t.Errorf("Expected 401, got %d", w.Code)
+			}
+			// どの理由でも同じメッセージを返す
+			if !strings.Contains(w.Body.String(), "invalid user_id or password") {
+				t.Errorf("unexpected message: %s", w.Body.String())
+			}
+		})
+	}
+}
+
+func TestAuthController_Login_LockAfterFailures(t *testing.T) {
+	ctrl, mock, teardown := setupAuth(t)
+	defer teardown()
+	ctrl.MaxFailedLogins = 3
+	ctrl.LockoutDuration = 10 * time.Minute
+
+	mock.ExpectQuery("FROM users_master WHERE user_id").
+		WillReturnRows(loginRow(mustHash(t, "secret-pass"), true, nil, false, ""))
+	mock.ExpectExec("locked_until = IF\\(failed_login_count \\+ 1 >= \\?, \\?, locked_until\\)").
+		WithArgs(3, sqlmock.AnyArg(), 3, int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	req, w := newJSONRequest("POST", "/auth/login", LoginRequest{UserID: "alice", Password: "wrong-pass"})
+	ctrl.Login(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected 401, got %d", w.Code)
+	}
+}
+
+func TestAuthController_Login_Locked(t *testing.T) {
+	ctrl, mock, teardown := setupAuth(t)
+	defer teardown()
+
+	// ロック中は正しいパスワードでも 429 を返し、失敗回数も増やさない
+	mock.ExpectQuery("FROM users_master WHERE user_id").
+		WillReturnRows(loginRow(mustHash(t, "secret-pass"), true, time.Now().Add(5*time.Minute), false, ""))
+
+	req, w := newJSONRequest("POST", "/auth/login", LoginRequest{UserID: "alice", Password: "secret-pass"})
+	ctrl.Login(w, req)
+
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("Expected 429, got %d", w.Code)
+	}
+	if ra := w.Header().Get("Retry-After"); ra != "300" {
+		t.Errorf("Expected Retry-After 300, got %q", ra)
+	}
+}
+
+func TestAuthController_Login_TOTP(t *testing.T) {
+	const secret = "JBSWY3DPEHPK3PXP"
+	code, err := totp.GenerateCodeCustom(secret, time.Now(), totpOpts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("code required", func(t *testing.T) {
+		ctrl, mock, teardown := setupAuth(t)
+		defer teardown()
+
+		mock.ExpectQuery("FROM users_master WHERE user_id").
+			WillReturnRows(loginRow(mustHash(t, "secret-pass"), true, nil, true, secret))
+
+		req, w := newJSONRequest("POST", "/auth/login", LoginRequest{UserID: "alice", Password: "secret-pass"})
+		ctrl.Login(w, req)
+
+		if w.Code != http.StatusUnauthorized || !strings.Contains(w.Body.String(), "one-time password required") {
+			t.Errorf("Expected 401 otp required, got %d: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("valid code", func(t *testing.T) {
+		ctrl, mock, teardown := setupAuth(t)
+		defer teardown()
+
+		mock.ExpectQuery("FROM users_master WHERE user_id").
+			WillReturnRows(loginRow(mustHash(t, "secret-pass"), true, nil, true, secret))
+		mock.ExpectExec("UPDATE users_master SET totp_last_step").
+			WithArgs(time.Now().Unix()/totpPeriod, int64(1), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		expectLoginSucceeded(mock)
+
+		req, w := newJSONRequest("POST", "/auth/login", LoginRequest{UserID: "alice", Password: "secret-pass", OtpCode: &code})
+		ctrl.Login(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("Expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("replayed code", func(t *testing.T) {
+		ctrl, mock, teardown := setupAuth(t)
+		defer teardown()
+
+		mock.ExpectQuery("FROM users_master WHERE user_id").
+			WillReturnRows(loginRow(mustHash(t, "secret-pass"), true, nil, true, secret))
+		// 同じ時間ステップは既に使用済み
+		mock.ExpectExec("UPDATE users_master SET totp_last_step").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("failed_login_count = IF").WillReturnResult(sqlmock.NewResult(0, 1))
+
+		req, w := newJSONRequest("POST", "/auth/login", LoginRequest{UserID: "alice", Password: "secret-pass", OtpCode: &code})
+		ctrl.Login(w, req)
+
+		if w.Code != http.StatusUnauthorized || !strings.Contains(w.Body.String(), "invalid one-time password") {
+			t.Errorf("Expected 401 invalid otp, got %d: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("recovery code", func(t *testing.T) {
+		ctrl, mock, teardown := setupAuth(t)
+		defer teardown()
+
+		mock.ExpectQuery("FROM users_master WHERE user_id").
+			WillReturnRows(loginRow(mustHash(t, "secret-pass"), true, nil, true, secret))
+		mock.ExpectExec("UPDATE user_recovery_codes SET used_at").
+			WithArgs(sqlmock.AnyArg(), int64(1), hashRecoveryCode("abcde-fghjk")).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		expectLoginSucceeded(mock)
+
+		// 大文字・ハイフンなしでも受け付ける
+		req, w := newJSONRequest("POST", "/auth/login", LoginRequest{UserID: "alice", Password: "secret-pass", RecoveryCode: ptr("ABCDEFGHJK")})
+		ctrl.Login(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("Expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+	})
+}
+
+func TestAuthController_Authenticate(t *testing.T) {
+	ctrl, mock, teardown := setupAuth(t)
+	defer teardown()
+
+	rows := sqlmock.NewRows(append([]string{"session_id"}, userRowColumns...)).
+		AddRow(10, 1, 1, "管理者", "alice", "Alice", "alice@example.com", 2, "総務部", nil, true, false, nil, 1)
+	mock.ExpectQuery("INNER JOIN user_sessions s").
+		WithArgs(hashToken("tok"), sqlmock.AnyArg()).
+		WillReturnRows(rows)
+
+	var got *AuthUser
+	handler := ctrl.Authenticate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ = AuthUserFrom(r.Context())
+	}))
+
+	req := securedRequest(httptest.NewRequest("GET", "/auth/me", nil))
+	req.Header.Set("Authorization", "Bearer tok")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if got == nil || got.ID != 1 || got.SessionID != 10 || got.GroupName != "管理者" {
+		t.Errorf("unexpected user in context: %+v", got)
+	}
+}
+
+func TestAuthController_Authenticate_Cookie(t *testing.T) {
+	ctrl, mock, teardown := setupAuth(t)
+	defer teardown()
+
+	// 失効・期限切れ・無効ユーザは検索条件で除外され、行が返らない
+	mock.ExpectQuery("s.revoked_at IS NULL AND s.expires_at > \\? AND u.valid_flag = true").
+		WithArgs(hashToken("old"), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows(append([]string{"session_id"}, userRowColumns...)))
+
+	handler := ctrl.Authenticate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("handler must not be called")
+	}))
+
+	req := securedRequest(httptest.NewRequest("GET", "/users", nil))
+	req.AddCookie(&http.Cookie{Name: SessionCookieName, Value: "old"})
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected 401, got %d", w.Code)
+	}
+	if w.Header().Get("WWW-Authenticate") == "" {
+		t.Error("WWW-Authenticate header is missing")
+	}
+}
+
+func TestAuthController_Authenticate_NoToken(t *testing.T) {
+	ctrl, _, teardown := setupAuth(t)
+	defer teardown()
+
+	called := false
+	handler := ctrl.Authenticate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+
+	// 認証不要な操作（ログイン）はそのまま通す
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/auth/login", nil))
+	if !called {
+		t.Error("public operation was rejected")
+	}
+
+	called = false
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, securedRequest(httptest.NewRequest("GET", "/users", nil)))
+	if called || w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected 401, got %d", w.Code)
+	}
+}
+
+func TestAuthController_Logout(t *testing.T) {
+	ctrl, mock, teardown := setupAuth(t)
+	defer teardown()
+
+	mock.ExpectExec("UPDATE user_sessions SET revoked_at = \\? WHERE id = \\?").
+		WithArgs(sqlmock.AnyArg(), int64(10), int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	req, w := newJSONRequest("POST", "/auth/logout", nil)
+	ctrl.Logout(w, withAuth(req, 1, 10))
+
+	if w.Code != http.StatusNoContent {
+		t.Errorf("Expected 204, got %d", w.Code)
+	}
+	cookies := w.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].MaxAge >= 0 {
+		t.Errorf("session cookie is not cleared: %+v", cookies)
+	}
+}
+
+func TestAuthController_ChangePassword(t *testing.T) {
+	ctrl, mock, teardown := setupAuth(t)
+	defer teardown()
+
+	mock.ExpectQuery("SELECT id, password_hash(.+) WHERE id").WithArgs(int64(1)).
+		WillReturnRows(loginRow(mustHash(t, "old-password"), true, nil, false, ""))
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE users_master SET password_hash").
+		WithArgs(bcryptOf("new-password"), int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	// 現在のセッション以外を失効させる
+	mock.ExpectExec("UPDATE user_sessions SET revoked_at").
+		WithArgs(sqlmock.AnyArg(), int64(1), int64(10)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	req, w := newJSONRequest("PUT", "/auth/password", ChangePasswordRequest{CurrentPassword: "old-password", NewPassword: "new-password"})
+	ctrl.ChangePassword(w, withAuth(req, 1, 10))
+
+	if w.Code != http.StatusNoContent {
+		t.Errorf("Expected 204, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestAuthController_ChangePassword_WrongCurrent(t *testing.T) {
+	ctrl, mock, teardown := setupAuth(t)
+	defer teardown()
+
+	mock.ExpectQuery("SELECT id, password_hash(.+) WHERE id").WithArgs(int64(1)).
+		WillReturnRows(loginRow(mustHash(t, "old-password"), true, nil, false, ""))
+
+	req, w := newJSONRequest("PUT", "/auth/password", ChangePasswordRequest{CurrentPassword: "guess", NewPassword: "new-password"})
+	ctrl.ChangePassword(w, withAuth(req, 1, 10))
+
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected 422, got %d", w.Code)
+	}
+}
+
+func TestAuthController_ListSessions(t *testing.T) {
+	ctrl, mock, teardown := setupAuth(t)
+	defer teardown()
+
+	now := time.Now()
+	mock.ExpectQuery("FROM user_sessions").WithArgs(int64(1), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_agent", "ip_address", "created_at", "expires_at"}).
+			AddRow(11, "curl", "192.0.2.2", now, now.Add(time.Hour)).
+			AddRow(10, nil, nil, now, now.Add(time.Hour)))
+
+	req, w := newJSONRequest("GET", "/auth/sessions", nil)
+	ctrl.ListSessions(w, withAuth(req, 1, 10))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp SessionsResponseGet
+	json.NewDecoder(w.Body).Decode(&resp)
+	if len(resp.Sessions) != 2 || resp.Sessions[0].Current || !resp.Sessions[1].Current || resp.Sessions[1].UserAgent != nil {
+		t.Errorf("unexpected sessions %+v", resp.Sessions)
+	}
+}
+
+func TestAuthController_RevokeSession_OtherUser(t *testing.T) {
+	ctrl, mock, teardown := setupAuth(t)
+	defer teardown()
+
+	// 他人のセッションは user_id 条件で一致せず 404 になる
+	mock.ExpectExec("UPDATE user_sessions SET revoked_at = \\? WHERE id = \\? AND user_id = \\?").
+		WithArgs(sqlmock.AnyArg(), int64(99), int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	req, w := newJSONRequest("DELETE", "/auth/sessions/99", nil)
+	ctrl.RevokeSession(w, withAuth(req, 1, 10), 99)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404, got %d", w.Code)
+	}
+}
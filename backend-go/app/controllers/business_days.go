@@ -0,0 +1,146 @@
+package controllers
+
+import (
+	"backend-go/holiday"
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// BusinessDaysController tells business days for the calendar screens and
+// the deadlines of the derived events. Saturdays, Sundays and the national
+// holidays computed by [holiday] are non-working days, unless
+// business_calendars_master says otherwise for all warehouses or for the
+// requested one.
+type BusinessDaysController struct {
+	Store Store
+}
+
+// maxBusinessDays is the longest period, in days, ListBusinessDays returns.
+const maxBusinessDays = 366
+
+// ListBusinessDays returns every day of the period from..to, both
+// inclusive, as a [BusinessDaysResponse].
+func (c *BusinessDaysController) ListBusinessDays(w http.ResponseWriter, r *http.Request, params ListBusinessDaysParams) {
+	from, to := holiday.DateOf(params.From.Time), holiday.DateOf(params.To.Time)
+	if to.Before(from) {
+		writeError(w, &BadRequestError{Err: fmt.Errorf("to must not be before from")})
+		return
+	}
+	if from.AddDays(maxBusinessDays - 1).Before(to) {
+		writeError(w, &BadRequestError{Err: fmt.Errorf("business days can be listed for up to %d days", maxBusinessDays)})
+		return
+	}
+	cal, err := loadBusinessCalendar(r.Context(), c.Store, params.Warehouse, from, to.AddDays(1))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	days := []BusinessDay{}
+	for d := from; !to.Before(d); d = d.AddDays(1) {
+		days = append(days, cal.day(d))
+	}
+	writeJSON(w, http.StatusOK, BusinessDaysResponse{Days: days})
+}
+
+// GetBusinessDay tells whether date is a business day.
+func (c *BusinessDaysController) GetBusinessDay(w http.ResponseWriter, r *http.Request, date BusinessDate, params GetBusinessDayParams) {
+	d := holiday.DateOf(date.Time)
+	cal, err := loadBusinessCalendar(r.Context(), c.Store, params.Warehouse, d, d.AddDays(1))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, cal.day(d))
+}
+
+// GetNextBusinessDay returns the first business day after date.
+func (c *BusinessDaysController) GetNextBusinessDay(w http.ResponseWriter, r *http.Request, date BusinessDate, params GetNextBusinessDayParams) {
+	c.addBusinessDays(w, r, date, 1, params.Warehouse)
+}
+
+// AddBusinessDays returns the business day days business days after date,
+// or before it when days is negative.
+func (c *BusinessDaysController) AddBusinessDays(w http.ResponseWriter, r *http.Request, date BusinessDate, params AddBusinessDaysParams) {
+	c.addBusinessDays(w, r, date, params.Days, params.Warehouse)
+}
+
+func (c *BusinessDaysController) addBusinessDays(w http.ResponseWriter, r *http.Request, date openapi_types.Date, n int, warehouse *string) {
+	start := holiday.DateOf(date.Time)
+	// 休業日の行は期間を指定して読むため、結果が読んだ期間に収まるまで期間を広げる
+	for span := 31 + 2*max(n, -n); ; span *= 2 {
+		from, to := start, start.AddDays(span)
+		if n < 0 {
+			from, to = start.AddDays(-span), start.AddDays(1)
+		}
+		cal, err := loadBusinessCalendar(r.Context(), c.Store, warehouse, from, to)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		if d := cal.AddBusinessDays(start, n); !d.Before(from) && d.Before(to) {
+			writeJSON(w, http.StatusOK, cal.day(d))
+			return
+		}
+	}
+}
+
+// businessCalendar is a [holiday.Calendar] with the names of the rows of
+// business_calendars_master it was built from.
+type businessCalendar struct {
+	holiday.Calendar
+	names map[holiday.Date]string
+}
+
+// loadBusinessCalendar returns the calendar of warehouse, or of all
+// warehouses when it is nil, with the overrides of [from, to). The rows of
+// the warehouse take precedence over those of all warehouses.
+func loadBusinessCalendar(ctx context.Context, store Store, warehouse *string, from, to holiday.Date) (businessCalendar, error) {
+	cal := businessCalendar{
+		Calendar: holiday.Calendar{Overrides: map[holiday.Date]bool{}},
+		names:    map[holiday.Date]string{},
+	}
+	rows, err := store.Calendar().Overrides(ctx, from.In(tokyo), to.In(tokyo))
+	if err != nil {
+		return cal, err
+	}
+	for _, own := range []bool{false, true} {
+		for _, o := range rows {
+			if own != (o.WarehouseCode != nil) || own && (warehouse == nil || *o.WarehouseCode != *warehouse) {
+				continue
+			}
+			d := holiday.DateOf(o.Date)
+			cal.Overrides[d] = o.Working
+			cal.names[d] = o.Name
+		}
+	}
+	return cal, nil
+}
+
+// day returns d as returned by the API. A national holiday on a weekend is
+// reported as a holiday.
+func (c businessCalendar) day(d holiday.Date) BusinessDay {
+	bd := BusinessDay{
+		Date:        openapi_types.Date{Time: d.In(time.UTC)},
+		BusinessDay: c.IsBusinessDay(d),
+		DayType:     DayTypeWeekday,
+	}
+	if working, ok := c.Overrides[d]; ok {
+		name := c.names[d]
+		bd.Name = &name
+		if bd.DayType = DayTypeClosure; working {
+			bd.DayType = DayTypeWorkingDay
+		}
+		return bd
+	}
+	if name, ok := holiday.Lookup(d); ok {
+		bd.Name = &name
+		bd.DayType = DayTypeHoliday
+	} else if wd := d.Weekday(); wd == time.Saturday || wd == time.Sunday {
+		bd.DayType = DayTypeWeekend
+	}
+	return bd
+}
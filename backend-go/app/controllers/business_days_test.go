@@ -0,0 +1,124 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestBusinessDays(t *testing.T) {
+	store := newSeededStore()
+	tables := loadMasterTables(t)
+	// 2026-05-07 は全倉庫の休業日、W1 だけ 05-08 も休業・05-09（土）は臨時営業日
+	addMaster(t, store, tables, "business_calendars_master", map[string]any{"calendar_date": "2026-05-07", "working": false, "name": "連休明け休業"})
+	addMaster(t, store, tables, "business_calendars_master", map[string]any{"warehouse_code": "W1", "calendar_date": "2026-05-08", "working": false, "name": "棚卸"})
+	addMaster(t, store, tables, "business_calendars_master", map[string]any{"warehouse_code": "W1", "calendar_date": "2026-05-09", "working": true, "name": "出荷対応"})
+	addMaster(t, store, tables, "business_calendars_master", map[string]any{"warehouse_code": "W1", "calendar_date": "2026-05-07", "working": true, "name": "W1は営業"})
+	c := &BusinessDaysController{Store: store}
+
+	get := func(t *testing.T, fn func(w http.ResponseWriter, r *http.Request), v any) int {
+		t.Helper()
+		req, w := newJSONRequest("GET", "/business-days", nil)
+		fn(w, req)
+		if w.Code == http.StatusOK {
+			if err := json.NewDecoder(w.Body).Decode(v); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return w.Code
+	}
+	w1 := ptr("W1")
+
+	t.Run("list", func(t *testing.T) {
+		var res BusinessDaysResponse
+		code := get(t, func(w http.ResponseWriter, r *http.Request) {
+			c.ListBusinessDays(w, r, ListBusinessDaysParams{From: *date(2026, 5, 2), To: *date(2026, 5, 9)})
+		}, &res)
+		if code != http.StatusOK || len(res.Days) != 8 {
+			t.Fatalf("Expected 8 days, got %d %+v", code, res.Days)
+		}
+		want := []DayType{DayTypeWeekend, DayTypeHoliday, DayTypeHoliday, DayTypeHoliday, DayTypeHoliday, DayTypeClosure, DayTypeWeekday, DayTypeWeekend}
+		for i, d := range res.Days {
+			if d.DayType != want[i] || d.BusinessDay != (want[i] == DayTypeWeekday) {
+				t.Errorf("%s: expected %s, got %+v", d.Date, want[i], d)
+			}
+		}
+		// 5月3日は日曜日の憲法記念日、6日は振替休日
+		if n := res.Days[1].Name; n == nil || *n != "憲法記念日" {
+			t.Errorf("unexpected name %v", n)
+		}
+		if n := res.Days[4].Name; n == nil || *n != "振替休日" {
+			t.Errorf("unexpected name %v", n)
+		}
+
+		code = get(t, func(w http.ResponseWriter, r *http.Request) {
+			c.ListBusinessDays(w, r, ListBusinessDaysParams{From: *date(2026, 5, 2), To: *date(2026, 5, 9), Warehouse: w1})
+		}, &res)
+		// 倉庫ごとの行が全倉庫共通の行に優先する
+		if code != http.StatusOK || res.Days[5].DayType != DayTypeWorkingDay || !res.Days[5].BusinessDay ||
+			res.Days[6].DayType != DayTypeClosure || res.Days[7].DayType != DayTypeWorkingDay {
+			t.Errorf("unexpected days for W1 %+v", res.Days)
+		}
+	})
+
+	t.Run("invalid period", func(t *testing.T) {
+		for _, p := range []ListBusinessDaysParams{
+			{From: *date(2026, 5, 9), To: *date(2026, 5, 2)},
+			{From: *date(2026, 1, 1), To: *date(2027, 1, 2)},
+		} {
+			code := get(t, func(w http.ResponseWriter, r *http.Request) { c.ListBusinessDays(w, r, p) }, nil)
+			if code != http.StatusBadRequest {
+				t.Errorf("Expected 400 for %v..%v, got %d", p.From, p.To, code)
+			}
+		}
+		// うるう年は366日まで
+		var res BusinessDaysResponse
+		code := get(t, func(w http.ResponseWriter, r *http.Request) {
+			c.ListBusinessDays(w, r, ListBusinessDaysParams{From: *date(2028, 1, 1), To: *date(2028, 12, 31)})
+		}, &res)
+		if code != http.StatusOK || len(res.Days) != 366 {
+			t.Errorf("Expected 366 days, got %d %d", code, len(res.Days))
+		}
+	})
+
+	cases := []struct {
+		name     string
+		fn       func(w http.ResponseWriter, r *http.Request)
+		want     string
+		business bool
+		wantType DayType
+	}{
+		{"is business day", func(w http.ResponseWriter, r *http.Request) {
+			c.GetBusinessDay(w, r, *date(2026, 5, 7), GetBusinessDayParams{})
+		}, "2026-05-07", false, DayTypeClosure},
+		{"next", func(w http.ResponseWriter, r *http.Request) {
+			c.GetNextBusinessDay(w, r, *date(2026, 5, 1), GetNextBusinessDayParams{})
+		}, "2026-05-08", true, DayTypeWeekday},
+		{"next for warehouse", func(w http.ResponseWriter, r *http.Request) {
+			c.GetNextBusinessDay(w, r, *date(2026, 5, 1), GetNextBusinessDayParams{Warehouse: w1})
+		}, "2026-05-07", true, DayTypeWorkingDay},
+		{"add", func(w http.ResponseWriter, r *http.Request) {
+			c.AddBusinessDays(w, r, *date(2026, 5, 1), AddBusinessDaysParams{Days: 3, Warehouse: w1})
+		}, "2026-05-11", true, DayTypeWeekday},
+		{"subtract", func(w http.ResponseWriter, r *http.Request) {
+			c.AddBusinessDays(w, r, *date(2026, 5, 8), AddBusinessDaysParams{Days: -1})
+		}, "2026-05-01", true, DayTypeWeekday},
+		{"zero", func(w http.ResponseWriter, r *http.Request) {
+			c.AddBusinessDays(w, r, *date(2026, 5, 3), AddBusinessDaysParams{Days: 0})
+		}, "2026-05-03", false, DayTypeHoliday},
+		{"far", func(w http.ResponseWriter, r *http.Request) {
+			c.AddBusinessDays(w, r, *date(2026, 1, 1), AddBusinessDaysParams{Days: 366})
+		}, "2027-07-05", true, DayTypeWeekday},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var d BusinessDay
+			if code := get(t, tc.fn, &d); code != http.StatusOK {
+				t.Fatalf("Expected 200, got %d", code)
+			}
+			if d.Date.String() != tc.want || d.BusinessDay != tc.business || d.DayType != tc.wantType {
+				t.Errorf("Expected %s (%v, %s), got %+v", tc.want, tc.business, tc.wantType, d)
+			}
+		})
+	}
+}
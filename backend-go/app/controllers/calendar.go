@@ -0,0 +1,258 @@
+package controllers
+
+import (
+	"backend-go/holiday"
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+)
+
+// maxDerivedMonths is the longest period, in months, for which events are
+// derived from the master data.
+const maxDerivedMonths = 12
+
+// billingMonthOffsets maps the names of billing_months_master to the number
+// of months from the closing date to the billing date.
+var billingMonthOffsets = map[string]int{"当月": 0, "翌月": 1, "翌々月": 2}
+
+// datedEvent is an [Event] with its start, by which events are ordered.
+type datedEvent struct {
+	Event
+	start time.Time
+}
+
+// deriveEvents generates the events of the business calendar falling in
+// [from, to), which must be midnights in Asia/Tokyo:
+//
+//   - the closing and billing dates of every billings_master row, for its
+//     shipper, moved to the previous business day when they fall on a
+//     non-working day;
+//   - the order cut-off times of order_deadlines_master, every business
+//     day;
+//   - the billing dates of services_useds_master;
+//   - the national holidays, and the closures and extra working days of
+//     business_calendars_master.
+//
+// Business days are those of all warehouses, see [loadBusinessCalendar].
+// The events are not stored. Their IDs are stable, so that clients can
+// track them across requests.
+func deriveEvents(ctx context.Context, store Store, from, to time.Time) ([]datedEvent, error) {
+	cal := store.Calendar()
+	schedules, err := cal.BillingSchedules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	deadlines, err := cal.OrderDeadlines(ctx)
+	if err != nil {
+		return nil, err
+	}
+	services, err := cal.ServiceBillings(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	overrides, err := cal.Overrides(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	// 翌月初めの締日・請求日が前営業日に繰り上がって期間に入ることがあるため、翌月末まで読む
+	business, err := loadBusinessCalendar(ctx, store, nil, holiday.DateOf(from), holiday.DateOf(monthOf(to).AddDate(0, 2, 0)))
+	if err != nil {
+		return nil, err
+	}
+
+	var events []datedEvent
+	for _, b := range schedules {
+		events = append(events, billingEvents(b, business.Calendar, from, to)...)
+	}
+	for _, d := range deadlines {
+		events = append(events, deadlineEvents(d, business.Calendar, from, to)...)
+	}
+	for _, s := range services {
+		events = append(events, allDayEvent(EventSourceServiceBilling, s.ID, s.BillingDate,
+			s.Name+" 請求日", EventStatusInfo, EventKindAll, nil))
+	}
+	for day := from; day.Before(to); day = day.AddDate(0, 0, 1) {
+		if name, ok := holiday.Lookup(holiday.DateOf(day)); ok {
+			e := allDayEvent(EventSourceHoliday, 0, day, name, EventStatusNone, EventKindAll, nil)
+			e.ID = fmt.Sprintf("%s-%s", EventSourceHoliday, day.Format("20060102"))
+			events = append(events, e)
+		}
+	}
+	for _, o := range overrides {
+		events = append(events, overrideEvent(o))
+	}
+	return events, nil
+}
+
+// billingEvents returns the closing dates of b in [from, to) and the billing
+// dates falling in [from, to), whose closing dates may be earlier. Dates
+// falling on a non-working day of cal are moved to the previous business
+// day; their IDs keep the nominal date.
+func billingEvents(b BillingSchedule, cal holiday.Calendar, from, to time.Time) []datedEvent {
+	offset, ok := billingMonthOffsets[b.BillingMonth]
+	if !ok {
+		// 請求月の名称から月数が分からないときは請求日を出さない
+		slog.Warn("unknown billing month", "billing_id", b.BillingID, "name", b.BillingMonth)
+	}
+	var events []datedEvent
+	// 請求日が期間に入る締日は最大2か月前。翌月初めの日は前営業日に繰り上がって期間に入ることがある
+	for m := monthOf(from).AddDate(0, -2, 0); m.Before(to.AddDate(0, 1, 0)); m = m.AddDate(0, 1, 0) {
+		closing := dayOfMonth(m, b.ClosingDay)
+		if e, ok := businessDayEvent(EventSourceClosing, b.BillingID, closing, cal, from, to,
+			"締日（"+b.ShippingName+"）", EventStatusWarning, EventKindShipper, &b.ShippingID); ok {
+			events = append(events, e)
+		}
+		if !ok {
+			continue
+		}
+		billing := dayOfMonth(m.AddDate(0, offset, 0), b.BillingDay)
+		if e, ok := businessDayEvent(EventSourceBilling, b.BillingID, billing, cal, from, to,
+			"請求日（"+b.ShippingName+"）", EventStatusInfo, EventKindShipper, &b.ShippingID); ok {
+			desc := closing.Format("2006年1月2日") + "締め分"
+			if e.Description != nil {
+				desc += "。" + *e.Description
+			}
+			e.Description = &desc
+			events = append(events, e)
+		}
+	}
+	return events
+}
+
+// businessDayEvent returns the derived event on the nominal day, moved to
+// the previous business day of cal when needed, unless it falls outside
+// [from, to). A moved event tells the nominal day in its description.
+func businessDayEvent(source EventSource, id int64, nominal time.Time, cal holiday.Calendar, from, to time.Time,
+	title string, status EventStatus, kind EventKind, shippingID *int64) (datedEvent, bool) {
+	day := cal.OnOrBefore(holiday.DateOf(nominal)).In(tokyo)
+	if !inPeriod(day, from, to) {
+		return datedEvent{}, false
+	}
+	e := allDayEvent(source, id, day, title, status, kind, shippingID)
+	e.ID = derivedEventID(source, id, nominal)
+	if !day.Equal(nominal) {
+		desc := nominal.Format("1月2日") + "が休業日のため前営業日に繰り上げ"
+		e.Description = &desc
+	}
+	return e, true
+}
+
+// deadlineEvents returns the cut-off time of d on every business day of cal
+// in [from, to). Times of 24:00 or later are periods counted from the order
+// day rather than times of the day, and are left out.
+func deadlineEvents(d OrderDeadline, cal holiday.Calendar, from, to time.Time) []datedEvent {
+	var hour, minute int
+	if _, err := fmt.Sscanf(d.Time, "%d:%d", &hour, &minute); err != nil ||
+		hour < 0 || hour >= 24 || minute < 0 || minute >= 60 {
+		return nil
+	}
+	var events []datedEvent
+	for day := from; day.Before(to); day = day.AddDate(0, 0, 1) {
+		if !cal.IsBusinessDay(holiday.DateOf(day)) {
+			continue
+		}
+		start := time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, tokyo)
+		events = append(events, datedEvent{start: start, Event: Event{
+			ID:     derivedEventID(EventSourceOrderDeadline, d.ID, day),
+			Title:  d.Name,
+			Start:  formatEventTime(start, false),
+			Status: EventStatusWait,
+			Kind:   EventKindWarehouse,
+			Source: EventSourceOrderDeadline,
+		}})
+	}
+	return events
+}
+
+// overrideEvent returns the closure or extra working day o. The days of a
+// single warehouse are for the warehouse staff.
+func overrideEvent(o CalendarOverride) datedEvent {
+	source, title, kind := EventSourceClosure, "休業日: "+o.Name, EventKindAll
+	if o.Working {
+		source, title = EventSourceWorkingDay, "臨時営業日: "+o.Name
+	}
+	if o.WarehouseCode != nil {
+		title += "（" + *o.WarehouseCode + "）"
+		kind = EventKindWarehouse
+	}
+	day := holiday.DateOf(o.Date).In(tokyo)
+	return allDayEvent(source, o.ID, day, title, EventStatusInfo, kind, nil)
+}
+
+// allDayEvent returns a derived event on day.
+func allDayEvent(source EventSource, id int64, day time.Time, title string,
+	status EventStatus, kind EventKind, shippingID *int64) datedEvent {
+	return datedEvent{start: day, Event: Event{
+		ID:         derivedEventID(source, id, day),
+		Title:      title,
+		Start:      formatEventTime(day, true),
+		AllDay:     true,
+		Status:     status,
+		Kind:       kind,
+		ShippingID: shippingID,
+		Source:     source,
+	}}
+}
+
+// derivedEventID identifies the event derived from the row id of the master
+// of source on day.
+func derivedEventID(source EventSource, id int64, day time.Time) string {
+	return fmt.Sprintf("%s-%d-%s", source, id, day.In(tokyo).Format("20060102"))
+}
+
+// derivedPeriod returns the period events are derived for from the bounds
+// of f, see [eventPeriod]. It must not exceed [maxDerivedMonths] months.
+func derivedPeriod(f EventFilter, now time.Time) (time.Time, time.Time, error) {
+	from, to := eventPeriod(f, now)
+	if to.After(from.AddDate(0, maxDerivedMonths, 0)) {
+		return from, to, &BadRequestError{Err: fmt.Errorf("events can be derived for up to %d months; narrow the period or set derived=false", maxDerivedMonths)}
+	}
+	return from, to, nil
+}
+
+// eventPeriod returns the period events are generated for from the bounds
+// of f. A missing bound extends the period to the end or the start of the
+// month of the other one, or of the current month.
+func eventPeriod(f EventFilter, now time.Time) (from, to time.Time) {
+	switch {
+	case f.From != nil:
+		from = *f.From
+	case f.To != nil:
+		from = monthOf(f.To.Add(-time.Nanosecond))
+	default:
+		from = monthOf(now)
+	}
+	if f.To != nil {
+		to = *f.To
+	} else {
+		to = monthOf(from).AddDate(0, 1, 0)
+	}
+	return from, to
+}
+
+// monthOf returns the first day of the month of t in Asia/Tokyo.
+func monthOf(t time.Time) time.Time {
+	t = t.In(tokyo)
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, tokyo)
+}
+
+// dayOfMonth returns the day of the month starting at m, the last day when
+// the month is shorter. A closing day of 31 thus means the end of the month.
+func dayOfMonth(m time.Time, day int) time.Time {
+	last := m.AddDate(0, 1, -1).Day()
+	return time.Date(m.Year(), m.Month(), max(1, min(day, last)), 0, 0, 0, 0, tokyo)
+}
+
+// inPeriod reports whether t falls in [from, to).
+func inPeriod(t, from, to time.Time) bool {
+	return !t.Before(from) && t.Before(to)
+}
+
+// matchesEvent reports whether a derived event passes the status and kind
+// filters of f.
+func matchesEvent(f EventFilter, e Event) bool {
+	return (len(f.Statuses) == 0 || slices.Contains(f.Statuses, e.Status)) &&
+		(f.Kind == "" || f.Kind == e.Kind)
+}
@@ -0,0 +1,130 @@
+package controllers
+
+import (
+	"backend-go/logging"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// CalendarFeedController publishes the schedule as iCalendar feeds that
+// Outlook or Google Calendar subscribe to.
+//
+// Calendar applications fetch the feed without a session, so each user gets
+// a feed token of their own. The feed shows what the user would see on the
+// schedule screen: it is served under the user's [Scope] and only while the
+// user is valid and has the events:read permission.
+type CalendarFeedController struct {
+	Store Store
+	// BaseURL is the externally reachable URL of the API the feed URLs
+	// start with.
+	BaseURL string
+}
+
+// calendarFeedPermission is the permission the owner of a feed token needs
+// for the feed to be served.
+const calendarFeedPermission = "events:read"
+
+// feedPastMonths is how many months before the current one a feed starts.
+// A feed covers [maxDerivedMonths] months from there.
+const feedPastMonths = 2
+
+// CreateCalendarFeed issues a feed token for the logged-in user, replacing
+// the previous one, and returns the feed URL with a 201 Created status. The
+// token is only stored hashed, so it cannot be shown again.
+func (c *CalendarFeedController) CreateCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	u, err := currentUser(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	token, err := newSessionToken()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := c.Store.Credentials().ReplaceFeedToken(r.Context(), u.ID, hashToken(token)); err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, CalendarFeed{URL: c.feedURL(token), Token: token})
+}
+
+// DeleteCalendarFeed revokes the feed token of the logged-in user and
+// returns 204 No Content, or 404 when the user has none.
+func (c *CalendarFeedController) DeleteCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	u, err := currentUser(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	ok, err := c.Store.Credentials().DeleteFeedToken(r.Context(), u.ID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if !ok {
+		writeError(w, &NotFoundError{Resource: "calendar feed", ID: u.ID})
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetCalendarFeed returns the events of the token owner, including the
+// derived ones, as text/calendar. The period starts [feedPastMonths] months
+// before the current month. Events can be filtered by status and kind as
+// with [EventsController.ListEvents].
+//
+// Unknown tokens, invalid owners and owners without events:read all get the
+// same 404, so that the response does not reveal which tokens exist.
+func (c *CalendarFeedController) GetCalendarFeed(w http.ResponseWriter, r *http.Request, params GetCalendarFeedParams) {
+	ctx := r.Context()
+	u, err := c.Store.Credentials().FeedTokenUser(ctx, hashToken(params.Token))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	granted, err := c.Store.Permissions().Has(ctx, u.GroupID, calendarFeedPermission)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if !granted {
+		writeError(w, &NotFoundError{Resource: "calendar feed", ID: "token"})
+		return
+	}
+	// 以降はトークンの持ち主としてイベントを読む（荷主側ユーザは自分の荷主の分だけ）
+	logging.SetUser(ctx, u.ID, u.UserID)
+	ctx = WithAuthUser(ctx, u)
+
+	now := time.Now()
+	from := monthOf(now).AddDate(0, -feedPastMonths, 0)
+	to := from.AddDate(0, maxDerivedMonths, 0)
+	f := EventFilter{From: &from, To: &to}
+	if params.Status != nil {
+		f.Statuses = *params.Status
+	}
+	if params.Kind != nil {
+		f.Kind = *params.Kind
+	}
+	all, err := collectEvents(ctx, c.Store, f, true)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	events := make([]Event, len(all))
+	for i, e := range all {
+		events[i] = e.Event
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="schedule.ics"`)
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(icsCalendar("スケジュール（"+u.Name+"）", events, now)))
+}
+
+// feedURL returns the URL of the feed with token.
+func (c *CalendarFeedController) feedURL(token string) string {
+	return strings.TrimSuffix(c.BaseURL, "/") + "/calendar-feed.ics?" + url.Values{"token": {token}}.Encode()
+}
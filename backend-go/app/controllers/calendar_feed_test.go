@@ -0,0 +1,145 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	middleware "github.com/deepmap/oapi-codegen/pkg/chi-middleware"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/go-chi/chi/v5"
+)
+
+func TestICSCalendar(t *testing.T) {
+	desc := "1行目\n2行目; A,B"
+	end := "2026-02-12"
+	events := []Event{
+		{ID: "12", Title: "入荷", Start: "2026-02-02T09:00:00", Kind: EventKindShipper, Status: EventStatusError, Source: EventSourceManual, Version: 3},
+		{ID: "closing-3-20260228", Title: "締日（荷主A）", Start: "2026-02-27", AllDay: true, Kind: EventKindShipper, Source: EventSourceClosing, Description: &desc},
+		{ID: "13", Title: strings.Repeat("長い件名", 10), Start: "2026-02-10", End: &end, AllDay: true, Kind: EventKindAll, Source: EventSourceManual, Version: 1},
+	}
+	got := icsCalendar("スケジュール", events, time.Date(2026, 2, 1, 0, 0, 0, 0, tokyo))
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\nVERSION:2.0\r\n",
+		"TZID:Asia/Tokyo\r\n",
+		"UID:event-12@shimada-trading\r\nDTSTAMP:20260131T150000Z\r\nDTSTART;TZID=Asia/Tokyo:20260202T090000\r\n",
+		"CATEGORIES:shipper,error\r\nSEQUENCE:3\r\n",
+		"UID:closing-3-20260228@shimada-trading\r\n",
+		"DTSTART;VALUE=DATE:20260227\r\nDTEND;VALUE=DATE:20260228\r\n",
+		`DESCRIPTION:1行目\n2行目\; A\,B` + "\r\n",
+		"DTSTART;VALUE=DATE:20260210\r\nDTEND;VALUE=DATE:20260212\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in\n%s", want, got)
+		}
+	}
+	// 75 オクテットを超える行は折り返す（UTF-8 の途中では切らない）
+	for _, l := range strings.Split(strings.TrimSuffix(got, "\r\n"), "\r\n") {
+		if len(l) > 75 || !utf8Valid(l) {
+			t.Errorf("invalid line %q", l)
+		}
+	}
+	if !strings.Contains(got, "\r\n ") {
+		t.Error("long line is not folded")
+	}
+}
+
+func utf8Valid(s string) bool {
+	return strings.ToValidUTF8(s, "�") == s
+}
+
+func TestCalendarFeed(t *testing.T) {
+	store := newSeededStore()
+	store.AddPermission(Permission{ID: 100, Code: "events:read"})
+	ctx := t.Context()
+	alice, err := store.Users().Create(ctx, UserInput{GroupID: 1, UserID: "alice", Name: "Alice", Email: "alice@example.com", DepartmentID: 2, ValidFlag: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.Grant(1, "events:read")
+
+	events := &EventsController{Store: store}
+	if _, code := postEvent(t, events, EventRequest{Title: "入荷", Start: time.Now().In(tokyo).Format("2006-01-02") + "T09:00:00"}, nil); code != http.StatusCreated {
+		t.Fatalf("Expected 201, got %d", code)
+	}
+
+	c := &CalendarFeedController{Store: store, BaseURL: "https://example.com/api/"}
+	req, w := newJSONRequest("POST", "/auth/calendar-feed", nil)
+	u, _ := store.Users().Find(ctx, alice)
+	req = req.WithContext(WithAuthUser(req.Context(), &AuthUser{User: u}))
+	c.CreateCalendarFeed(w, req)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var feed CalendarFeed
+	json.NewDecoder(w.Body).Decode(&feed)
+	if !strings.HasPrefix(feed.URL, "https://example.com/api/calendar-feed.ics?token=") || feed.Token == "" {
+		t.Fatalf("unexpected feed %+v", feed)
+	}
+
+	// 仕様書のバリデーションを通して、セッションなしで取得できること
+	swagger, err := GetSwagger()
+	if err != nil {
+		t.Fatal(err)
+	}
+	swagger.Servers = nil
+	r := chi.NewRouter()
+	r.Use(middleware.OapiRequestValidatorWithOptions(swagger, &middleware.Options{
+		ErrorHandler: ValidatorErrorHandler,
+		Options:      openapi3filter.Options{AuthenticationFunc: CheckCredentials},
+	}))
+	HandlerWithOptions(&Server{CalendarFeedController: c}, ChiServerOptions{BaseRouter: r})
+	get := func(query string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", "/calendar-feed.ics?"+query, nil))
+		return w
+	}
+
+	w = get("token=" + url.QueryEscape(feed.Token))
+	if w.Code != http.StatusOK || !strings.HasPrefix(w.Header().Get("Content-Type"), "text/calendar") {
+		t.Fatalf("Expected 200 text/calendar, got %d %s: %s", w.Code, w.Header().Get("Content-Type"), w.Body.String())
+	}
+	body := w.Body.String()
+	if !strings.Contains(body, "SUMMARY:入荷\r\n") || !strings.Contains(body, "X-WR-CALNAME:スケジュール（Alice）\r\n") {
+		t.Errorf("unexpected feed\n%s", body)
+	}
+	// 区分で絞り込める
+	w = get("token=" + url.QueryEscape(feed.Token) + "&kind=warehouse")
+	if w.Code != http.StatusOK || strings.Contains(w.Body.String(), "SUMMARY:入荷") {
+		t.Errorf("kind filter is ignored: %d", w.Code)
+	}
+
+	// 権限がなくなったら 404
+	if err := store.Permissions().SetGroupCodes(ctx, 1, nil); err != nil {
+		t.Fatal(err)
+	}
+	if w = get("token=" + url.QueryEscape(feed.Token)); w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 without events:read, got %d", w.Code)
+	}
+	store.Grant(1, "events:read")
+
+	// 失効後は 404
+	req, w = newJSONRequest("DELETE", "/auth/calendar-feed", nil)
+	c.DeleteCalendarFeed(w, req.WithContext(WithAuthUser(req.Context(), &AuthUser{User: u})))
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("Expected 204, got %d", w.Code)
+	}
+	if w = get("token=" + url.QueryEscape(feed.Token)); w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 after revocation, got %d", w.Code)
+	}
+	req, w = newJSONRequest("DELETE", "/auth/calendar-feed", nil)
+	c.DeleteCalendarFeed(w, req.WithContext(WithAuthUser(req.Context(), &AuthUser{User: u})))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 without a feed, got %d", w.Code)
+	}
+	// トークンがなければ 400
+	if w = get(""); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 without a token, got %d", w.Code)
+	}
+}
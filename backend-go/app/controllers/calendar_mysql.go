@@ -0,0 +1,99 @@
+package controllers
+
+import (
+	"backend-go/schema"
+	"context"
+	"time"
+)
+
+// mysqlCalendar is the [CalendarRepository] of [MySQLStore].
+type mysqlCalendar struct {
+	s *MySQLStore
+}
+
+func (r mysqlCalendar) BillingSchedules(ctx context.Context) ([]BillingSchedule, error) {
+	where, args := scopeOf(ctx).where("billings_master", "b", nil, nil)
+	rows, err := r.s.q.QueryContext(ctx,
+		`SELECT b.id, b.shipping_id, s.name, c.day, m.name, d.day
+FROM billings_master b
+INNER JOIN shippings_master s ON s.id = b.shipping_id
+INNER JOIN closing_dates_master c ON c.id = b.closing_date_id
+INNER JOIN billing_months_master m ON m.id = b.billing_date_kind
+INNER JOIN billing_days_master d ON d.id = b.billing_date_id`+where+" ORDER BY b.id", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []BillingSchedule
+	for rows.Next() {
+		var b BillingSchedule
+		if err := rows.Scan(&b.BillingID, &b.ShippingID, &b.ShippingName, &b.ClosingDay, &b.BillingMonth, &b.BillingDay); err != nil {
+			return nil, err
+		}
+		out = append(out, b)
+	}
+	return out, rows.Err()
+}
+
+func (r mysqlCalendar) OrderDeadlines(ctx context.Context) ([]OrderDeadline, error) {
+	rows, err := r.s.q.QueryContext(ctx,
+		"SELECT id, name, time FROM order_deadlines_master WHERE valid_flag = true ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []OrderDeadline
+	for rows.Next() {
+		var d OrderDeadline
+		if err := rows.Scan(&d.ID, &d.Name, &d.Time); err != nil {
+			return nil, err
+		}
+		out = append(out, d)
+	}
+	return out, rows.Err()
+}
+
+func (r mysqlCalendar) ServiceBillings(ctx context.Context, from, to time.Time) ([]ServiceBilling, error) {
+	// billing_date は DATE 型のため日付の文字列で比べる
+	rows, err := r.s.q.QueryContext(ctx,
+		`SELECT id, name, billing_date FROM services_useds_master
+WHERE billing_date >= ? AND billing_date < ? ORDER BY billing_date, id`,
+		from.In(tokyo).Format(schema.DateLayout), to.In(tokyo).Format(schema.DateLayout))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []ServiceBilling
+	for rows.Next() {
+		var sb ServiceBilling
+		if err := rows.Scan(&sb.ID, &sb.Name, &sb.BillingDate); err != nil {
+			return nil, err
+		}
+		out = append(out, sb)
+	}
+	return out, rows.Err()
+}
+
+func (r mysqlCalendar) Overrides(ctx context.Context, from, to time.Time) ([]CalendarOverride, error) {
+	rows, err := r.s.q.QueryContext(ctx,
+		`SELECT id, warehouse_code, calendar_date, working, name FROM business_calendars_master
+WHERE calendar_date >= ? AND calendar_date < ? ORDER BY calendar_date, id`,
+		from.In(tokyo).Format(schema.DateLayout), to.In(tokyo).Format(schema.DateLayout))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []CalendarOverride
+	for rows.Next() {
+		var o CalendarOverride
+		if err := rows.Scan(&o.ID, &o.WarehouseCode, &o.Date, &o.Working, &o.Name); err != nil {
+			return nil, err
+		}
+		out = append(out, o)
+	}
+	return out, rows.Err()
+}
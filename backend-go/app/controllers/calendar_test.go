@@ -0,0 +1,174 @@
+package controllers
+
+import (
+	"backend-go/holiday"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// 共通ヘルパー: マスタに行を追加して ID を返す
+func addMaster(t *testing.T, store Store, tables map[string]*MasterTable, table string, values map[string]any) int64 {
+	t.Helper()
+	id, err := store.Masters().Create(t.Context(), tables[table], values)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return id
+}
+
+func TestBillingEvents(t *testing.T) {
+	from := time.Date(2026, 2, 1, 0, 0, 0, 0, tokyo)
+	to := time.Date(2026, 4, 1, 0, 0, 0, 0, tokyo)
+	b := BillingSchedule{BillingID: 3, ShippingID: 1, ShippingName: "荷主A", ClosingDay: 31, BillingMonth: "翌月", BillingDay: 10}
+
+	var got []string
+	for _, e := range billingEvents(b, holiday.Calendar{}, from, to) {
+		got = append(got, e.ID+" "+e.Start)
+	}
+	// 31日締めは月末、請求日は前月の締め分から。2月28日は土曜日のため前営業日に繰り上げ
+	want := []string{
+		"billing-3-20260210 2026-02-10",
+		"closing-3-20260228 2026-02-27",
+		"billing-3-20260310 2026-03-10",
+		"closing-3-20260331 2026-03-31",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Expected %v, got %v", want, got)
+			break
+		}
+	}
+
+	// 請求月が不明なら締日だけ
+	b.BillingMonth = "不明"
+	if events := billingEvents(b, holiday.Calendar{}, from, to); len(events) != 2 {
+		t.Errorf("Expected only closing dates, got %+v", events)
+	}
+
+	// 翌月1日締めが休業日なら前月末に繰り上がって期間に入る
+	b = BillingSchedule{BillingID: 4, ShippingID: 1, ShippingName: "荷主A", ClosingDay: 1, BillingMonth: "当月", BillingDay: 1}
+	cal := holiday.Calendar{Overrides: map[holiday.Date]bool{{Year: 2026, Month: time.April, Day: 1}: false}}
+	events := billingEvents(b, cal, from, to)
+	last := events[len(events)-1]
+	if last.ID != "billing-4-20260401" || last.Start != "2026-03-31" || last.Description == nil {
+		t.Errorf("unexpected billing %+v", last)
+	}
+}
+
+func TestDerivedPeriod(t *testing.T) {
+	now := time.Date(2026, 2, 15, 10, 0, 0, 0, tokyo)
+	d := func(y int, m time.Month, day int) *time.Time {
+		t := time.Date(y, m, day, 0, 0, 0, 0, tokyo)
+		return &t
+	}
+	cases := []struct {
+		name     string
+		f        EventFilter
+		from, to time.Time
+		wantErr  bool
+	}{
+		{"none", EventFilter{}, *d(2026, 2, 1), *d(2026, 3, 1), false},
+		{"from", EventFilter{From: d(2026, 5, 10)}, *d(2026, 5, 10), *d(2026, 6, 1), false},
+		{"to", EventFilter{To: d(2026, 5, 11)}, *d(2026, 5, 1), *d(2026, 5, 11), false},
+		{"too long", EventFilter{From: d(2026, 1, 1), To: d(2027, 1, 2)}, time.Time{}, time.Time{}, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			from, to, err := derivedPeriod(tc.f, now)
+			if tc.wantErr {
+				if err == nil {
+					t.Error("Expected an error")
+				}
+				return
+			}
+			if err != nil || !from.Equal(tc.from) || !to.Equal(tc.to) {
+				t.Errorf("Expected %v..%v, got %v..%v (%v)", tc.from, tc.to, from, to, err)
+			}
+		})
+	}
+}
+
+func TestEvents_Derived(t *testing.T) {
+	store := newSeededStore()
+	tables := loadMasterTables(t)
+	closing := addMaster(t, store, tables, "closing_dates_master", map[string]any{"name": "20日", "day": int64(20)})
+	month := addMaster(t, store, tables, "billing_months_master", map[string]any{"name": "当月"})
+	day := addMaster(t, store, tables, "billing_days_master", map[string]any{"name": "31日", "day": int64(31)})
+	addMaster(t, store, tables, "billings_master", map[string]any{
+		"shipping_id": int64(2), "closing_date_id": closing, "billing_date_kind": month, "billing_date_id": day,
+	})
+	addMaster(t, store, tables, "order_deadlines_master", map[string]any{"name": "受注締切時刻", "time": "12:00", "valid_flag": true})
+	addMaster(t, store, tables, "order_deadlines_master", map[string]any{"name": "緊急出庫基準時刻", "time": "48:00", "valid_flag": true})
+	addMaster(t, store, tables, "order_deadlines_master", map[string]any{"name": "無効", "time": "13:00", "valid_flag": false})
+	addMaster(t, store, tables, "services_useds_master", map[string]any{"name": "WMS", "billing_date": "2026-02-25"})
+	addMaster(t, store, tables, "business_calendars_master", map[string]any{"calendar_date": "2026-02-27", "working": false, "name": "棚卸"})
+	addMaster(t, store, tables, "business_calendars_master", map[string]any{"warehouse_code": "W1", "calendar_date": "2026-02-28", "working": true, "name": "出荷対応"})
+
+	c := &EventsController{Store: store}
+	if _, code := postEvent(t, c, EventRequest{Title: "入荷", Start: "2026-02-02T09:00:00"}, nil); code != http.StatusCreated {
+		t.Fatalf("Expected 201, got %d", code)
+	}
+
+	res := listEvents(t, c, ListEventsParams{From: date(2026, 2, 1), To: date(2026, 2, 28)})
+	// 受注締切 営業日17日分 + 締日 + 請求日 + サービス請求日 + 祝日2日 + 休業日・臨時営業日 + 登録したイベント
+	if res.Count != 17+3+2+2+1 {
+		t.Fatalf("Expected 25 events, got %d: %+v", res.Count, res.Events)
+	}
+	bySource := map[EventSource][]Event{}
+	for _, e := range res.Events {
+		bySource[e.Source] = append(bySource[e.Source], e)
+	}
+	if e := bySource[EventSourceOrderDeadline][0]; e.Start != "2026-02-02T12:00:00" || e.Kind != EventKindWarehouse {
+		t.Errorf("unexpected deadline %+v", e)
+	}
+	// 28日は土曜日、27日は全倉庫の休業日
+	if e := bySource[EventSourceBilling]; len(e) != 1 || e[0].Start != "2026-02-26" || *e[0].ShippingID != 2 {
+		t.Errorf("unexpected billing %+v", e)
+	}
+	if e := bySource[EventSourceClosing]; len(e) != 1 || e[0].Start != "2026-02-20" || e[0].Version != 0 {
+		t.Errorf("unexpected closing %+v", e)
+	}
+	if e := bySource[EventSourceServiceBilling]; len(e) != 1 || e[0].Start != "2026-02-25" || e[0].Title != "WMS 請求日" {
+		t.Errorf("unexpected service billing %+v", e)
+	}
+	if e := bySource[EventSourceHoliday]; len(e) != 2 || e[0].ID != "holiday-20260211" || e[1].Title != "天皇誕生日" {
+		t.Errorf("unexpected holidays %+v", e)
+	}
+	if e := bySource[EventSourceWorkingDay]; len(e) != 1 || e[0].Kind != EventKindWarehouse || e[0].Title != "臨時営業日: 出荷対応（W1）" {
+		t.Errorf("unexpected working day %+v", e)
+	}
+	// 開始日時の順（2日は締切12時より前に登録したイベント）
+	if res.Events[0].Source != EventSourceManual {
+		t.Errorf("unexpected order %+v", res.Events[:3])
+	}
+
+	// 絞り込みは導出したイベントにも効く
+	res = listEvents(t, c, ListEventsParams{From: date(2026, 2, 1), To: date(2026, 2, 28), Kind: ptr(EventKindShipper)})
+	if res.Count != 2 {
+		t.Errorf("Expected closing and billing, got %+v", res.Events)
+	}
+	res = listEvents(t, c, ListEventsParams{From: date(2026, 2, 1), To: date(2026, 2, 28), Derived: ptr(false)})
+	if res.Count != 1 {
+		t.Errorf("Expected the stored event only, got %+v", res.Events)
+	}
+
+	// 他荷主の締日・請求日は見えない
+	req, w := newJSONRequest("GET", "/events", nil)
+	c.ListEvents(w, withShipper(req, 1), ListEventsParams{From: date(2026, 2, 1), To: date(2026, 2, 28), Kind: ptr(EventKindShipper)})
+	json.NewDecoder(w.Body).Decode(&res)
+	if res.Count != 0 {
+		t.Errorf("Expected no events, got %+v", res.Events)
+	}
+
+	// 期間が長すぎる
+	req, w = newJSONRequest("GET", "/events", nil)
+	c.ListEvents(w, withAuth(req, 7, 10), ListEventsParams{From: date(2026, 1, 1), To: date(2027, 1, 1)})
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400, got %d", w.Code)
+	}
+}
@@ -0,0 +1,191 @@
+package controllers
+
+import (
+	"backend-go/logging"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// CompatRequest is the API request a PHP endpoint is served by.
+type CompatRequest struct {
+	Method string
+	Path   string
+	Query  url.Values
+	// Body is sent as JSON unless it is nil.
+	Body any
+}
+
+// CompatRoute converts the JSON body POSTed to a PHP endpoint into the
+// [CompatRequest] serving it. now is the time of the request.
+type CompatRoute func(body json.RawMessage, now time.Time) (CompatRequest, error)
+
+// CompatRoutes are the PHP endpoints of backend/api ported to the API, keyed
+// by the name without ".php". An endpoint is ported by adding its entry
+// here; the frontend keeps calling /api/{name}.php.
+var CompatRoutes = map[string]CompatRoute{
+	"events": eventsCompat,
+}
+
+// PHPCompatController serves the POST /api/{name}.php contract of the PHP
+// backend, which backGroundApi in frontend/src/api/BackGround.tsx calls, so
+// that endpoints can move to Go one at a time without changing the frontend.
+//
+// A ported name is served by the API operation its [CompatRoute] returns,
+// through API with the caller's headers, so that authentication,
+// x-permission and validation apply as when the operation is called
+// directly; the access log shows both requests with the same request ID.
+// Other names are passed to PHP when it is set and are 404 otherwise.
+type PHPCompatController struct {
+	// API serves the operations of the spec. It is the router the
+	// controller is mounted on.
+	API http.Handler
+	// Routes are the ported endpoints, usually [CompatRoutes].
+	Routes map[string]CompatRoute
+	// PHP proxies the endpoints not ported yet to the PHP backend, or is
+	// nil.
+	PHP http.Handler
+	// AllowOrigin is the origin allowed by CORS, as events.php does for
+	// the Vite dev server. No CORS headers are sent when it is empty.
+	AllowOrigin string
+	// BasePath is the path the operations are mounted under on API. The
+	// paths of [CompatRequest] are those of the spec.
+	BasePath string
+}
+
+// ServeHTTP serves /api/{name}.php.
+func (c *PHPCompatController) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "name")
+	route, ok := c.Routes[name]
+	if !ok && c.PHP != nil {
+		// 移行前のエンドポイントは PHP がヘッダも含めて応答する
+		c.PHP.ServeHTTP(w, r)
+		return
+	}
+
+	// events.php と同じ CORS ヘッダ
+	if c.AllowOrigin != "" {
+		w.Header().Set("Access-Control-Allow-Origin", c.AllowOrigin)
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		w.Header().Set("Access-Control-Allow-Credentials", "true")
+	}
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if !ok {
+		writeError(w, &NotFoundError{Resource: "api", ID: name + ".php"})
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", "POST, OPTIONS")
+		MethodNotAllowedHandler(w, r)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if !json.Valid(body) {
+		writeError(w, &BadRequestError{Err: errors.New("invalid JSON")})
+		return
+	}
+	cr, err := route(body, time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	req, err := c.apiRequest(r, cr)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	c.API.ServeHTTP(w, req)
+}
+
+// apiRequest builds the request of cr, keeping the headers of r such as
+// the session cookie and the request ID.
+func (c *PHPCompatController) apiRequest(r *http.Request, cr CompatRequest) (*http.Request, error) {
+	var body []byte
+	if cr.Body != nil {
+		var err error
+		if body, err = json.Marshal(cr.Body); err != nil {
+			return nil, err
+		}
+	}
+	// 改めてルーティングさせるため、chi のルーティング情報を外す
+	ctx := context.WithValue(r.Context(), chi.RouteCtxKey, nil)
+	u := &url.URL{Path: c.BasePath + cr.Path, RawQuery: cr.Query.Encode()}
+	req, err := http.NewRequestWithContext(ctx, cr.Method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header = r.Header.Clone()
+	req.Header.Del("Content-Length")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	} else {
+		req.Header.Del("Content-Type")
+		req.Body = http.NoBody
+	}
+	if id := logging.RequestIDFrom(ctx); id != "" {
+		req.Header.Set(logging.RequestIDHeader, id)
+	}
+	req.RemoteAddr = r.RemoteAddr
+	req.Host = r.Host
+	return req, nil
+}
+
+// eventsCompat serves events.php by GET /events.
+//
+// The screen sends {userId, from: "calendar"}: the user is the one logged
+// in, and from and to are only used when they are dates. Without a period,
+// the events of [maxDerivedMonths] months starting [feedPastMonths] months
+// before the current one are returned, as in the calendar feed. status and
+// kind filter as in [EventsController.ListEvents].
+func eventsCompat(body json.RawMessage, now time.Time) (CompatRequest, error) {
+	var req struct {
+		From   string   `json:"from"`
+		To     string   `json:"to"`
+		Status []string `json:"status"`
+		Kind   string   `json:"kind"`
+	}
+	if err := json.Unmarshal(body, &req); err != nil {
+		return CompatRequest{}, &BadRequestError{Err: fmt.Errorf("invalid request: %w", err)}
+	}
+
+	q := url.Values{}
+	from, fromErr := time.Parse(time.DateOnly, req.From)
+	to, toErr := time.Parse(time.DateOnly, req.To)
+	switch {
+	case fromErr == nil || toErr == nil:
+		if fromErr == nil {
+			q.Set("from", from.Format(time.DateOnly))
+		}
+		if toErr == nil {
+			q.Set("to", to.Format(time.DateOnly))
+		}
+	default:
+		start := monthOf(now).AddDate(0, -feedPastMonths, 0)
+		q.Set("from", start.Format(time.DateOnly))
+		q.Set("to", start.AddDate(0, maxDerivedMonths, -1).Format(time.DateOnly))
+	}
+	for _, s := range req.Status {
+		q.Add("status", s)
+	}
+	if req.Kind != "" {
+		q.Set("kind", req.Kind)
+	}
+	return CompatRequest{Method: http.MethodGet, Path: "/events", Query: q}, nil
+}
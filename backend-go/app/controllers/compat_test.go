@@ -0,0 +1,120 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	middleware "github.com/deepmap/oapi-codegen/pkg/chi-middleware"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/go-chi/chi/v5"
+)
+
+func newCompatRouter(t *testing.T, c *EventsController, php http.Handler) http.Handler {
+	swagger, err := GetSwagger()
+	if err != nil {
+		t.Fatal(err)
+	}
+	swagger.Servers = nil
+	r := chi.NewRouter()
+	r.Handle("/api/{name}.php", &PHPCompatController{API: r, Routes: CompatRoutes, PHP: php, AllowOrigin: "http://localhost:5173"})
+	r.Group(func(r chi.Router) {
+		r.Use(middleware.OapiRequestValidatorWithOptions(swagger, &middleware.Options{
+			ErrorHandler: ValidatorErrorHandler,
+			Options:      openapi3filter.Options{AuthenticationFunc: CheckCredentials},
+		}))
+		HandlerWithOptions(&Server{EventsController: c}, ChiServerOptions{BaseRouter: r})
+	})
+	return r
+}
+
+func manualTitles(res EventsResponse) string {
+	var titles []string
+	for _, e := range res.Events {
+		if e.Source == EventSourceManual {
+			titles = append(titles, e.Title)
+		}
+	}
+	return strings.Join(titles, " ")
+}
+
+func TestPHPCompat(t *testing.T) {
+	c := &EventsController{Store: newSeededStore()}
+	now := time.Now().In(tokyo)
+	postEvent(t, c, EventRequest{Title: "今月", Start: now.Format(time.DateOnly)}, nil)
+	postEvent(t, c, EventRequest{Title: "3か月前", Start: now.AddDate(0, -3, 0).Format(time.DateOnly)}, nil)
+	var php []string
+	r := newCompatRouter(t, c, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		php = append(php, r.URL.Path)
+	}))
+
+	// Schedule.tsx と同じ呼び出し
+	req := httptest.NewRequest("POST", "/api/events.php", strings.NewReader(`{"userId":"user-001","from":"calendar"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer x")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusOK || w.Header().Get("Access-Control-Allow-Origin") != "http://localhost:5173" {
+		t.Fatalf("Expected 200 with CORS, got %d %v: %s", w.Code, w.Header(), w.Body)
+	}
+	var res EventsResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+		t.Fatal(err)
+	}
+	if manual := manualTitles(res); manual != "今月" {
+		t.Errorf("unexpected events %s", manual)
+	}
+
+	// 日付の期間はそのまま使う
+	req = httptest.NewRequest("POST", "/api/events.php", strings.NewReader(`{"from":"`+now.AddDate(0, -4, 0).Format(time.DateOnly)+`","to":"`+now.AddDate(0, 1, 0).Format(time.DateOnly)+`"}`))
+	req.Header.Set("Authorization", "Bearer x")
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	res = EventsResponse{}
+	if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil || manualTitles(res) != "3か月前 今月" {
+		t.Errorf("Expected 2 events, got %d %s", w.Code, w.Body)
+	}
+
+	// 認証は API と同じ
+	req = httptest.NewRequest("POST", "/api/events.php", strings.NewReader(`{}`))
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected 401, got %d", w.Code)
+	}
+
+	for _, tc := range []struct {
+		method, path, body string
+		code               int
+	}{
+		{"OPTIONS", "/api/events.php", "", http.StatusNoContent},
+		{"GET", "/api/events.php", "", http.StatusMethodNotAllowed},
+		{"POST", "/api/events.php", "{", http.StatusBadRequest},
+		{"POST", "/api/events.php", `{"status":"error"}`, http.StatusBadRequest},
+	} {
+		req = httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+		req.Header.Set("Authorization", "Bearer x")
+		w = httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if w.Code != tc.code || w.Header().Get("Access-Control-Allow-Credentials") != "true" {
+			t.Errorf("%s %s %s: expected %d, got %d", tc.method, tc.path, tc.body, tc.code, w.Code)
+		}
+	}
+
+	// 移行前のエンドポイントは PHP へ
+	req = httptest.NewRequest("POST", "/api/test-db-select.php", strings.NewReader(`{}`))
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if len(php) != 1 || php[0] != "/api/test-db-select.php" || w.Header().Get("Access-Control-Allow-Origin") != "" {
+		t.Errorf("Expected the request to be proxied, got %v %v", php, w.Header())
+	}
+	r = newCompatRouter(t, c, nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("POST", "/api/test-db-select.php", strings.NewReader(`{}`)))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404, got %d", w.Code)
+	}
+}
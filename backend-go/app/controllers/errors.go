@@ -0,0 +1,277 @@
+package controllers
+
+import (
+	"backend-go/logging"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// MySQLのエラー番号
+const (
+	mysqlErrDuplicateEntry    = 1062 // ER_DUP_ENTRY
+	mysqlErrRowIsReferenced   = 1451 // ER_ROW_IS_REFERENCED_2
+	mysqlErrNoReferencedRow   = 1452 // ER_NO_REFERENCED_ROW_2
+	mysqlErrBadNull           = 1048 // ER_BAD_NULL_ERROR
+	mysqlErrDataTooLong       = 1406 // ER_DATA_TOO_LONG
+	mysqlErrTruncatedWrongVal = 1366 // ER_TRUNCATED_WRONG_VALUE_FOR_FIELD
+	mysqlErrDeadlock          = 1213 // ER_LOCK_DEADLOCK
+)
+
+// NotFoundError is returned when the requested resource does not exist.
+// It is rendered as 404 Not Found.
+type NotFoundError struct {
+	// Resource is the name of the missing resource (e.g. "user").
+	Resource string
+	// ID identifies the missing record.
+	ID any
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("%s %v not found", e.Resource, e.ID)
+}
+
+// ConflictError is returned when a request collides with the current state
+// of a resource, such as a duplicate key. It is rendered as 409 Conflict.
+type ConflictError struct {
+	Message string
+}
+
+func (e *ConflictError) Error() string {
+	return e.Message
+}
+
+// ValidationError is returned when a well-formed request violates a business
+// rule, such as a reference to a non-existent record. It is rendered as
+// 422 Unprocessable Entity.
+type ValidationError struct {
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+// UnauthorizedError is returned when a request carries no valid session or
+// the login credentials are wrong. It is rendered as 401 Unauthorized.
+type UnauthorizedError struct {
+	Message string
+}
+
+func (e *UnauthorizedError) Error() string {
+	return e.Message
+}
+
+// ForbiddenError is returned when the group of the logged-in user lacks the
+// permission an operation requires. It is rendered as 403 Forbidden.
+type ForbiddenError struct {
+	// Permission is the missing permission code.
+	Permission string
+}
+
+func (e *ForbiddenError) Error() string {
+	return "permission " + e.Permission + " is required"
+}
+
+// TooManyAttemptsError is returned when an account is locked after repeated
+// failed logins. It is rendered as 429 Too Many Requests with a Retry-After
+// header.
+type TooManyAttemptsError struct {
+	// Until is when the account unlocks.
+	Until time.Time
+}
+
+func (e *TooManyAttemptsError) Error() string {
+	return "account is locked after too many failed attempts"
+}
+
+// PreconditionRequiredError is returned when a write request lacks the
+// If-Match header. It is rendered as 428 Precondition Required.
+type PreconditionRequiredError struct{}
+
+func (e *PreconditionRequiredError) Error() string {
+	return "If-Match header is required"
+}
+
+// PreconditionFailedError is returned when the If-Match header does not
+// match the current version of a row, that is, someone else updated it
+// first. It is rendered as 412 Precondition Failed with the current
+// representation as the body and its ETag.
+type PreconditionFailedError struct {
+	Version int64
+	// Current is the representation returned by GET.
+	Current any
+}
+
+func (e *PreconditionFailedError) Error() string {
+	return "the resource has been modified (version " + strconv.FormatInt(e.Version, 10) + ")"
+}
+
+// BadRequestError is returned when the request itself cannot be parsed.
+// It is rendered as 400 Bad Request.
+type BadRequestError struct {
+	Err error
+}
+
+func (e *BadRequestError) Error() string {
+	return "invalid request: " + e.Err.Error()
+}
+
+func (e *BadRequestError) Unwrap() error {
+	return e.Err
+}
+
+// errorStatus maps err to an HTTP status code and a client-facing message.
+// Unknown errors become 500 with a generic message so that internal details
+// never leak to the client.
+func errorStatus(err error) (int, string) {
+	var (
+		notFound   *NotFoundError
+		conflict   *ConflictError
+		validation *ValidationError
+		badRequest *BadRequestError
+		unauth     *UnauthorizedError
+		forbidden  *ForbiddenError
+		tooMany    *TooManyAttemptsError
+		preReq     *PreconditionRequiredError
+		preFailed  *PreconditionFailedError
+		tooLarge   *http.MaxBytesError
+		mysqlErr   *mysql.MySQLError
+	)
+	switch {
+	case errors.As(err, &tooLarge):
+		return http.StatusRequestEntityTooLarge, fmt.Sprintf("request body is larger than %d bytes", tooLarge.Limit)
+	case errors.As(err, &notFound):
+		return http.StatusNotFound, notFound.Error()
+	case errors.As(err, &conflict):
+		return http.StatusConflict, conflict.Error()
+	case errors.As(err, &validation):
+		return http.StatusUnprocessableEntity, validation.Error()
+	case errors.As(err, &badRequest):
+		return http.StatusBadRequest, badRequest.Error()
+	case errors.As(err, &unauth):
+		return http.StatusUnauthorized, unauth.Error()
+	case errors.As(err, &forbidden):
+		return http.StatusForbidden, forbidden.Error()
+	case errors.As(err, &tooMany):
+		return http.StatusTooManyRequests, tooMany.Error()
+	case errors.As(err, &preReq):
+		return http.StatusPreconditionRequired, preReq.Error()
+	case errors.As(err, &preFailed):
+		return http.StatusPreconditionFailed, preFailed.Error()
+	case errors.Is(err, sql.ErrNoRows):
+		return http.StatusNotFound, "resource not found"
+	case errors.As(err, &mysqlErr):
+		switch mysqlErr.Number {
+		case mysqlErrDuplicateEntry:
+			return http.StatusConflict, "duplicate entry"
+		case mysqlErrRowIsReferenced:
+			return http.StatusConflict, "resource is referenced by other records"
+		case mysqlErrNoReferencedRow:
+			return http.StatusUnprocessableEntity, "referenced record does not exist"
+		case mysqlErrBadNull, mysqlErrDataTooLong, mysqlErrTruncatedWrongVal:
+			return http.StatusUnprocessableEntity, "invalid column value"
+		}
+	}
+	return http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError)
+}
+
+// writeJSON writes v as the JSON response body with the given status code.
+// Nothing may be written to w before calling it.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		slog.Error("failed to encode response", "error", err)
+	}
+}
+
+// writeError renders err as an [ErrorResponse], except for a
+// [PreconditionFailedError], which is rendered as the current representation.
+func writeError(w http.ResponseWriter, err error) {
+	status, message := errorStatus(err)
+	switch status {
+	case http.StatusInternalServerError:
+		// アクセスログの行にエラーを載せる。載せられない場合は単独で出力する
+		if !logging.RecordError(w, err) {
+			slog.Error("internal error", "error", err)
+		}
+	case http.StatusUnauthorized:
+		w.Header().Set("WWW-Authenticate", "Bearer")
+	case http.StatusTooManyRequests:
+		var tooMany *TooManyAttemptsError
+		if errors.As(err, &tooMany) {
+			secs := int(math.Ceil(time.Until(tooMany.Until).Seconds()))
+			w.Header().Set("Retry-After", strconv.Itoa(max(secs, 1)))
+		}
+	case http.StatusPreconditionFailed:
+		var preFailed *PreconditionFailedError
+		if errors.As(err, &preFailed) {
+			// 画面で差分を確認して再送できるよう、最新の内容を返す
+			setETag(w, preFailed.Version)
+			writeJSON(w, status, preFailed.Current)
+			return
+		}
+	}
+	writeJSON(w, status, ErrorResponse{Code: status, Message: message})
+}
+
+// decodeJSON decodes the request body into v, wrapping failures in a
+// [BadRequestError].
+func decodeJSON(r *http.Request, v any) error {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return &BadRequestError{Err: err}
+	}
+	return nil
+}
+
+// ValidatorErrorHandler renders failures of the OpenAPI request validator
+// middleware as an [ErrorResponse].
+func ValidatorErrorHandler(w http.ResponseWriter, message string, statusCode int) {
+	if statusCode == http.StatusUnauthorized {
+		// 認証スキームごとの詳細は返さない
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		message = "authentication required"
+	}
+	writeJSON(w, statusCode, ErrorResponse{Code: statusCode, Message: message})
+}
+
+// ParamErrorHandler renders parameter binding failures of the generated
+// router as an [ErrorResponse]. It is meant for ChiServerOptions.ErrorHandlerFunc.
+func ParamErrorHandler(w http.ResponseWriter, r *http.Request, err error) {
+	writeError(w, &BadRequestError{Err: err})
+}
+
+// LimitRequestBody rejects request bodies larger than n bytes with 413. It
+// must run before the OpenAPI request validator, which reads the whole body.
+func LimitRequestBody(n int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength > n {
+				writeError(w, &http.MaxBytesError{Limit: n})
+				return
+			}
+			// Content-Length のない（chunked の）リクエストは読んだ分で打ち切る
+			r.Body = http.MaxBytesReader(w, r.Body, n)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// NotFoundHandler renders unknown routes as an [ErrorResponse].
+func NotFoundHandler(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusNotFound, ErrorResponse{Code: http.StatusNotFound, Message: "route not found"})
+}
+
+// MethodNotAllowedHandler renders unsupported methods as an [ErrorResponse].
+func MethodNotAllowedHandler(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusMethodNotAllowed, ErrorResponse{Code: http.StatusMethodNotAllowed, Message: "method not allowed"})
+}
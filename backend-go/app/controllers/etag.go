@@ -0,0 +1,55 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Rows that can be edited carry a version, incremented by every update and
+// sent as the ETag of their representation. Writes require the ETag in
+// If-Match so that two operators editing the same row cannot silently
+// overwrite each other: the second one gets 412 with the current row.
+
+// etag returns the entity tag of a row version.
+func etag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// setETag sets the ETag header of a response.
+func setETag(w http.ResponseWriter, version int64) {
+	w.Header().Set("ETag", etag(version))
+}
+
+// requireIfMatch rejects a write request without an If-Match header with a
+// [PreconditionRequiredError]. It is called before the request body is
+// read.
+func requireIfMatch(header *IfMatch) error {
+	if header == nil || strings.TrimSpace(*header) == "" {
+		return &PreconditionRequiredError{}
+	}
+	return nil
+}
+
+// checkVersion compares the If-Match header with the version of a row
+// locked by the transaction. On a mismatch it returns a
+// [PreconditionFailedError] with the representation read by current.
+//
+// The header may list several tags or be "*". Weak tags never match, as
+// If-Match uses the strong comparison.
+func checkVersion(header *IfMatch, version int64, current func() (any, error)) error {
+	if err := requireIfMatch(header); err != nil {
+		return err
+	}
+	want := etag(version)
+	for tag := range strings.SplitSeq(*header, ",") {
+		if tag = strings.TrimSpace(tag); tag == "*" || tag == want {
+			return nil
+		}
+	}
+	rep, err := current()
+	if err != nil {
+		return err
+	}
+	return &PreconditionFailedError{Version: version, Current: rep}
+}
@@ -0,0 +1,84 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestCheckVersion(t *testing.T) {
+	current := func() (any, error) { return "current", nil }
+	for header, ok := range map[string]bool{`"3"`: true, `"2", "3"`: true, `*`: true, `"2"`: false, `W/"3"`: false} {
+		err := checkVersion(ptr[IfMatch](header), 3, current)
+		if (err == nil) != ok {
+			t.Errorf("%s: got %v", header, err)
+		}
+	}
+	if err := requireIfMatch(nil); err == nil {
+		t.Error("Expected an error without If-Match")
+	}
+}
+
+func TestMastersController_OptimisticLock(t *testing.T) {
+	ctrl := &MastersController{Store: newSeededStore(), Tables: loadMasterTables(t)}
+
+	req, w := newJSONRequest("POST", "/masters/kinds_master", map[string]any{"name": "納品書"})
+	ctrl.CreateMasterRecord(w, req, "kinds_master")
+	if w.Header().Get("ETag") != `"1"` {
+		t.Fatalf("unexpected ETag %q", w.Header().Get("ETag"))
+	}
+	var rec map[string]any
+	json.NewDecoder(w.Body).Decode(&rec)
+	id := int64(rec["id"].(float64))
+
+	// 1人目の更新でバージョンが2になる
+	req, w = newJSONRequest("PUT", "/masters/kinds_master", map[string]any{"name": "A"})
+	ctrl.UpdateMasterRecord(w, req, "kinds_master", id, UpdateMasterRecordParams{IfMatch: ifMatchV1})
+	if w.Code != http.StatusOK || w.Header().Get("ETag") != `"2"` {
+		t.Fatalf("Expected 200 with version 2, got %d %q", w.Code, w.Header().Get("ETag"))
+	}
+
+	// 2人目は古いバージョンのため 412 で最新の内容を受け取る
+	req, w = newJSONRequest("PUT", "/masters/kinds_master", map[string]any{"name": "B"})
+	ctrl.UpdateMasterRecord(w, req, "kinds_master", id, UpdateMasterRecordParams{IfMatch: ifMatchV1})
+	if w.Code != http.StatusPreconditionFailed || w.Header().Get("ETag") != `"2"` {
+		t.Fatalf("Expected 412 with version 2, got %d %q", w.Code, w.Header().Get("ETag"))
+	}
+	json.NewDecoder(w.Body).Decode(&rec)
+	if rec["name"] != "A" {
+		t.Errorf("unexpected current record %v", rec)
+	}
+
+	// If-Match なしは 428
+	req, w = newJSONRequest("DELETE", "/masters/kinds_master", nil)
+	ctrl.DeleteMasterRecord(w, req, "kinds_master", id, DeleteMasterRecordParams{})
+	if w.Code != http.StatusPreconditionRequired {
+		t.Errorf("Expected 428, got %d", w.Code)
+	}
+}
+
+func TestUsersController_OptimisticLock(t *testing.T) {
+	store := newSeededStore()
+	ctrl := &UsersController{Store: store}
+	req, w := newJSONRequest("POST", "/users", UsersRequestPost{
+		GroupID: 1, UserID: "jdoe", Name: "John Doe", Email: "jdoe@example.com", DepartmentID: 3,
+	})
+	ctrl.CreateUser(w, withAuth(req, 1, 10))
+	var u User
+	json.NewDecoder(w.Body).Decode(&u)
+
+	req, w = newJSONRequest("PATCH", "/users", UsersRequestPatch{Name: ptr("A")})
+	ctrl.PatchUser(w, req, u.ID, PatchUserParams{IfMatch: ifMatchV1})
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	req, w = newJSONRequest("PATCH", "/users", UsersRequestPatch{Name: ptr("B")})
+	ctrl.PatchUser(w, req, u.ID, PatchUserParams{IfMatch: ifMatchV1})
+	if w.Code != http.StatusPreconditionFailed {
+		t.Fatalf("Expected 412, got %d", w.Code)
+	}
+	json.NewDecoder(w.Body).Decode(&u)
+	if u.Name != "A" || u.Version != 2 {
+		t.Errorf("unexpected current user %+v", u)
+	}
+}
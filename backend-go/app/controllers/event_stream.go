@@ -0,0 +1,277 @@
+package controllers
+
+import (
+	"backend-go/metrics"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// defaultStreamHistory is the number of changes an [EventBroker] keeps when
+// History is not set.
+const defaultStreamHistory = 1000
+
+// defaultStreamHeartbeat is the interval of heartbeats on /events/stream
+// when [EventsController.Heartbeat] is not set.
+const defaultStreamHeartbeat = 25 * time.Second
+
+// streamRetry is the reconnection delay suggested to EventSource clients.
+const streamRetry = 3 * time.Second
+
+// streamBuffer is the number of changes a subscription holds for a slow
+// client before it is dropped. The client then reconnects with
+// Last-Event-ID and catches up from the history.
+const streamBuffer = 64
+
+// EventBroker delivers the changes of events made through
+// [EventsController] to the clients of /events/stream.
+//
+// Changes are only delivered within this process. They are numbered from
+// the time the broker starts, so that IDs keep increasing across restarts,
+// and the most recent ones are kept for clients reconnecting with
+// Last-Event-ID. A client that missed more than that, or whose last ID
+// comes from an earlier process, is told to reset.
+//
+// The zero value is ready to use.
+type EventBroker struct {
+	// History is the number of recent changes kept for reconnecting
+	// clients, [defaultStreamHistory] when zero.
+	History int
+
+	mu sync.Mutex
+	// last is the ID of the latest change, or the starting point before
+	// the first one.
+	last   int64
+	recent []brokerChange
+	subs   map[*eventSubscription]struct{}
+	closed bool
+}
+
+// brokerChange is a change as published, with the event before an update.
+type brokerChange struct {
+	EventChange
+	prev *Event
+}
+
+// eventSubscription is a client of /events/stream.
+type eventSubscription struct {
+	scope Scope
+	kind  EventKind
+	ch    chan EventChange
+}
+
+// init starts the numbering of changes. b.mu must be held.
+func (b *EventBroker) init() {
+	if b.subs == nil {
+		b.subs = map[*eventSubscription]struct{}{}
+		b.last = time.Now().UnixMilli()
+	}
+}
+
+// publish delivers a change of e to the subscriptions that can see it. prev
+// is the event before an update, if it was visible to others. A nil broker
+// publishes nothing.
+func (b *EventBroker) publish(t EventChangeType, e Event, prev *Event) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.init()
+	b.last++
+	c := brokerChange{EventChange: EventChange{ID: b.last, Type: t, Event: &e}, prev: prev}
+
+	history := b.History
+	if history <= 0 {
+		history = defaultStreamHistory
+	}
+	b.recent = append(b.recent, c)
+	if n := len(b.recent) - history; n > 0 {
+		b.recent = slices.Delete(b.recent, 0, n)
+	}
+
+	for s := range b.subs {
+		msg, ok := s.filter(c)
+		if !ok {
+			continue
+		}
+		select {
+		case s.ch <- msg:
+		default:
+			// 受け取りが追いつかないクライアントは切断し、再接続で送り直す
+			delete(b.subs, s)
+			close(s.ch)
+		}
+	}
+}
+
+// subscribe registers a subscription for the changes visible under sc,
+// limited to kind unless it is empty. When lastID is set, it also returns
+// the changes after it, or a single reset when they are no longer kept.
+func (b *EventBroker) subscribe(sc Scope, kind EventKind, lastID *int64) (*eventSubscription, []EventChange) {
+	s := &eventSubscription{scope: sc, kind: kind, ch: make(chan EventChange, streamBuffer)}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.init()
+	if b.closed {
+		close(s.ch)
+		return s, nil
+	}
+	b.subs[s] = struct{}{}
+	if lastID == nil {
+		return s, nil
+	}
+
+	oldest := b.last + 1
+	if len(b.recent) > 0 {
+		oldest = b.recent[0].ID
+	}
+	if *lastID > b.last || *lastID < oldest-1 {
+		return s, []EventChange{{ID: b.last, Type: EventChangeReset}}
+	}
+	var replay []EventChange
+	for _, c := range b.recent {
+		if c.ID <= *lastID {
+			continue
+		}
+		if msg, ok := s.filter(c); ok {
+			replay = append(replay, msg)
+		}
+	}
+	return s, replay
+}
+
+// unsubscribe removes a subscription registered by [EventBroker.subscribe].
+func (b *EventBroker) unsubscribe(s *eventSubscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.subs[s]; ok {
+		delete(b.subs, s)
+		close(s.ch)
+	}
+}
+
+// Close ends every stream and refuses new ones, so that a graceful shutdown
+// does not wait for clients that never disconnect.
+func (b *EventBroker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	for s := range b.subs {
+		delete(b.subs, s)
+		close(s.ch)
+	}
+}
+
+// filter returns c as s receives it. An update that hides an event from s
+// is received as its deletion.
+func (s *eventSubscription) filter(c brokerChange) (EventChange, bool) {
+	if c.Event == nil || s.visible(*c.Event) {
+		return c.EventChange, true
+	}
+	if c.Type == EventChangeUpdated && c.prev != nil && s.visible(*c.prev) {
+		return EventChange{ID: c.ID, Type: EventChangeDeleted, Event: c.prev}, true
+	}
+	return EventChange{}, false
+}
+
+// visible reports whether e is in the scope and of the kind of s.
+func (s *eventSubscription) visible(e Event) bool {
+	sc := s.scope.ShippingID
+	return (sc == nil || e.ShippingID == nil || *e.ShippingID == *sc) && (s.kind == "" || e.Kind == s.kind)
+}
+
+// StreamEvents streams the changes of events as Server-Sent Events until
+// the client disconnects or the server shuts down. Changes are filtered by
+// the caller's [Scope] and by kind, and a comment line is sent every
+// [EventsController.Heartbeat] so that proxies keep the connection open.
+//
+// A Last-Event-ID that cannot be parsed is treated as one too old to
+// resume from.
+func (c *EventsController) StreamEvents(w http.ResponseWriter, r *http.Request, params StreamEventsParams) {
+	if c.Broker == nil {
+		writeError(w, errors.New("event stream is not configured"))
+		return
+	}
+	var lastID *int64
+	if params.LastEventID != nil {
+		id, err := strconv.ParseInt(*params.LastEventID, 10, 64)
+		if err != nil {
+			id = -1
+		}
+		lastID = &id
+	}
+	var kind EventKind
+	if params.Kind != nil {
+		kind = *params.Kind
+	}
+
+	// 接続中はずっと書き込むため、サーバの書き込みタイムアウトを外す
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		writeError(w, err)
+		return
+	}
+	sub, replay := c.Broker.subscribe(scopeOf(r.Context()), kind, lastID)
+	defer c.Broker.unsubscribe(sub)
+	metrics.EventStreams.Inc()
+	defer metrics.EventStreams.Dec()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	// nginx などのプロキシにバッファさせない
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, "retry: %d\n\n", streamRetry.Milliseconds())
+	for _, msg := range replay {
+		if err := writeEventChange(w, msg); err != nil {
+			return
+		}
+	}
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	heartbeat := c.Heartbeat
+	if heartbeat <= 0 {
+		heartbeat = defaultStreamHeartbeat
+	}
+	ticker := time.NewTicker(heartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case msg, ok := <-sub.ch:
+			if !ok {
+				return
+			}
+			if err := writeEventChange(w, msg); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// writeEventChange writes a change as a Server-Sent Event whose id is the
+// change ID, so that EventSource sends it back as Last-Event-ID.
+func writeEventChange(w http.ResponseWriter, c EventChange) error {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", c.ID, c.Type, data)
+	return err
+}
@@ -0,0 +1,144 @@
+package controllers
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	middleware "github.com/deepmap/oapi-codegen/pkg/chi-middleware"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/go-chi/chi/v5"
+)
+
+func TestEventBroker(t *testing.T) {
+	b := &EventBroker{History: 2}
+	shipper := int64(5)
+	other := int64(6)
+	all, _ := b.subscribe(Scope{}, "", nil)
+	mine, _ := b.subscribe(Scope{ShippingID: &shipper}, EventKindShipper, nil)
+
+	b.publish(EventChangeCreated, Event{ID: "1", Kind: EventKindShipper, ShippingID: &shipper}, nil)
+	b.publish(EventChangeCreated, Event{ID: "2", Kind: EventKindWarehouse}, nil)
+	// 別の荷主に変えたイベントは、見えていた荷主には削除として届く
+	b.publish(EventChangeUpdated, Event{ID: "1", Kind: EventKindShipper, ShippingID: &other}, &Event{ID: "1", Kind: EventKindShipper, ShippingID: &shipper})
+
+	if len(all.ch) != 3 {
+		t.Errorf("Expected 3 changes, got %d", len(all.ch))
+	}
+	first, second := <-mine.ch, <-mine.ch
+	if len(mine.ch) != 0 || first.Type != EventChangeCreated || second.Type != EventChangeDeleted || second.Event.ID != "1" || second.ID != first.ID+2 {
+		t.Errorf("unexpected changes %+v %+v", first, second)
+	}
+
+	// 直近 2 件までは送り直し、それより前は reset
+	_, replay := b.subscribe(Scope{}, "", ptr(first.ID))
+	if len(replay) != 2 || replay[0].Event.ID != "2" {
+		t.Errorf("unexpected replay %+v", replay)
+	}
+	_, replay = b.subscribe(Scope{}, "", ptr(first.ID-1))
+	if len(replay) != 1 || replay[0].Type != EventChangeReset || replay[0].ID != second.ID {
+		t.Errorf("Expected a reset, got %+v", replay)
+	}
+	_, replay = b.subscribe(Scope{}, "", ptr(second.ID+1))
+	if len(replay) != 1 || replay[0].Type != EventChangeReset {
+		t.Errorf("Expected a reset for an unknown id, got %+v", replay)
+	}
+	if _, replay = b.subscribe(Scope{}, "", ptr(second.ID)); len(replay) != 0 {
+		t.Errorf("Expected nothing to replay, got %+v", replay)
+	}
+
+	b.Close()
+	if _, ok := <-all.ch; ok {
+		// 未読の変更が残っているので読み切る
+		for range all.ch {
+		}
+	}
+	s, _ := b.subscribe(Scope{}, "", nil)
+	if _, ok := <-s.ch; ok {
+		t.Error("subscription after Close is open")
+	}
+}
+
+func TestStreamEvents(t *testing.T) {
+	store := newSeededStore()
+	broker := &EventBroker{}
+	c := &EventsController{Store: store, Broker: broker, Heartbeat: 20 * time.Millisecond}
+
+	// 仕様書のバリデーションを通し、/events/{id} ではなく /events/stream に届くこと
+	swagger, err := GetSwagger()
+	if err != nil {
+		t.Fatal(err)
+	}
+	swagger.Servers = nil
+	r := chi.NewRouter()
+	r.Use(middleware.OapiRequestValidatorWithOptions(swagger, &middleware.Options{
+		ErrorHandler: ValidatorErrorHandler,
+		Options:      openapi3filter.Options{AuthenticationFunc: CheckCredentials},
+	}))
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			next.ServeHTTP(w, withShipper(req, 1))
+		})
+	})
+	HandlerWithOptions(&Server{EventsController: c}, ChiServerOptions{BaseRouter: r})
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	req, _ := http.NewRequestWithContext(t.Context(), "GET", srv.URL+"/events/stream?kind=shipper", nil)
+	req.Header.Set("Authorization", "Bearer x")
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK || res.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("Expected 200 text/event-stream, got %d %s", res.StatusCode, res.Header.Get("Content-Type"))
+	}
+	lines := bufio.NewScanner(res.Body)
+	next := func() string {
+		if !lines.Scan() {
+			t.Fatalf("stream ended: %v", lines.Err())
+		}
+		return lines.Text()
+	}
+	if l := next(); l != "retry: 3000" {
+		t.Errorf("unexpected first line %q", l)
+	}
+	// ハートビート
+	for l := next(); l != ": heartbeat"; l = next() {
+	}
+
+	// 他の荷主・他の区分のイベントは届かない
+	postEvent(t, c, EventRequest{Title: "他荷主", Start: "2026-02-01", Kind: ptr(EventKindShipper), ShippingID: ptr(int64(2))}, nil)
+	postEvent(t, c, EventRequest{Title: "倉庫", Start: "2026-02-01", Kind: ptr(EventKindWarehouse)}, nil)
+	e, code := postEvent(t, c, EventRequest{Title: "橋本店未入荷", Start: "2026-02-01T10:00:00", Status: ptr(EventStatusError), Kind: ptr(EventKindShipper)}, ptr(int64(1)))
+	if code != http.StatusCreated {
+		t.Fatalf("Expected 201, got %d", code)
+	}
+	var id, data string
+	for l := next(); l != "event: created"; l = next() {
+		id = l
+	}
+	data = next()
+	var change EventChange
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(data, "data: ")), &change); err != nil {
+		t.Fatal(err)
+	}
+	if change.Event == nil || change.Event.ID != e.ID || id != "id: "+itoa(change.ID) {
+		t.Errorf("unexpected change %s %s", id, data)
+	}
+
+	// 終了時にストリームを閉じる
+	broker.Close()
+	for lines.Scan() {
+	}
+}
+
+func itoa(n int64) string {
+	b, _ := json.Marshal(n)
+	return string(b)
+}
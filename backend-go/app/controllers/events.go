@@ -0,0 +1,592 @@
+package controllers
+
+import (
+	"backend-go/rrule"
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// EventsController serves the events of the schedule screen, stored in the
+// events table. It replaces the mock backend/api/events.php and keeps its
+// {count, events} response.
+//
+// Events with a shipper are shown to that shipper and to warehouse staff;
+// events without one are shared by all shippers and can only be written by
+// warehouse staff.
+type EventsController struct {
+	Store Store
+	// Broker delivers the changes made here to /events/stream. Changes are
+	// not published when it is nil.
+	Broker *EventBroker
+	// Heartbeat is the interval of the heartbeats of /events/stream.
+	Heartbeat time.Duration
+	// BasePath is the path the operations are mounted under, which the
+	// Location of a created event starts with.
+	BasePath string
+}
+
+// eventLocalLayout is the format of the times of an [Event], as used by
+// events.php: Asia/Tokyo without an offset.
+const eventLocalLayout = "2006-01-02T15:04:05"
+
+// eventTimeLayouts are the layouts accepted for the times of an
+// [EventRequest], in Asia/Tokyo unless they carry an offset.
+var eventTimeLayouts = []string{time.RFC3339, eventLocalLayout, "2006-01-02T15:04", time.DateOnly}
+
+// ListEvents returns the events overlapping the period from..to, both
+// inclusive, as an [EventsResponse] ordered by start. Events can be
+// filtered by status and kind.
+//
+// Unless derived is false, the events of the business calendar generated
+// by [deriveEvents] are merged in, for at most [maxDerivedMonths] months.
+// Recurring events are expanded into their occurrences; a missing bound of
+// the period is taken as for the derived events.
+func (c *EventsController) ListEvents(w http.ResponseWriter, r *http.Request, params ListEventsParams) {
+	f := eventFilter(params.From, params.To)
+	if params.Status != nil {
+		f.Statuses = *params.Status
+	}
+	if params.Kind != nil {
+		f.Kind = *params.Kind
+	}
+	all, err := collectEvents(r.Context(), c.Store, f, params.Derived == nil || *params.Derived)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	events := make([]Event, len(all))
+	for i, e := range all {
+		events[i] = e.Event
+	}
+	writeJSON(w, http.StatusOK, EventsResponse{Count: len(events), Events: events})
+}
+
+// collectEvents returns the stored events passing f ordered by start, with
+// the recurring ones expanded into their occurrences, merged with the
+// derived events passing it when derived is set.
+func collectEvents(ctx context.Context, store Store, f EventFilter, derived bool) ([]datedEvent, error) {
+	now := time.Now()
+	var generated []datedEvent
+	if derived {
+		from, to, err := derivedPeriod(f, now)
+		if err != nil {
+			return nil, err
+		}
+		if generated, err = deriveEvents(ctx, store, from, to); err != nil {
+			return nil, err
+		}
+	}
+	recs, err := store.Events().List(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+
+	all := make([]datedEvent, 0, len(recs)+len(generated))
+	var recurring []EventRecord
+	for _, rec := range recs {
+		if rec.RRule != nil {
+			recurring = append(recurring, rec)
+			continue
+		}
+		all = append(all, datedEvent{Event: event(rec), start: rec.Start})
+	}
+	if len(recurring) > 0 {
+		from, to, err := recurrencePeriod(f, now)
+		if err != nil {
+			return nil, err
+		}
+		occurrences, err := expandEvents(ctx, store, recurring, from, to)
+		if err != nil {
+			return nil, err
+		}
+		generated = append(generated, occurrences...)
+	}
+	for _, e := range generated {
+		if matchesEvent(f, e.Event) {
+			all = append(all, e)
+		}
+	}
+	// 登録したイベントは開始日時・ID 順のため、安定ソートで順序を保つ
+	slices.SortStableFunc(all, func(a, b datedEvent) int { return a.start.Compare(b.start) })
+	return all, nil
+}
+
+// CreateEvent creates an event from an [EventRequest] and returns it with a
+// 201 Created status.
+//
+// Shipper-side callers can only create events of their own shipper, which
+// is also the default shipping_id for them.
+func (c *EventsController) CreateEvent(w http.ResponseWriter, r *http.Request) {
+	var req EventRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+	ctx := r.Context()
+	in, err := c.eventInput(ctx, req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var e Event
+	err = c.Store.WithTx(ctx, func(tx Store) error {
+		id, err := tx.Events().Create(ctx, in)
+		if err != nil {
+			return err
+		}
+		rec, err := tx.Events().Find(ctx, id)
+		if err != nil {
+			return err
+		}
+		e = event(rec)
+		return recordAudit(ctx, tx, AuditCreate, "events", id, nil, e)
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	c.Broker.publish(EventChangeCreated, e, nil)
+	w.Header().Set("Location", c.BasePath+"/events/"+e.ID)
+	setETag(w, e.Version)
+	writeJSON(w, http.StatusCreated, e)
+}
+
+// GetEvent returns a single event, or 404 when it does not exist or belongs
+// to another shipper.
+func (c *EventsController) GetEvent(w http.ResponseWriter, r *http.Request, id ResourceID) {
+	rec, err := c.Store.Events().Find(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	setETag(w, rec.Version)
+	writeJSON(w, http.StatusOK, event(rec))
+}
+
+// UpdateEvent replaces the event identified by id.
+//
+// It returns 404 when no event has the requested ID, 412 when If-Match does
+// not carry the current ETag, and 422 when a shipper-side caller changes an
+// event shared by all shippers.
+func (c *EventsController) UpdateEvent(w http.ResponseWriter, r *http.Request, id ResourceID, params UpdateEventParams) {
+	if err := requireIfMatch(params.IfMatch); err != nil {
+		writeError(w, err)
+		return
+	}
+	var req EventRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+	ctx := r.Context()
+	in, err := c.eventInput(ctx, req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var before, e Event
+	err = c.Store.WithTx(ctx, func(tx Store) error {
+		var err error
+		if before, err = lockEvent(ctx, tx, id, params.IfMatch); err != nil {
+			return err
+		}
+		if err := tx.Events().Update(ctx, id, in); err != nil {
+			return err
+		}
+		rec, err := tx.Events().Find(ctx, id)
+		if err != nil {
+			return err
+		}
+		e = event(rec)
+		return recordAudit(ctx, tx, AuditUpdate, "events", id, before, e)
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	c.Broker.publish(EventChangeUpdated, e, &before)
+	setETag(w, e.Version)
+	writeJSON(w, http.StatusOK, e)
+}
+
+// DeleteEvent deletes the event identified by id and returns 204 No Content.
+//
+// It returns 404 when no event has the requested ID, 412 when If-Match does
+// not carry the current ETag, and 422 when a shipper-side caller deletes an
+// event shared by all shippers.
+func (c *EventsController) DeleteEvent(w http.ResponseWriter, r *http.Request, id ResourceID, params DeleteEventParams) {
+	if err := requireIfMatch(params.IfMatch); err != nil {
+		writeError(w, err)
+		return
+	}
+	ctx := r.Context()
+	var before Event
+	err := c.Store.WithTx(ctx, func(tx Store) error {
+		var err error
+		if before, err = lockEvent(ctx, tx, id, params.IfMatch); err != nil {
+			return err
+		}
+		if err := tx.Events().Delete(ctx, id); err != nil {
+			return err
+		}
+		return recordAudit(ctx, tx, AuditDelete, "events", id, before, nil)
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	c.Broker.publish(EventChangeDeleted, before, nil)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// UpdateEventOccurrence changes the occurrence of a recurring event
+// generated for date, replacing its previous override, and returns the
+// recurring event.
+//
+// It returns 404 when the event does not recur on date, 412 when If-Match
+// does not carry the current ETag of the recurring event, and 422 when the
+// occurrence would end before it starts.
+func (c *EventsController) UpdateEventOccurrence(w http.ResponseWriter, r *http.Request, id ResourceID, date openapi_types.Date, params UpdateEventOccurrenceParams) {
+	if err := requireIfMatch(params.IfMatch); err != nil {
+		writeError(w, err)
+		return
+	}
+	var req EventOccurrenceRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+	ctx := r.Context()
+	day := eventDay(date.Time)
+
+	var before, e Event
+	err := c.Store.WithTx(ctx, func(tx Store) error {
+		var rec EventRecord
+		var err error
+		if before, rec, err = lockOccurrence(ctx, tx, id, day, params.IfMatch); err != nil {
+			return err
+		}
+		o, err := occurrenceOverride(rec, day, req)
+		if err != nil {
+			return err
+		}
+		if err := tx.Events().SetOverride(ctx, id, o); err != nil {
+			return err
+		}
+		if rec, err = tx.Events().Find(ctx, id); err != nil {
+			return err
+		}
+		e = event(rec)
+		return recordAudit(ctx, tx, AuditUpdate, "events", id, before, e)
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	c.Broker.publish(EventChangeUpdated, e, &before)
+	setETag(w, e.Version)
+	writeJSON(w, http.StatusOK, e)
+}
+
+// CancelEventOccurrence leaves the occurrence of a recurring event generated
+// for date out by adding date to its exdates, discards the override of the
+// occurrence and returns 204 No Content.
+//
+// It returns 404 when the event does not recur on date and 412 when
+// If-Match does not carry the current ETag of the recurring event.
+func (c *EventsController) CancelEventOccurrence(w http.ResponseWriter, r *http.Request, id ResourceID, date openapi_types.Date, params CancelEventOccurrenceParams) {
+	if err := requireIfMatch(params.IfMatch); err != nil {
+		writeError(w, err)
+		return
+	}
+	ctx := r.Context()
+	day := eventDay(date.Time)
+	var before, e Event
+	err := c.Store.WithTx(ctx, func(tx Store) error {
+		var rec EventRecord
+		var err error
+		if before, rec, err = lockOccurrence(ctx, tx, id, day, params.IfMatch); err != nil {
+			return err
+		}
+		in := rec.EventInput
+		in.ExDates = append(slices.Clone(in.ExDates), day)
+		slices.SortFunc(in.ExDates, time.Time.Compare)
+		if err := tx.Events().Update(ctx, id, in); err != nil {
+			return err
+		}
+		if err := tx.Events().DeleteOverride(ctx, id, day); err != nil {
+			return err
+		}
+		if rec, err = tx.Events().Find(ctx, id); err != nil {
+			return err
+		}
+		e = event(rec)
+		return recordAudit(ctx, tx, AuditUpdate, "events", id, before, e)
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	c.Broker.publish(EventChangeUpdated, e, &before)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// lockOccurrence locks a recurring event as [lockEvent] does and returns it
+// as it is before the change, or a [NotFoundError] when it has no occurrence
+// for day.
+func lockOccurrence(ctx context.Context, tx Store, id ResourceID, day time.Time, ifMatch *IfMatch) (Event, EventRecord, error) {
+	before, err := lockEvent(ctx, tx, id, ifMatch)
+	if err != nil {
+		return before, EventRecord{}, err
+	}
+	rec, err := tx.Events().Find(ctx, id)
+	if err != nil {
+		return before, rec, err
+	}
+	ok, err := isOccurrence(rec, day)
+	if err != nil {
+		return before, rec, err
+	}
+	if !ok {
+		return before, rec, &NotFoundError{Resource: "event occurrence", ID: fmt.Sprintf("%d/%s", id, day.Format(time.DateOnly))}
+	}
+	return before, rec, nil
+}
+
+// occurrenceOverride converts and validates an [EventOccurrenceRequest] for
+// the occurrence of rec generated for day. Times are parsed as those of
+// rec, and the end must not be before the start the occurrence will have.
+func occurrenceOverride(rec EventRecord, day time.Time, req EventOccurrenceRequest) (OccurrenceOverride, error) {
+	o := OccurrenceOverride{Date: day, Title: req.Title, Status: req.Status, Description: req.Description}
+	if req.Start != nil {
+		start, err := parseEventTime("start", *req.Start, rec.AllDay)
+		if err != nil {
+			return o, err
+		}
+		o.Start = &start
+	}
+	if req.End != nil {
+		end, err := parseEventTime("end", *req.End, rec.AllDay)
+		if err != nil {
+			return o, err
+		}
+		o.End = &end
+	}
+	if o.End != nil {
+		// 開始を変えない場合は、元の日付の開始時刻と比べる
+		t := rec.Start.In(tokyo)
+		start := time.Date(day.Year(), day.Month(), day.Day(), t.Hour(), t.Minute(), t.Second(), 0, tokyo)
+		if o.Start != nil {
+			start = *o.Start
+		}
+		if o.End.Before(start) {
+			return o, &ValidationError{Message: "end must not be before start"}
+		}
+	}
+	return o, nil
+}
+
+// lockEvent locks an event for the transaction, checks its version against
+// the If-Match header and returns it as it is before the change. Events
+// shared by all shippers are read-only for shipper-side callers.
+func lockEvent(ctx context.Context, tx Store, id ResourceID, ifMatch *IfMatch) (Event, error) {
+	version, err := tx.Events().Lock(ctx, id)
+	if err != nil {
+		return Event{}, err
+	}
+	rec, err := tx.Events().Find(ctx, id)
+	if err != nil {
+		return Event{}, err
+	}
+	e := event(rec)
+	if err := checkVersion(ifMatch, version, func() (any, error) {
+		return e, nil
+	}); err != nil {
+		return e, err
+	}
+	if rec.ShippingID == nil && scopeOf(ctx).ShippingID != nil {
+		return e, &ValidationError{Message: "events shared by all shippers cannot be changed by shipper-side users"}
+	}
+	return e, nil
+}
+
+// eventInput converts and validates an [EventRequest]. A shipper-side
+// caller's shipper is filled in when shipping_id is missing; shippers
+// outside the caller's [Scope] are reported as missing.
+func (c *EventsController) eventInput(ctx context.Context, req EventRequest) (EventInput, error) {
+	in := EventInput{
+		ShippingID:  req.ShippingID,
+		Title:       req.Title,
+		Status:      EventStatusNone,
+		Kind:        EventKindAll,
+		Description: req.Description,
+	}
+	if req.AllDay != nil {
+		in.AllDay = *req.AllDay
+	}
+	if req.Status != nil {
+		in.Status = *req.Status
+	}
+	if req.Kind != nil {
+		in.Kind = *req.Kind
+	}
+
+	var err error
+	if in.Start, err = parseEventTime("start", req.Start, in.AllDay); err != nil {
+		return in, err
+	}
+	if req.End != nil {
+		end, err := parseEventTime("end", *req.End, in.AllDay)
+		if err != nil {
+			return in, err
+		}
+		if end.Before(in.Start) {
+			return in, &ValidationError{Message: "end must not be before start"}
+		}
+		in.End = &end
+	}
+	if err := recurrenceInput(&in, req); err != nil {
+		return in, err
+	}
+
+	if in.ShippingID == nil {
+		in.ShippingID = scopeOf(ctx).ShippingID
+	}
+	if in.ShippingID != nil {
+		ok, err := c.Store.Users().ShippingExists(ctx, *in.ShippingID)
+		if err != nil {
+			return in, err
+		}
+		if !ok {
+			return in, &ValidationError{Message: fmt.Sprintf("shipping_id %d does not exist", *in.ShippingID)}
+		}
+	}
+	return in, nil
+}
+
+// recurrenceInput validates the recurrence of an [EventRequest] into in.
+// The rule is stored in its canonical form, and exdates sorted without
+// duplicates.
+func recurrenceInput(in *EventInput, req EventRequest) error {
+	in.BusinessDay = EventBusinessDayNone
+	if req.BusinessDay != nil {
+		in.BusinessDay = *req.BusinessDay
+	}
+	if req.Rrule == nil {
+		if req.Exdates != nil && len(*req.Exdates) > 0 || in.BusinessDay != EventBusinessDayNone {
+			return &ValidationError{Message: "exdates and business_day require rrule"}
+		}
+		return nil
+	}
+	rule, err := rrule.Parse(*req.Rrule, tokyo)
+	if err != nil {
+		return &ValidationError{Message: err.Error()}
+	}
+	s := rule.String()
+	in.RRule = &s
+	if req.Exdates != nil {
+		for _, d := range *req.Exdates {
+			in.ExDates = append(in.ExDates, eventDay(d.Time))
+		}
+		slices.SortFunc(in.ExDates, time.Time.Compare)
+		in.ExDates = slices.CompactFunc(in.ExDates, time.Time.Equal)
+	}
+	return nil
+}
+
+// parseEventTime parses a time of an [EventRequest] in one of
+// eventTimeLayouts. The times of all-day events are truncated to the day.
+func parseEventTime(field, s string, allDay bool) (time.Time, error) {
+	for _, layout := range eventTimeLayouts {
+		t, err := time.ParseInLocation(layout, s, tokyo)
+		if err != nil {
+			continue
+		}
+		t = t.In(tokyo)
+		if allDay {
+			t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, tokyo)
+		}
+		return t, nil
+	}
+	return time.Time{}, &ValidationError{Message: fmt.Sprintf("%s %q is not a valid date or time", field, s)}
+}
+
+// event returns the row of events as returned by the API.
+func event(rec EventRecord) Event {
+	e := Event{
+		ID:          strconv.FormatInt(rec.ID, 10),
+		Title:       rec.Title,
+		Start:       formatEventTime(rec.Start, rec.AllDay),
+		AllDay:      rec.AllDay,
+		Status:      rec.Status,
+		Kind:        rec.Kind,
+		ShippingID:  rec.ShippingID,
+		Description: rec.Description,
+		Source:      EventSourceManual,
+		Version:     rec.Version,
+	}
+	if rec.End != nil {
+		end := formatEventTime(*rec.End, rec.AllDay)
+		e.End = &end
+	}
+	if rec.RRule != nil {
+		e.Rrule = rec.RRule
+		e.BusinessDay = &rec.BusinessDay
+		exdates := make([]openapi_types.Date, len(rec.ExDates))
+		for i, d := range rec.ExDates {
+			exdates[i] = apiDate(d)
+		}
+		e.Exdates = &exdates
+		overrides := make([]EventOverride, len(rec.Overrides))
+		for i, o := range rec.Overrides {
+			overrides[i] = EventOverride{RecurrenceID: apiDate(o.Date), Title: o.Title, Status: o.Status, Description: o.Description}
+			if o.Start != nil {
+				start := formatEventTime(*o.Start, rec.AllDay)
+				overrides[i].Start = &start
+			}
+			if o.End != nil {
+				end := formatEventTime(*o.End, rec.AllDay)
+				overrides[i].End = &end
+			}
+		}
+		e.Overrides = &overrides
+	}
+	return e
+}
+
+// apiDate converts a midnight in Asia/Tokyo to a date of the API.
+func apiDate(t time.Time) openapi_types.Date {
+	return openapi_types.Date{Time: time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)}
+}
+
+// formatEventTime formats a time of an [Event] in Asia/Tokyo, as a date for
+// all-day events.
+func formatEventTime(t time.Time, allDay bool) string {
+	if allDay {
+		return t.In(tokyo).Format(time.DateOnly)
+	}
+	return t.In(tokyo).Format(eventLocalLayout)
+}
+
+// eventFilter converts the period of [ListEventsParams]. Dates are taken in
+// Asia/Tokyo and to includes the whole day.
+func eventFilter(from, to *openapi_types.Date) EventFilter {
+	var f EventFilter
+	if from != nil {
+		t := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, tokyo)
+		f.From = &t
+	}
+	if to != nil {
+		t := time.Date(to.Year(), to.Month(), to.Day()+1, 0, 0, 0, 0, tokyo)
+		f.To = &t
+	}
+	return f
+}
@@ -0,0 +1,237 @@
+package controllers
+
+import (
+	"backend-go/schema"
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+)
+
+// eventSelect selects events rows in the order of [scanEvent]. Queries must
+// add the [Scope] filter of events, unqualified.
+const eventSelect = `SELECT id, shipping_id, title, start_at, end_at, all_day, status, kind, description, rrule,
+exdates, business_day, version
+FROM events`
+
+// mysqlEvents is the [EventRepository] of [MySQLStore].
+type mysqlEvents struct {
+	s *MySQLStore
+}
+
+func (r mysqlEvents) List(ctx context.Context, f EventFilter) ([]EventRecord, error) {
+	var conds []string
+	var args []any
+	if f.To != nil {
+		conds = append(conds, "start_at < ?")
+		args = append(args, *f.To)
+	}
+	if f.From != nil {
+		// 期間内に始まるか、期間の開始をまたいで続くイベント（繰り返しイベントは回ごとに呼び出し側で判定する）
+		conds = append(conds, "(rrule IS NOT NULL OR start_at >= ? OR end_at > ?)")
+		args = append(args, *f.From, *f.From)
+	}
+	if len(f.Statuses) > 0 {
+		// 回ごとの変更で状態が変わるため、繰り返しイベントは状態で絞り込まない
+		conds = append(conds, "(rrule IS NOT NULL OR status IN (?"+strings.Repeat(", ?", len(f.Statuses)-1)+"))")
+		for _, st := range f.Statuses {
+			args = append(args, st)
+		}
+	}
+	if f.Kind != "" {
+		conds = append(conds, "kind = ?")
+		args = append(args, f.Kind)
+	}
+	where, args := scopeOf(ctx).where("events", "", conds, args)
+
+	rows, err := r.s.q.QueryContext(ctx, eventSelect+where+" ORDER BY start_at, id", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []EventRecord{}
+	for rows.Next() {
+		e, err := scanEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+	return events, r.loadOverrides(ctx, events)
+}
+
+func (r mysqlEvents) Find(ctx context.Context, id int64) (EventRecord, error) {
+	where, args := scopeOf(ctx).where("events", "", []string{"id = ?"}, []any{id})
+	e, err := scanEvent(r.s.q.QueryRowContext(ctx, eventSelect+where, args...))
+	if errors.Is(err, sql.ErrNoRows) {
+		return e, &NotFoundError{Resource: "event", ID: id}
+	}
+	if err != nil {
+		return e, err
+	}
+	events := []EventRecord{e}
+	err = r.loadOverrides(ctx, events)
+	return events[0], err
+}
+
+// loadOverrides fills in the overrides of the recurring events.
+func (r mysqlEvents) loadOverrides(ctx context.Context, events []EventRecord) error {
+	index := map[int64]int{}
+	var args []any
+	for i, e := range events {
+		if e.RRule != nil {
+			index[e.ID] = i
+			args = append(args, e.ID)
+		}
+	}
+	if len(args) == 0 {
+		return nil
+	}
+	rows, err := r.s.q.QueryContext(ctx,
+		`SELECT event_id, recurrence_date, title, start_at, end_at, status, description FROM event_overrides
+WHERE event_id IN (?`+strings.Repeat(", ?", len(args)-1)+`) ORDER BY event_id, recurrence_date`, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id int64
+		var o OccurrenceOverride
+		if err := rows.Scan(&id, &o.Date, &o.Title, &o.Start, &o.End, &o.Status, &o.Description); err != nil {
+			return err
+		}
+		o.Date = eventDay(o.Date)
+		e := &events[index[id]]
+		e.Overrides = append(e.Overrides, o)
+	}
+	return rows.Err()
+}
+
+func (r mysqlEvents) Create(ctx context.Context, in EventInput) (int64, error) {
+	var createdBy *int64
+	if au, ok := AuthUserFrom(ctx); ok {
+		createdBy = &au.ID
+	}
+	res, err := r.s.q.ExecContext(ctx,
+		`INSERT INTO events (shipping_id, title, start_at, end_at, all_day, status, kind, description, rrule, exdates,
+		business_day, created_by)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		in.ShippingID, in.Title, in.Start, in.End, in.AllDay, in.Status, in.Kind, nullString(in.Description),
+		nullString(in.RRule), joinExDates(in.ExDates), in.BusinessDay, createdBy)
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+func (r mysqlEvents) Lock(ctx context.Context, id int64) (int64, error) {
+	where, args := scopeOf(ctx).where("events", "", []string{"id = ?"}, []any{id})
+	var version int64
+	err := r.s.q.QueryRowContext(ctx, "SELECT version FROM events"+where+" FOR UPDATE", args...).Scan(&version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, &NotFoundError{Resource: "event", ID: id}
+	}
+	return version, err
+}
+
+func (r mysqlEvents) Update(ctx context.Context, id int64, in EventInput) error {
+	where, args := scopeOf(ctx).where("events", "", []string{"id = ?"}, []any{id})
+	res, err := r.s.q.ExecContext(ctx,
+		`UPDATE events SET shipping_id = ?, title = ?, start_at = ?, end_at = ?, all_day = ?, status = ?, kind = ?,
+		description = ?, rrule = ?, exdates = ?, business_day = ?, version = version + 1`+where,
+		append([]any{in.ShippingID, in.Title, in.Start, in.End, in.AllDay, in.Status, in.Kind,
+			nullString(in.Description), nullString(in.RRule), joinExDates(in.ExDates), in.BusinessDay}, args...)...)
+	if err != nil {
+		return err
+	}
+	return requireAffected(res, "event", id)
+}
+
+func (r mysqlEvents) Delete(ctx context.Context, id int64) error {
+	if _, err := r.Lock(ctx, id); err != nil {
+		return err
+	}
+	if _, err := r.s.q.ExecContext(ctx, "DELETE FROM event_overrides WHERE event_id = ?", id); err != nil {
+		return err
+	}
+	where, args := scopeOf(ctx).where("events", "", []string{"id = ?"}, []any{id})
+	res, err := r.s.q.ExecContext(ctx, "DELETE FROM events"+where, args...)
+	if err != nil {
+		return err
+	}
+	return requireAffected(res, "event", id)
+}
+
+func (r mysqlEvents) SetOverride(ctx context.Context, id int64, o OccurrenceOverride) error {
+	where, args := scopeOf(ctx).where("events", "", []string{"id = ?"}, []any{id})
+	res, err := r.s.q.ExecContext(ctx, "UPDATE events SET version = version + 1"+where, args...)
+	if err != nil {
+		return err
+	}
+	if err := requireAffected(res, "event", id); err != nil {
+		return err
+	}
+	_, err = r.s.q.ExecContext(ctx,
+		`INSERT INTO event_overrides (event_id, recurrence_date, title, start_at, end_at, status, description)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE title = VALUES(title), start_at = VALUES(start_at), end_at = VALUES(end_at),
+		status = VALUES(status), description = VALUES(description)`,
+		id, o.Date.Format(schema.DateLayout), nullString(o.Title), o.Start, o.End, o.Status, nullString(o.Description))
+	return err
+}
+
+func (r mysqlEvents) DeleteOverride(ctx context.Context, id int64, date time.Time) error {
+	if _, err := r.Lock(ctx, id); err != nil {
+		return err
+	}
+	_, err := r.s.q.ExecContext(ctx, "DELETE FROM event_overrides WHERE event_id = ? AND recurrence_date = ?",
+		id, date.Format(schema.DateLayout))
+	return err
+}
+
+// scanEvent reads one row selected by [eventSelect].
+func scanEvent(s rowScanner) (EventRecord, error) {
+	var e EventRecord
+	var exdates sql.NullString
+	err := s.Scan(&e.ID, &e.ShippingID, &e.Title, &e.Start, &e.End, &e.AllDay, &e.Status, &e.Kind,
+		&e.Description, &e.RRule, &exdates, &e.BusinessDay, &e.Version)
+	if err == nil && exdates.Valid {
+		e.ExDates, err = splitExDates(exdates.String)
+	}
+	return e, err
+}
+
+// joinExDates formats the exdates column: the dates separated by commas, or
+// NULL when there are none.
+func joinExDates(dates []time.Time) sql.NullString {
+	if len(dates) == 0 {
+		return sql.NullString{}
+	}
+	s := make([]string, len(dates))
+	for i, d := range dates {
+		s[i] = d.Format(schema.DateLayout)
+	}
+	return sql.NullString{String: strings.Join(s, ","), Valid: true}
+}
+
+// splitExDates parses the exdates column written by [joinExDates].
+func splitExDates(s string) ([]time.Time, error) {
+	var dates []time.Time
+	for v := range strings.SplitSeq(s, ",") {
+		if v = strings.TrimSpace(v); v == "" {
+			continue
+		}
+		d, err := time.ParseInLocation(schema.DateLayout, v, tokyo)
+		if err != nil {
+			return nil, err
+		}
+		dates = append(dates, d)
+	}
+	return dates, nil
+}
@@ -0,0 +1,238 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// 共通ヘルパー: イベントを登録して返す
+func postEvent(t *testing.T, c *EventsController, body EventRequest, shippingID *int64) (Event, int) {
+	t.Helper()
+	req, w := newJSONRequest("POST", "/events", body)
+	if shippingID != nil {
+		req = withShipper(req, *shippingID)
+	} else {
+		req = withAuth(req, 7, 10)
+	}
+	c.CreateEvent(w, req)
+	var e Event
+	json.NewDecoder(w.Body).Decode(&e)
+	return e, w.Code
+}
+
+func listEvents(t *testing.T, c *EventsController, params ListEventsParams) EventsResponse {
+	t.Helper()
+	req, w := newJSONRequest("GET", "/events", nil)
+	c.ListEvents(w, withAuth(req, 7, 10), params)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var res EventsResponse
+	json.NewDecoder(w.Body).Decode(&res)
+	return res
+}
+
+func date(y int, m time.Month, d int) *openapi_types.Date {
+	return &openapi_types.Date{Time: time.Date(y, m, d, 0, 0, 0, 0, time.UTC)}
+}
+
+func TestEvents_List(t *testing.T) {
+	c := &EventsController{Store: newSeededStore()}
+	for _, body := range []EventRequest{
+		{Title: "入荷", Start: "2026-02-01T10:00:00", End: ptr("2026-02-01T11:00:00"), Status: ptr(EventStatusError), Kind: ptr(EventKindShipper)},
+		{Title: "棚卸", Start: "2026-01-30", End: ptr("2026-02-02"), AllDay: ptr(true), Kind: ptr(EventKindWarehouse)},
+		{Title: "出荷", Start: "2026-02-03T00:30:00+09:00", Status: ptr(EventStatusDone)},
+		{Title: "翌月", Start: "2026-03-01T09:00:00"},
+	} {
+		if _, code := postEvent(t, c, body, nil); code != http.StatusCreated {
+			t.Fatalf("Expected 201, got %d", code)
+		}
+	}
+
+	titles := func(res EventsResponse) []string {
+		var out []string
+		for _, e := range res.Events {
+			out = append(out, e.Title)
+		}
+		return out
+	}
+	cases := []struct {
+		name   string
+		params ListEventsParams
+		want   []string
+	}{
+		{"all", ListEventsParams{}, []string{"棚卸", "入荷", "出荷", "翌月"}},
+		{"range", ListEventsParams{From: date(2026, 2, 1), To: date(2026, 2, 28)}, []string{"棚卸", "入荷", "出荷"}},
+		{"after all-day end", ListEventsParams{From: date(2026, 2, 2), To: date(2026, 2, 3)}, []string{"出荷"}},
+		{"status", ListEventsParams{Status: &[]EventStatus{EventStatusError, EventStatusDone}}, []string{"入荷", "出荷"}},
+		{"kind", ListEventsParams{Kind: ptr(EventKindWarehouse)}, []string{"棚卸"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// 導出したイベント（祝日など）は calendar_test.go で確かめる
+			tc.params.Derived = ptr(false)
+			res := listEvents(t, c, tc.params)
+			if got := titles(res); res.Count != len(tc.want) || len(got) != len(tc.want) {
+				t.Fatalf("Expected %v, got %v", tc.want, got)
+			} else {
+				for i := range got {
+					if got[i] != tc.want[i] {
+						t.Errorf("Expected %v, got %v", tc.want, got)
+						break
+					}
+				}
+			}
+		})
+	}
+
+	// events.php と同じ形式（終日は日付のみ）
+	res := listEvents(t, c, ListEventsParams{})
+	if e := res.Events[0]; e.Start != "2026-01-30" || *e.End != "2026-02-02" || !e.AllDay {
+		t.Errorf("unexpected all-day event %+v", e)
+	}
+	if e := res.Events[1]; e.Start != "2026-02-01T10:00:00" || e.Status != EventStatusError || e.Kind != EventKindShipper {
+		t.Errorf("unexpected event %+v", e)
+	}
+	if e := res.Events[3]; e.Status != EventStatusNone || e.Kind != EventKindAll || e.End != nil {
+		t.Errorf("unexpected defaults %+v", e)
+	}
+}
+
+func TestEvents_Validation(t *testing.T) {
+	c := &EventsController{Store: newSeededStore()}
+	cases := []struct {
+		name string
+		body EventRequest
+	}{
+		{"bad start", EventRequest{Title: "x", Start: "2026/02/01"}},
+		{"end before start", EventRequest{Title: "x", Start: "2026-02-01T10:00:00", End: ptr("2026-02-01T09:00:00")}},
+		{"unknown shipper", EventRequest{Title: "x", Start: "2026-02-01", ShippingID: ptr[int64](99)}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, code := postEvent(t, c, tc.body, nil); code != http.StatusUnprocessableEntity {
+				t.Errorf("Expected 422, got %d", code)
+			}
+		})
+	}
+}
+
+func TestEvents_Scoped(t *testing.T) {
+	store := newSeededStore()
+	c := &EventsController{Store: store}
+	shared, _ := postEvent(t, c, EventRequest{Title: "共通", Start: "2026-02-01"}, nil)
+	other, _ := postEvent(t, c, EventRequest{Title: "荷主B", Start: "2026-02-01", ShippingID: ptr[int64](2)}, nil)
+
+	// 荷主側ユーザのイベントは自分の荷主になる
+	own, code := postEvent(t, c, EventRequest{Title: "荷主A", Start: "2026-02-01"}, ptr[int64](1))
+	if code != http.StatusCreated || own.ShippingID == nil || *own.ShippingID != 1 {
+		t.Fatalf("unexpected event %d %+v", code, own)
+	}
+	if _, code := postEvent(t, c, EventRequest{Title: "x", Start: "2026-02-01", ShippingID: ptr[int64](2)}, ptr[int64](1)); code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected 422 for another shipper, got %d", code)
+	}
+
+	req, w := newJSONRequest("GET", "/events", nil)
+	c.ListEvents(w, withShipper(req, 1), ListEventsParams{Derived: ptr(false)})
+	var res EventsResponse
+	json.NewDecoder(w.Body).Decode(&res)
+	if res.Count != 2 {
+		t.Errorf("Expected the shared and own events, got %+v", res.Events)
+	}
+
+	req, w = newJSONRequest("GET", "/events/"+other.ID, nil)
+	c.GetEvent(w, withShipper(req, 1), mustID(t, other.ID))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404, got %d", w.Code)
+	}
+
+	// 共通のイベントは参照できるが変更できない
+	req, w = newJSONRequest("DELETE", "/events/"+shared.ID, nil)
+	c.DeleteEvent(w, withShipper(req, 1), mustID(t, shared.ID), DeleteEventParams{IfMatch: ifMatchV1})
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected 422, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestEvents_UpdateDelete(t *testing.T) {
+	store := newSeededStore()
+	c := &EventsController{Store: store}
+	e, _ := postEvent(t, c, EventRequest{Title: "入荷", Start: "2026-02-01T10:00:00"}, nil)
+	id := mustID(t, e.ID)
+
+	body := EventRequest{Title: "入荷（変更）", Start: "2026-02-02T10:00:00", Status: ptr(EventStatusWarning)}
+	req, w := newJSONRequest("PUT", "/events/"+e.ID, body)
+	c.UpdateEvent(w, withAuth(req, 7, 10), id, UpdateEventParams{IfMatch: ifMatchV1})
+	if w.Code != http.StatusOK || w.Header().Get("ETag") != `"2"` {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	req, w = newJSONRequest("PUT", "/events/"+e.ID, body)
+	c.UpdateEvent(w, withAuth(req, 7, 10), id, UpdateEventParams{IfMatch: ifMatchV1})
+	if w.Code != http.StatusPreconditionFailed {
+		t.Errorf("Expected 412, got %d", w.Code)
+	}
+
+	req, w = newJSONRequest("DELETE", "/events/"+e.ID, nil)
+	c.DeleteEvent(w, withAuth(req, 7, 10), id, DeleteEventParams{IfMatch: ptr[IfMatch](`"2"`)})
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("Expected 204, got %d: %s", w.Code, w.Body.String())
+	}
+	if _, err := store.Events().Find(t.Context(), id); err == nil {
+		t.Error("the event was not deleted")
+	}
+
+	logs, total, _ := store.Audit().List(t.Context(), AuditFilter{Table: "events", Limit: 10})
+	if total != 3 || logs[0].Action != AuditDelete || logs[1].Changes["title"].After != "入荷（変更）" {
+		t.Errorf("unexpected audit %+v", logs)
+	}
+}
+
+func TestMySQLEvents_List(t *testing.T) {
+	ctrl, mock, teardown := setup(t)
+	defer teardown()
+
+	// 荷主側ユーザには自荷主と全荷主共通のイベント
+	mock.ExpectQuery(`FROM events WHERE start_at < \? AND \(rrule IS NOT NULL OR start_at >= \? OR end_at > \?\) AND \(rrule IS NOT NULL OR status IN \(\?, \?\)\) AND kind = \? AND \(shipping_id = \? OR shipping_id IS NULL\) ORDER BY start_at, id`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "shipping_id", "title", "start_at", "end_at", "all_day", "status", "kind", "description", "rrule", "exdates", "business_day", "version"}).
+			AddRow(1, nil, "入荷", time.Date(2026, 2, 1, 1, 0, 0, 0, time.UTC), nil, false, "error", "shipper", nil, nil, nil, "none", 1).
+			AddRow(2, nil, "定例", time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC), nil, false, "", "shipper", nil, "FREQ=WEEKLY", "2026-02-09", "none", 3))
+	// 繰り返しイベントの回ごとの変更（状態を error にした回だけが絞り込みに残る）
+	mock.ExpectQuery(`SELECT event_id, recurrence_date, title, start_at, end_at, status, description FROM event_overrides WHERE event_id IN \(\?\) ORDER BY event_id, recurrence_date`).
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows([]string{"event_id", "recurrence_date", "title", "start_at", "end_at", "status", "description"}).
+			AddRow(2, time.Date(2026, 2, 16, 0, 0, 0, 0, time.UTC), nil, nil, nil, "error", nil))
+
+	req, w := newJSONRequest("GET", "/events", nil)
+	c := &EventsController{Store: ctrl.Store}
+	c.ListEvents(w, withShipper(req, 5), ListEventsParams{
+		From:    date(2026, 2, 1),
+		To:      date(2026, 2, 28),
+		Status:  &[]EventStatus{EventStatusError, EventStatusWarning},
+		Kind:    ptr(EventKindShipper),
+		Derived: ptr(false),
+	})
+	var res EventsResponse
+	json.NewDecoder(w.Body).Decode(&res)
+	if w.Code != http.StatusOK || res.Count != 2 || res.Events[0].Start != "2026-02-01T10:00:00" ||
+		res.Events[1].ID != "2-20260216" || res.Events[1].Start != "2026-02-16T09:00:00" {
+		t.Errorf("unexpected response %d %+v", w.Code, res)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func mustID(t *testing.T, s string) int64 {
+	t.Helper()
+	var id int64
+	if err := json.Unmarshal([]byte(s), &id); err != nil {
+		t.Fatal(err)
+	}
+	return id
+}
@@ -0,0 +1,48 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// readyTimeout bounds the DB ping performed by Readyz.
+const readyTimeout = 2 * time.Second
+
+// HealthResponse is the body returned by the probe endpoints.
+type HealthResponse struct {
+	Status string `json:"status"`
+	// Database is set by Readyz only.
+	Database string `json:"database,omitempty"`
+}
+
+// HealthController serves the liveness and readiness probes used by
+// docker-compose and Kubernetes. The probes are not part of the OpenAPI
+// specification and are registered outside the request validator.
+type HealthController struct {
+	DB Pinger
+}
+
+// Pinger checks the database connection. It is implemented by *sql.DB.
+type Pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+// Healthz reports that the process is alive. It never touches the database
+// so that a database outage does not make the orchestrator restart the pod.
+func (c *HealthController) Healthz(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, HealthResponse{Status: "ok"})
+}
+
+// Readyz reports whether the server can serve traffic, i.e. whether the
+// database answers a ping. It returns 503 otherwise.
+func (c *HealthController) Readyz(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), readyTimeout)
+	defer cancel()
+
+	if err := c.DB.PingContext(ctx); err != nil {
+		writeJSON(w, http.StatusServiceUnavailable, HealthResponse{Status: "unavailable", Database: "down"})
+		return
+	}
+	writeJSON(w, http.StatusOK, HealthResponse{Status: "ok", Database: "up"})
+}
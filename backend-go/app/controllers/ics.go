@@ -0,0 +1,134 @@
+package controllers
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// icsUIDDomain qualifies the UIDs of the VEVENTs so that they are globally
+// unique. It must not change, or subscribers would see every event twice.
+const icsUIDDomain = "shimada-trading"
+
+// icsTimeZone is the VTIMEZONE of Asia/Tokyo. Japan has not observed
+// daylight saving time since 1951, so a single standard observance covers
+// every event.
+var icsTimeZone = []string{
+	"BEGIN:VTIMEZONE",
+	"TZID:Asia/Tokyo",
+	"X-LIC-LOCATION:Asia/Tokyo",
+	"BEGIN:STANDARD",
+	"DTSTART:19700101T000000",
+	"TZOFFSETFROM:+0900",
+	"TZOFFSETTO:+0900",
+	"TZNAME:JST",
+	"END:STANDARD",
+	"END:VTIMEZONE",
+}
+
+// icsCalendar renders events as an RFC 5545 VCALENDAR published under name.
+// now is the DTSTAMP of the events.
+//
+// The UID of an event is derived from its ID, and SEQUENCE is its version,
+// so that subscribers replace an event they already have when it changes.
+// Times are local to Asia/Tokyo; all-day events use DATE values with an
+// exclusive end, as in [Event].
+func icsCalendar(name string, events []Event, now time.Time) string {
+	var b strings.Builder
+	line := func(prop, value string) {
+		writeICSLine(&b, prop+":"+value)
+	}
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//"+icsUIDDomain+"//backend-go//JA")
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	line("X-WR-CALNAME", icsText(name))
+	line("X-WR-TIMEZONE", "Asia/Tokyo")
+	// 購読側の更新間隔の目安
+	line("REFRESH-INTERVAL;VALUE=DURATION", "PT1H")
+	line("X-PUBLISHED-TTL", "PT1H")
+	for _, l := range icsTimeZone {
+		writeICSLine(&b, l)
+	}
+
+	stamp := now.UTC().Format("20060102T150405Z")
+	for _, e := range events {
+		line("BEGIN", "VEVENT")
+		line("UID", icsUID(e))
+		line("DTSTAMP", stamp)
+		if e.AllDay {
+			start, _ := time.ParseInLocation(time.DateOnly, e.Start, tokyo)
+			end := start.AddDate(0, 0, 1)
+			if e.End != nil {
+				if t, err := time.ParseInLocation(time.DateOnly, *e.End, tokyo); err == nil && t.After(start) {
+					end = t
+				}
+			}
+			line("DTSTART;VALUE=DATE", start.Format("20060102"))
+			line("DTEND;VALUE=DATE", end.Format("20060102"))
+		} else {
+			line("DTSTART;TZID=Asia/Tokyo", icsLocalTime(e.Start))
+			if e.End != nil {
+				line("DTEND;TZID=Asia/Tokyo", icsLocalTime(*e.End))
+			}
+		}
+		line("SUMMARY", icsText(e.Title))
+		if e.Description != nil && *e.Description != "" {
+			line("DESCRIPTION", icsText(*e.Description))
+		}
+		categories := []string{icsText(string(e.Kind))}
+		if e.Status != EventStatusNone {
+			categories = append(categories, icsText(string(e.Status)))
+		}
+		line("CATEGORIES", strings.Join(categories, ","))
+		line("SEQUENCE", strconv.FormatInt(e.Version, 10))
+		line("END", "VEVENT")
+	}
+	line("END", "VCALENDAR")
+	return b.String()
+}
+
+// icsUID returns the UID of e. Stored and derived events have IDs of
+// different forms, which never collide.
+func icsUID(e Event) string {
+	id := e.ID
+	if e.Source == EventSourceManual {
+		id = "event-" + id
+	}
+	return id + "@" + icsUIDDomain
+}
+
+// icsLocalTime converts a time of an [Event] to the local DATE-TIME form.
+func icsLocalTime(s string) string {
+	t, err := time.ParseInLocation(eventLocalLayout, s, tokyo)
+	if err != nil {
+		return s
+	}
+	return t.Format("20060102T150405")
+}
+
+// icsText escapes a TEXT value.
+func icsText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", "").Replace(s)
+}
+
+// writeICSLine writes a content line terminated by CRLF, folded so that no
+// line exceeds 75 octets. Lines are folded between characters, never inside
+// a UTF-8 sequence.
+func writeICSLine(b *strings.Builder, l string) {
+	const limit = 75
+	n := 0
+	for len(l) > 0 {
+		_, size := utf8.DecodeRuneInString(l)
+		if n+size > limit {
+			b.WriteString("\r\n ")
+			n = 1
+		}
+		b.WriteString(l[:size])
+		n += size
+		l = l[size:]
+	}
+	b.WriteString("\r\n")
+}
@@ -0,0 +1,525 @@
+package controllers
+
+import (
+	"backend-go/schema"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// maxImportErrors is the number of row errors returned in an
+// [ImportResult]; the rest are only counted.
+const maxImportErrors = 1000
+
+// errImportRollback makes WithTx roll back a dry run or a unit of rows with
+// errors.
+var errImportRollback = errors.New("import rolled back")
+
+// ImportController loads master tables from CSV files and Excel workbooks.
+// Every row goes through the checks of [MastersController] and is written
+// to the audit trail like a row written through the API.
+type ImportController struct {
+	Store  Store
+	Tables map[string]*MasterTable
+	// AsyncRows is the number of data rows above which a file is imported
+	// by a background job.
+	AsyncRows int
+	// ChunkSize is the number of rows committed together with commit=chunk.
+	ChunkSize int
+	// JobTTL is how long a finished job can be polled.
+	JobTTL time.Duration
+	// BasePath is the path the operations are mounted under, which the
+	// Location of a created job starts with.
+	BasePath string
+
+	jobs importJobs
+}
+
+// ImportMasterRecords imports the rows of the uploaded file and returns an
+// [ImportResult] with the errors of each row. Files with more than
+// AsyncRows rows are imported in the background: the response is 202 with
+// the [ImportJob] to poll.
+//
+// The file is parsed and its header checked before responding, so that a
+// file that cannot be imported at all is rejected with 422 either way.
+func (c *ImportController) ImportMasterRecords(w http.ResponseWriter, r *http.Request, table MasterTableName, params ImportMasterRecordsParams) {
+	t, err := lookupTable(c.Tables, table)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := authorizeWrite(r, c.Store, t); err != nil {
+		writeError(w, err)
+		return
+	}
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, &BadRequestError{Err: err})
+		return
+	}
+	var sheet string
+	if params.Sheet != nil {
+		sheet = *params.Sheet
+	}
+	file, err := readImportFile(data, r.Header.Get("Content-Type"), sheet)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	im, err := newImporter(t, file)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	im.dryRun = params.DryRun != nil && *params.DryRun
+	if params.Commit != nil && *params.Commit == ImportCommitChunk {
+		im.chunk = c.ChunkSize
+	}
+
+	if len(file.Rows) <= c.AsyncRows {
+		res, err := im.run(r.Context(), c.Store, nil)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, res)
+		return
+	}
+
+	au, _ := AuthUserFrom(r.Context())
+	job, err := c.jobs.add(t.Name, len(file.Rows), au.ID, c.JobTTL)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	// ログインユーザとリクエストIDは引き継ぎ、レスポンスを返しても取消されないようにする
+	ctx := context.WithoutCancel(r.Context())
+	go c.runJob(ctx, job.ID, im)
+	w.Header().Set("Location", c.BasePath+"/import-jobs/"+job.ID)
+	writeJSON(w, http.StatusAccepted, job)
+}
+
+// runJob imports the rows of a background job and records its outcome.
+func (c *ImportController) runJob(ctx context.Context, id string, im *importer) {
+	c.jobs.update(id, func(j *ImportJob) { j.Status = ImportRunning })
+	res, err := im.run(ctx, c.Store, func(n int) {
+		c.jobs.update(id, func(j *ImportJob) { j.ProcessedRows = n })
+	})
+	now := time.Now()
+	if err != nil {
+		status, message := errorStatus(err)
+		if status == http.StatusInternalServerError {
+			slog.ErrorContext(ctx, "import job failed", "job", id, "table", im.t.Name, "error", err)
+		}
+		c.jobs.update(id, func(j *ImportJob) {
+			j.Status, j.Error, j.FinishedAt = ImportFailed, &message, &now
+		})
+		return
+	}
+	c.jobs.update(id, func(j *ImportJob) {
+		j.Status, j.Result, j.FinishedAt = ImportSucceeded, &res, &now
+	})
+}
+
+// GetImportJob returns the progress of a background import. Jobs are only
+// shown to the user who started them.
+func (c *ImportController) GetImportJob(w http.ResponseWriter, r *http.Request, id string) {
+	au, _ := AuthUserFrom(r.Context())
+	job, ok := c.jobs.get(id, au.ID, c.JobTTL)
+	if !ok {
+		writeError(w, &NotFoundError{Resource: "import job", ID: id})
+		return
+	}
+	writeJSON(w, http.StatusOK, job)
+}
+
+// importer writes the rows of an [importFile] to a table.
+type importer struct {
+	t    *MasterTable
+	file *importFile
+	// index is the position of each column in the header.
+	index  map[string]int
+	dryRun bool
+	// chunk is the number of rows committed together; 0 commits every row
+	// in one transaction.
+	chunk int
+	// refs caches the foreign keys found to exist or not. Only references
+	// to other tables are cached, as the import does not change them.
+	refs map[importRef]bool
+}
+
+// importRef is a key of importer.refs.
+type importRef struct {
+	table, column, value string
+}
+
+// importUnit counts the rows written in one transaction.
+type importUnit struct {
+	rows, inserted, updated int
+	errors                  []ImportError
+	// failed is the number of rows with errors.
+	failed int
+}
+
+// newImporter maps the header of file to the exposed columns of t by name
+// or by Japanese name (see [logicalNames]). Unknown, ambiguous and
+// duplicate headers are rejected, and so is a file that can only insert
+// rows but lacks a required column.
+func newImporter(t *MasterTable, file *importFile) (*importer, error) {
+	byComment := map[string][]*schema.Column{}
+	for _, col := range t.Columns {
+		for _, name := range logicalNames(col) {
+			byComment[name] = append(byComment[name], col)
+		}
+	}
+
+	im := &importer{
+		t:     t,
+		file:  file,
+		index: map[string]int{},
+		refs:  map[importRef]bool{},
+	}
+	for i, h := range file.Header {
+		h = strings.TrimSpace(h)
+		if h == "" {
+			continue // 見出しのない列は読まない
+		}
+		col, ok := t.Column(h)
+		if !ok {
+			switch cols := byComment[h]; len(cols) {
+			case 0:
+				return nil, &ValidationError{Message: fmt.Sprintf("unknown column %q", h)}
+			case 1:
+				col = cols[0]
+			default:
+				return nil, &ValidationError{Message: fmt.Sprintf("column %q is ambiguous; use the column name", h)}
+			}
+		}
+		if _, ok := im.index[col.Name]; ok {
+			return nil, &ValidationError{Message: fmt.Sprintf("column %s appears more than once", col.Name)}
+		}
+		im.index[col.Name] = i
+	}
+
+	// id の列がなければすべて登録になる
+	if _, ok := im.index["id"]; !ok {
+		for _, col := range t.Columns {
+			if _, ok := im.index[col.Name]; !ok && col.Required() && col.Name != versionColumn {
+				return nil, &ValidationError{Message: fmt.Sprintf("column %s is required", col.Name)}
+			}
+		}
+	}
+	return im, nil
+}
+
+// logicalNames returns the Japanese names of a column matched against the
+// header: the comment, and the comment without the notes following "⇒"
+// (e.g. "部門コード" of "部門コード⇒何桁？★").
+func logicalNames(col *schema.Column) []string {
+	name, _, _ := strings.Cut(col.Comment, "⇒")
+	name = strings.TrimSpace(strings.TrimRight(name, "★"))
+	switch {
+	case col.Comment == "":
+		return nil
+	case name == "" || name == col.Comment:
+		return []string{col.Comment}
+	}
+	return []string{col.Comment, name}
+}
+
+// run writes the rows in transactions of im.chunk rows, or of every row,
+// and reports the outcome. A unit with a row error is rolled back, and so
+// is everything on a dry run. Errors other than those of a row, such as a
+// lost connection, stop the import.
+//
+// progress, when given, is called with the number of rows processed.
+func (im *importer) run(ctx context.Context, store Store, progress func(int)) (ImportResult, error) {
+	rows := im.file.Rows
+	res := ImportResult{DryRun: im.dryRun, TotalRows: len(rows), Errors: []ImportError{}}
+	size := len(rows)
+	if im.chunk > 0 && !im.dryRun {
+		size = im.chunk
+	}
+	for start := 0; start < len(rows); start += size {
+		unit := rows[start:min(start+size, len(rows))]
+		var u importUnit
+		err := store.WithTx(ctx, func(tx Store) error {
+			u = importUnit{rows: len(unit)} // デッドロックで再実行されたら数え直す
+			for i, row := range unit {
+				created, errs, err := im.writeRow(ctx, tx, row)
+				if err != nil {
+					return err
+				}
+				switch {
+				case len(errs) > 0:
+					u.errors = append(u.errors, errs...)
+					u.failed++
+				case created:
+					u.inserted++
+				default:
+					u.updated++
+				}
+				if progress != nil {
+					progress(start + i + 1)
+				}
+			}
+			if im.dryRun || len(u.errors) > 0 {
+				return errImportRollback
+			}
+			return nil
+		})
+		if err != nil && !errors.Is(err, errImportRollback) {
+			return res, err
+		}
+		res.add(u)
+	}
+	return res, nil
+}
+
+// add counts the rows of a unit. On a dry run the rows without errors are
+// counted as written.
+func (res *ImportResult) add(u importUnit) {
+	res.ErrorCount += len(u.errors)
+	res.Errors = append(res.Errors, u.errors[:min(len(u.errors), maxImportErrors-len(res.Errors))]...)
+	if res.DryRun || len(u.errors) == 0 {
+		res.Inserted += u.inserted
+		res.Updated += u.updated
+		res.Skipped += u.failed
+		return
+	}
+	res.Skipped += u.rows
+}
+
+// writeRow inserts a row without an id and updates the row of the id
+// otherwise. It returns the errors of the row, or an error that stops the
+// import.
+func (im *importer) writeRow(ctx context.Context, tx Store, row importRow) (bool, []ImportError, error) {
+	values, id, version, errs := im.values(row)
+	created := id == 0
+	if len(errs) > 0 {
+		return created, errs, nil
+	}
+	errs, err := im.checkRefs(ctx, tx, row, values)
+	if err != nil || len(errs) > 0 {
+		return created, errs, err
+	}
+
+	if created {
+		err = im.create(ctx, tx, values)
+	} else {
+		err = im.update(ctx, tx, id, version, values)
+	}
+	if err != nil {
+		// 重複・参照整合性・他の利用者の更新などは行のエラーとして返す
+		status, message := errorStatus(err)
+		if status == http.StatusInternalServerError {
+			return created, nil, err
+		}
+		return created, []ImportError{{Row: row.Line, Message: message}}, nil
+	}
+	return created, nil, nil
+}
+
+func (im *importer) create(ctx context.Context, tx Store, values map[string]any) error {
+	id, err := tx.Masters().Create(ctx, im.t, values)
+	if err != nil {
+		return err
+	}
+	rec, err := findInScope(ctx, tx, im.t, id)
+	if err != nil {
+		return err
+	}
+	return recordAudit(ctx, tx, AuditCreate, im.t.Name, id, nil, rec)
+}
+
+// update writes values to the row. A version given in the file must be the
+// current one, like the If-Match header of the API.
+func (im *importer) update(ctx context.Context, tx Store, id int64, version *int64, values map[string]any) error {
+	ifMatch := IfMatch("*")
+	if version != nil {
+		ifMatch = etag(*version)
+	}
+	before, err := lockRecord(ctx, tx, im.t, id, &ifMatch)
+	if err != nil {
+		return err
+	}
+	if err := tx.Masters().Update(ctx, im.t, id, values); err != nil {
+		return err
+	}
+	rec, err := findInScope(ctx, tx, im.t, id)
+	if err != nil {
+		return err
+	}
+	return recordAudit(ctx, tx, AuditUpdate, im.t.Name, id, before, rec)
+}
+
+// values converts the cells of a row to the values written by the
+// [MasterRepository], with the id and version of the row (0 and nil when
+// blank).
+//
+// Blank cells are NULL, except for NOT NULL strings, which become empty,
+// and columns with a default on insert, which are left to the default.
+func (im *importer) values(row importRow) (map[string]any, int64, *int64, []ImportError) {
+	var errs []ImportError
+	fail := func(col, message string) {
+		errs = append(errs, ImportError{Row: row.Line, Column: &col, Message: message})
+	}
+	cell := func(name string) (string, bool) {
+		i, ok := im.index[name]
+		if !ok || i >= len(row.Cells) {
+			return "", ok
+		}
+		return row.Cells[i], true
+	}
+
+	var id int64
+	if s, _ := cell("id"); strings.TrimSpace(s) != "" {
+		n, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+		if err != nil || n < 1 {
+			fail("id", "must be a positive integer")
+		} else {
+			id = n
+		}
+	}
+	var version *int64
+	if s, _ := cell(versionColumn); strings.TrimSpace(s) != "" {
+		n, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+		if err != nil {
+			fail(versionColumn, "must be an integer")
+		}
+		version = &n
+	}
+	insert := id == 0
+
+	values := make(map[string]any, len(im.index))
+	for _, col := range im.t.Columns {
+		if col.AutoIncrement || col.Name == versionColumn {
+			continue
+		}
+		s, ok := cell(col.Name)
+		if !ok {
+			if insert && col.Required() {
+				fail(col.Name, "is required")
+			}
+			continue
+		}
+		v := im.value(col, s)
+		if v == nil && insert && col.Default != nil {
+			continue // 既定値を使う
+		}
+		cv, err := col.Convert(v)
+		if err != nil {
+			fail(col.Name, err.Error())
+			continue
+		}
+		values[col.Name] = cv
+	}
+	return values, id, version, errs
+}
+
+// importDateLayouts are the date formats accepted besides
+// [schema.DateLayout], as written by Excel in Japanese locales.
+var importDateLayouts = []string{"2006/1/2", "2006-1-2"}
+
+// importDateTimeLayouts are the date-time formats accepted besides those of
+// [schema.Column.Convert].
+var importDateTimeLayouts = []string{
+	"2006/1/2 15:04:05", "2006/1/2 15:04", "2006-1-2 15:04:05", "2006-1-2 15:04",
+}
+
+// value turns a cell into the JSON value expected by
+// [schema.Column.Convert], nil for a blank cell. Cells that cannot be read
+// are passed on as strings for Convert to report.
+func (im *importer) value(col *schema.Column, s string) any {
+	kind := col.Kind()
+	if kind != schema.String && kind != schema.Text {
+		s = strings.TrimSpace(s)
+	}
+	if s == "" {
+		if col.NotNull && (kind == schema.String || kind == schema.Text) {
+			return ""
+		}
+		return nil
+	}
+	switch kind {
+	case schema.Integer:
+		return json.Number(s)
+	case schema.Boolean:
+		switch strings.ToLower(s) {
+		case "1", "true":
+			return true
+		case "0", "false":
+			return false
+		}
+	case schema.Date:
+		if t, ok := im.serial(s); ok {
+			return t.Format(schema.DateLayout)
+		}
+		for _, layout := range importDateLayouts {
+			if t, err := time.Parse(layout, s); err == nil {
+				return t.Format(schema.DateLayout)
+			}
+		}
+	case schema.DateTime:
+		if t, ok := im.serial(s); ok {
+			return t.Format(schema.DateTimeLayout)
+		}
+		for _, layout := range importDateTimeLayouts {
+			if t, err := time.Parse(layout, s); err == nil {
+				return t.Format(schema.DateTimeLayout)
+			}
+		}
+	}
+	return s
+}
+
+// serial reads the serial number of an Excel date. The time is the wall
+// clock time of the cell.
+func (im *importer) serial(s string) (time.Time, bool) {
+	if !im.file.Serial {
+		return time.Time{}, false
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	t, err := excelize.ExcelDateToTime(f, im.file.Date1904)
+	return t, err == nil
+}
+
+// checkRefs checks that the foreign keys of a row refer to existing rows
+// within the caller's [Scope].
+func (im *importer) checkRefs(ctx context.Context, tx Store, row importRow, values map[string]any) ([]ImportError, error) {
+	var errs []ImportError
+	for _, col := range im.t.Columns {
+		v := values[col.Name]
+		if col.FK == nil || v == nil {
+			continue
+		}
+		key := importRef{col.FK.Table, col.FK.Column, fmt.Sprint(v)}
+		ok, cached := im.refs[key]
+		if !cached {
+			var err error
+			if ok, err = tx.Masters().Exists(ctx, col.FK.Table, col.FK.Column, v); err != nil {
+				return nil, err
+			}
+			if col.FK.Table != im.t.Name {
+				im.refs[key] = ok
+			}
+		}
+		if !ok {
+			name := col.Name
+			errs = append(errs, ImportError{Row: row.Line, Column: &name, Message: fmt.Sprintf("%v does not exist", v)})
+		}
+	}
+	return errs, nil
+}
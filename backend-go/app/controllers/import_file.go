@@ -0,0 +1,137 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/xuri/excelize/v2"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/transform"
+)
+
+// xlsxContentType is the media type of Excel workbooks.
+const xlsxContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+// importFile is an uploaded spreadsheet: the header row and the data rows.
+type importFile struct {
+	Header []string
+	Rows   []importRow
+	// Serial is set for Excel workbooks, whose dates are stored as serial
+	// numbers.
+	Serial bool
+	// Date1904 tells whether the serials count from 1904 instead of 1900.
+	Date1904 bool
+}
+
+// importRow is a data row of an [importFile].
+type importRow struct {
+	// Line is the row number in the file, the header being 1.
+	Line  int
+	Cells []string
+}
+
+// readImportFile parses a CSV file or an Excel workbook. The format follows
+// contentType; application/octet-stream is told apart by the content. Blank
+// rows are dropped.
+func readImportFile(data []byte, contentType, sheet string) (*importFile, error) {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType == xlsxContentType ||
+		mediaType != "text/csv" && bytes.HasPrefix(data, []byte("PK\x03\x04")) { // xlsx は zip
+		return readXLSX(data, sheet)
+	}
+	return readCSV(data)
+}
+
+// readCSV parses a CSV file in UTF-8, with or without a BOM, or in Shift_JIS
+// as saved by Excel.
+func readCSV(data []byte) (*importFile, error) {
+	data = bytes.TrimPrefix(data, []byte("\ufeff"))
+	var r io.Reader = bytes.NewReader(data)
+	if !utf8.Valid(data) {
+		// UTF-8 でなければ Excel が保存する Shift_JIS（CP932）とみなす
+		r = transform.NewReader(r, japanese.ShiftJIS.NewDecoder())
+	}
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1 // 末尾の空欄を省いた行も受け付ける
+
+	f := &importFile{}
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, &ValidationError{Message: "invalid CSV: " + err.Error()}
+		}
+		line, _ := cr.FieldPos(0)
+		f.add(line, record)
+	}
+	return f, f.check()
+}
+
+// readXLSX parses a sheet of an Excel workbook, the first one when sheet is
+// empty. Cells are read as stored, without the number format applied.
+func readXLSX(data []byte, sheet string) (*importFile, error) {
+	wb, err := excelize.OpenReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, &ValidationError{Message: "invalid Excel file: " + err.Error()}
+	}
+	defer wb.Close()
+
+	if sheet == "" {
+		if sheets := wb.GetSheetList(); len(sheets) > 0 {
+			sheet = sheets[0]
+		}
+	}
+	if idx, err := wb.GetSheetIndex(sheet); err != nil || idx < 0 {
+		return nil, &ValidationError{Message: fmt.Sprintf("sheet %q not found", sheet)}
+	}
+	rows, err := wb.GetRows(sheet, excelize.Options{RawCellValue: true})
+	if err != nil {
+		return nil, &ValidationError{Message: "invalid Excel file: " + err.Error()}
+	}
+	f := &importFile{Serial: true}
+	if props, err := wb.GetWorkbookProps(); err == nil && props.Date1904 != nil {
+		f.Date1904 = *props.Date1904
+	}
+	for i, row := range rows {
+		f.add(i+1, row)
+	}
+	return f, f.check()
+}
+
+// add appends a row read at line, the first non-blank one being the header.
+func (f *importFile) add(line int, cells []string) {
+	if !hasValue(cells) {
+		return
+	}
+	if f.Header == nil {
+		f.Header = cells
+		return
+	}
+	f.Rows = append(f.Rows, importRow{Line: line, Cells: cells})
+}
+
+// check rejects a file without a header.
+func (f *importFile) check() error {
+	if f.Header == nil {
+		return &ValidationError{Message: "the file has no header row"}
+	}
+	return nil
+}
+
+// hasValue reports whether some cell is not blank.
+func hasValue(cells []string) bool {
+	for _, c := range cells {
+		if strings.TrimSpace(c) != "" {
+			return true
+		}
+	}
+	return false
+}
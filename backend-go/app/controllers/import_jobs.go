@@ -0,0 +1,79 @@
+package controllers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+// importJobs holds the background imports of this process. Jobs are not
+// persisted: they are lost on restart, and a job still running then is
+// abandoned with the rows of its committed units written.
+//
+// The zero value is ready to use.
+type importJobs struct {
+	mu   sync.Mutex
+	jobs map[string]*importJobEntry
+}
+
+// importJobEntry is a job with the user who started it.
+type importJobEntry struct {
+	job    ImportJob
+	userID int64
+}
+
+// add registers a queued job and returns it.
+func (j *importJobs) add(table string, rows int, userID int64, ttl time.Duration) (ImportJob, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ImportJob{}, err
+	}
+	job := ImportJob{
+		ID:        hex.EncodeToString(b),
+		Table:     table,
+		Status:    ImportQueued,
+		TotalRows: rows,
+		CreatedAt: time.Now(),
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.expire(ttl)
+	if j.jobs == nil {
+		j.jobs = map[string]*importJobEntry{}
+	}
+	j.jobs[job.ID] = &importJobEntry{job: job, userID: userID}
+	return job, nil
+}
+
+// get returns a job started by the user.
+func (j *importJobs) get(id string, userID int64, ttl time.Duration) (ImportJob, bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.expire(ttl)
+	e, ok := j.jobs[id]
+	if !ok || e.userID != userID {
+		return ImportJob{}, false
+	}
+	return e.job, true
+}
+
+// update changes a job with fn.
+func (j *importJobs) update(id string, fn func(*ImportJob)) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if e, ok := j.jobs[id]; ok {
+		fn(&e.job)
+	}
+}
+
+// expire drops the jobs finished more than ttl ago. j.mu must be held.
+func (j *importJobs) expire(ttl time.Duration) {
+	cutoff := time.Now().Add(-ttl)
+	for id, e := range j.jobs {
+		if e.job.FinishedAt != nil && e.job.FinishedAt.Before(cutoff) {
+			delete(j.jobs, id)
+		}
+	}
+}
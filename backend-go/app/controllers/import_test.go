@@ -0,0 +1,262 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+	"golang.org/x/text/encoding/japanese"
+)
+
+// 共通ヘルパー: ファイルをアップロードするリクエスト
+func newImportRequest(contentType string, data []byte) (*http.Request, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest("POST", "/masters/x/import", bytes.NewReader(data))
+	req.Header.Set("Content-Type", contentType)
+	return withAuth(req, 7, 10), httptest.NewRecorder()
+}
+
+func newImportController(t *testing.T) *ImportController {
+	return &ImportController{
+		Store:     newSeededStore(),
+		Tables:    loadMasterTables(t),
+		AsyncRows: 100,
+		ChunkSize: 1,
+		JobTTL:    time.Hour,
+	}
+}
+
+func countRecords(t *testing.T, c *ImportController, table string) int {
+	t.Helper()
+	_, total, err := c.Store.Masters().List(t.Context(), c.Tables[table], MasterFilter{Limit: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return total
+}
+
+func firstRecord(t *testing.T, c *ImportController, table string) MasterRecord {
+	t.Helper()
+	recs, _, err := c.Store.Masters().List(t.Context(), c.Tables[table], MasterFilter{Limit: 1})
+	if err != nil || len(recs) == 0 {
+		t.Fatalf("no records in %s: %v", table, err)
+	}
+	return recs[0]
+}
+
+func importResult(t *testing.T, w *httptest.ResponseRecorder) ImportResult {
+	t.Helper()
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var res ImportResult
+	json.NewDecoder(w.Body).Decode(&res)
+	return res
+}
+
+// 論理名の見出し・BOM 付き UTF-8・3行目の日付が不正
+const taxRatesCSV = "\ufeffコード,税率,有効開始日,備考\n" +
+	"10,10.00,2019/10/1,10%\n" +
+	"\n" +
+	"08,8.00,2019/13/1,軽減税率\n"
+
+func TestImport_CommitModes(t *testing.T) {
+	c := newImportController(t)
+	const table = "consumption_tax_rates_master"
+
+	cases := []struct {
+		name    string
+		params  ImportMasterRecordsParams
+		want    ImportResult
+		written int
+	}{
+		{"dry run", ImportMasterRecordsParams{DryRun: ptr(true)}, ImportResult{DryRun: true, Inserted: 1, Skipped: 1}, 0},
+		{"all", ImportMasterRecordsParams{}, ImportResult{Skipped: 2}, 0},
+		{"chunk", ImportMasterRecordsParams{Commit: ptr(ImportCommitChunk)}, ImportResult{Inserted: 1, Skipped: 1}, 1},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, w := newImportRequest("text/csv", []byte(taxRatesCSV))
+			c.ImportMasterRecords(w, req, table, tc.params)
+			res := importResult(t, w)
+			if res.DryRun != tc.want.DryRun || res.TotalRows != 2 || res.Inserted != tc.want.Inserted ||
+				res.Updated != 0 || res.Skipped != tc.want.Skipped || res.ErrorCount != 1 {
+				t.Errorf("unexpected result %+v", res)
+			}
+			// 空行も数えた行番号
+			if e := res.Errors[0]; e.Row != 4 || e.Column == nil || *e.Column != "effective_start_date" {
+				t.Errorf("unexpected error %+v", e)
+			}
+			if n := countRecords(t, c, table); n != tc.written {
+				t.Errorf("Expected %d rows, got %d", tc.written, n)
+			}
+		})
+	}
+
+	// 登録した行は監査ログに残る
+	logs, total, _ := c.Store.Audit().List(t.Context(), AuditFilter{Table: table, Limit: 10})
+	if total != 1 || logs[0].Action != AuditCreate || *logs[0].UserID != 7 {
+		t.Errorf("unexpected audit %+v", logs)
+	}
+}
+
+func TestImport_Update(t *testing.T) {
+	c := newImportController(t)
+	req, w := newImportRequest("text/csv", []byte("name\n請求書\n"))
+	c.ImportMasterRecords(w, req, "kinds_master", ImportMasterRecordsParams{})
+	importResult(t, w)
+
+	id := fmt.Sprint(firstRecord(t, c, "kinds_master")["id"])
+
+	// version が現在と異なる行はエラー、存在しない id もエラー
+	csv := "id,version,name\n" + id + ",1,請求書（控）\n" + id + ",1,納品書\n999,,x\n,,領収書\n"
+	req, w = newImportRequest("text/csv", []byte(csv))
+	c.ImportMasterRecords(w, req, "kinds_master", ImportMasterRecordsParams{Commit: ptr(ImportCommitChunk)})
+	res := importResult(t, w)
+	if res.Updated != 1 || res.Inserted != 1 || res.Skipped != 2 || res.ErrorCount != 2 ||
+		res.Errors[0].Row != 3 || res.Errors[0].Column != nil || res.Errors[1].Row != 4 {
+		t.Errorf("unexpected result %+v", res)
+	}
+	if rec := firstRecord(t, c, "kinds_master"); rec["name"] != "請求書（控）" || rec[versionColumn] != int64(2) {
+		t.Errorf("unexpected record %v", rec)
+	}
+}
+
+func TestImport_Formats(t *testing.T) {
+	sjis, err := japanese.ShiftJIS.NewEncoder().Bytes([]byte("種別名\n納品書\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wb := excelize.NewFile()
+	wb.SetSheetRow("Sheet1", "A1", &[]any{"code", "tax_rate", "effective_start_date"})
+	wb.SetSheetRow("Sheet1", "A2", &[]any{"10", 10, time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC)})
+	var xlsx bytes.Buffer
+	if err := wb.Write(&xlsx); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name        string
+		table       string
+		contentType string
+		data        []byte
+		column      string
+		want        string
+	}{
+		{"shift_jis", "kinds_master", "text/csv", sjis, "name", "納品書"},
+		{"xlsx", "consumption_tax_rates_master", xlsxContentType, xlsx.Bytes(), "effective_start_date", "2019-10-01"},
+		{"octet-stream", "consumption_tax_rates_master", "application/octet-stream", xlsx.Bytes(), "tax_rate", "10"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := newImportController(t)
+			req, w := newImportRequest(tc.contentType, tc.data)
+			c.ImportMasterRecords(w, req, tc.table, ImportMasterRecordsParams{})
+			if res := importResult(t, w); res.Inserted != 1 {
+				t.Fatalf("unexpected result %+v", res)
+			}
+			if rec := firstRecord(t, c, tc.table); fmt.Sprint(rec[tc.column]) != tc.want {
+				t.Errorf("unexpected record %v", rec)
+			}
+		})
+	}
+}
+
+func TestImport_Header(t *testing.T) {
+	cases := []struct {
+		name string
+		csv  string
+		want int
+	}{
+		{"unknown", "name,foo\na,b\n", http.StatusUnprocessableEntity},
+		{"duplicate", "name,種別名\na,b\n", http.StatusUnprocessableEntity},
+		{"required", "version\n1\n", http.StatusUnprocessableEntity},
+		{"empty", "\n\n", http.StatusUnprocessableEntity},
+		{"broken", "name\n\"a\n", http.StatusUnprocessableEntity},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := newImportController(t)
+			req, w := newImportRequest("text/csv", []byte(tc.csv))
+			c.ImportMasterRecords(w, req, "kinds_master", ImportMasterRecordsParams{})
+			if w.Code != tc.want {
+				t.Errorf("Expected %d, got %d: %s", tc.want, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestImport_Scoped(t *testing.T) {
+	c := newImportController(t)
+	// 自分の部門を他荷主に移すことはできない
+	csv := "id,shipping_id\n2,2\n"
+	req, w := newImportRequest("text/csv", []byte(csv))
+	c.ImportMasterRecords(w, withShipper(req, 1), "departments_master", ImportMasterRecordsParams{})
+	res := importResult(t, w)
+	if res.ErrorCount != 1 || *res.Errors[0].Column != "shipping_id" {
+		t.Errorf("unexpected result %+v", res)
+	}
+}
+
+func TestImport_Job(t *testing.T) {
+	c := newImportController(t)
+	c.AsyncRows = 1
+	req, w := newImportRequest("text/csv", []byte("name\n納品書\n請求書\n"))
+	c.ImportMasterRecords(w, req, "kinds_master", ImportMasterRecordsParams{})
+	if w.Code != http.StatusAccepted || !strings.HasPrefix(w.Header().Get("Location"), "/import-jobs/") {
+		t.Fatalf("Expected 202, got %d: %s", w.Code, w.Body.String())
+	}
+	var job ImportJob
+	json.NewDecoder(w.Body).Decode(&job)
+	if job.TotalRows != 2 || job.Table != "kinds_master" {
+		t.Errorf("unexpected job %+v", job)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for job.Status != ImportSucceeded && job.Status != ImportFailed {
+		if time.Now().After(deadline) {
+			t.Fatal("the job did not finish")
+		}
+		time.Sleep(10 * time.Millisecond)
+		req, w := newJSONRequest("GET", "/import-jobs/"+job.ID, nil)
+		c.GetImportJob(w, withAuth(req, 7, 10), job.ID)
+		json.NewDecoder(w.Body).Decode(&job)
+	}
+	if job.Status != ImportSucceeded || job.ProcessedRows != 2 || job.Result == nil ||
+		job.Result.Inserted != 2 || job.FinishedAt == nil {
+		t.Errorf("unexpected job %+v", job)
+	}
+
+	// 他のユーザには見えない
+	req, w = newJSONRequest("GET", "/import-jobs/"+job.ID, nil)
+	c.GetImportJob(w, withAuth(req, 8, 11), job.ID)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404, got %d", w.Code)
+	}
+}
+
+func TestLimitRequestBody(t *testing.T) {
+	h := LimitRequestBody(4)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var buf bytes.Buffer
+		if _, err := buf.ReadFrom(r.Body); err != nil {
+			writeError(w, err)
+		}
+	}))
+	for _, chunked := range []bool{false, true} {
+		req := httptest.NewRequest("POST", "/", strings.NewReader("12345"))
+		if chunked {
+			req.ContentLength = -1
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		if w.Code != http.StatusRequestEntityTooLarge {
+			t.Errorf("chunked=%v: Expected 413, got %d", chunked, w.Code)
+		}
+	}
+}
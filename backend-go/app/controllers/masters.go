@@ -0,0 +1,528 @@
+package controllers
+
+import (
+	"backend-go/schema"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// masterTables lists the tables served by /masters/{table} with the columns
+// exposed for each; nil exposes every column. Binary columns are never
+// exposed, and id and version are always.
+//
+// users_master has its own API and permissions_master follows the
+// x-permission values of the spec, so neither is listed.
+var masterTables = map[string][]string{
+	"account_types_master":           nil,
+	"approval_flows_master":          nil,
+	"billing_days_master":            nil,
+	"billing_months_master":          nil,
+	"billings_master":                nil,
+	"business_calendars_master":      nil,
+	"closing_dates_master":           nil,
+	"collaborations_master":          nil,
+	"consumption_tax_rates_master":   nil,
+	"consumption_tax_shows_master":   nil,
+	"customers_info_master":          nil,
+	"customers_master":               nil,
+	"delivery_companys_master":       nil,
+	"departments_master":             nil,
+	"entry_and_exit_fees_master":     nil,
+	"external_collaborations_master": nil,
+	"fare_aggregations_master":       nil,
+	"groups_master":                  nil,
+	"items_master":                   nil,
+	"kinds_master":                   nil,
+	"locations_master":               nil,
+	"mobile_devices_master":          nil,
+	"order_deadlines_master":         nil,
+	"packing_sizes_master":           nil,
+	"product_categories_master":      nil,
+	"product_units_master":           nil,
+	"return_and_repair_units_master": nil,
+	"roundings_master":               nil,
+	"services_useds_master":          nil,
+	"set_items_master":               nil,
+	"set_items_product_units_master": nil,
+	"shipping_fees_master":           nil,
+	"shippings_master":               nil,
+	"stores_master":                  nil,
+	"system_mail_settings_master":    nil,
+}
+
+// writePermissions maps the tables whose rows apply to every user, such as
+// the groups and the system mails, to the permission required to write
+// them on top of masters:write, including by import.
+var writePermissions = map[string]string{
+	"groups_master":               managePermission,
+	"system_mail_settings_master": managePermission,
+}
+
+// versionColumn is the row version of every master table, incremented by
+// [MasterRepository.Update] and sent as the ETag of the row.
+const versionColumn = "version"
+
+// identifierPattern matches the table and column names that may be put in
+// SQL text. Names come from schema.yaml, never from the request.
+var identifierPattern = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// MasterTable is a table served by the master maintenance API.
+type MasterTable struct {
+	Name string
+	// Label is the Japanese name of the table.
+	Label string
+	// NameColumn is the column shown for a row referenced by a foreign key:
+	// name, else code. Empty shows the id.
+	NameColumn string
+	// Columns are the exposed columns in schema.yaml order, starting with
+	// the primary key id.
+	Columns []*schema.Column
+	// Filled are the NOT NULL columns without a default that are not
+	// exposed. Inserted rows get the zero value of their type.
+	Filled []*schema.Column
+	// Unique are the columns of the unique indexes.
+	Unique [][]string
+	// WritePermission is required to change the rows in addition to the
+	// x-permission of the operation, or is empty.
+	WritePermission string
+}
+
+// Column returns the exposed column with the name.
+func (t *MasterTable) Column(name string) (*schema.Column, bool) {
+	for _, c := range t.Columns {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return nil, false
+}
+
+// NewMasterTables builds the tables of masterTables from their definitions
+// in s. It fails when a listed table or column is missing from s, or a
+// table lacks an auto-increment id.
+func NewMasterTables(s *schema.Schema) (map[string]*MasterTable, error) {
+	tables := make(map[string]*MasterTable, len(masterTables))
+	for _, name := range slices.Sorted(maps.Keys(masterTables)) {
+		def, ok := s.Table(name)
+		if !ok {
+			return nil, fmt.Errorf("master table %s is not defined in the schema", name)
+		}
+		if id, ok := def.Column("id"); !ok || !id.PK || !id.AutoIncrement {
+			return nil, fmt.Errorf("master table %s has no auto-increment id", name)
+		}
+		if v, ok := def.Column(versionColumn); !ok || v.Kind() != schema.Integer || !v.NotNull || v.Default == nil {
+			return nil, fmt.Errorf("master table %s has no version column", name)
+		}
+		exposed := masterTables[name]
+		for _, col := range exposed {
+			c, ok := def.Column(col)
+			if !ok {
+				return nil, fmt.Errorf("master table %s has no column %s", name, col)
+			}
+			if c.Kind() == schema.Binary {
+				return nil, fmt.Errorf("master table %s: binary column %s cannot be exposed", name, col)
+			}
+		}
+
+		t := &MasterTable{Name: name, Label: def.Comment, WritePermission: writePermissions[name]}
+		for i := range def.Columns {
+			c := &def.Columns[i]
+			if !identifierPattern.MatchString(c.Name) ||
+				c.FK != nil && !(identifierPattern.MatchString(c.FK.Table) && identifierPattern.MatchString(c.FK.Column)) {
+				return nil, fmt.Errorf("master table %s: column %q cannot be used", name, c.Name)
+			}
+			switch {
+			case c.Name == "id" || c.Name == versionColumn ||
+				c.Kind() != schema.Binary && (exposed == nil || slices.Contains(exposed, c.Name)):
+				t.Columns = append(t.Columns, c)
+			case c.Required():
+				t.Filled = append(t.Filled, c)
+			}
+		}
+		for _, col := range []string{"name", "code"} {
+			if c, ok := t.Column(col); ok && c.Kind() == schema.String {
+				t.NameColumn = col
+				break
+			}
+		}
+		for _, idx := range def.Indexes {
+			if idx.Unique {
+				t.Unique = append(t.Unique, idx.Columns)
+			}
+		}
+		tables[name] = t
+	}
+	return tables, nil
+}
+
+// MastersController serves the generic maintenance API of the tables in
+// masterTables. Requests are validated against the column definitions of
+// schema.yaml, so no code is written per table.
+//
+// Shipper-side callers only see the rows of their own shipper in the
+// tables of scopedTables, and can neither reference other shippers' rows
+// nor write rows that would leave their scope.
+type MastersController struct {
+	Store  Store
+	Tables map[string]*MasterTable
+}
+
+// table returns the served table with the name, or a [NotFoundError].
+func (c *MastersController) table(name string) (*MasterTable, error) {
+	return lookupTable(c.Tables, name)
+}
+
+// lookupTable returns the table of tables with the name, or a
+// [NotFoundError].
+func lookupTable(tables map[string]*MasterTable, name string) (*MasterTable, error) {
+	t, ok := tables[name]
+	if !ok {
+		return nil, &NotFoundError{Resource: "table", ID: name}
+	}
+	return t, nil
+}
+
+// writableTable returns the served table with the name, or a
+// [ForbiddenError] when the caller may not write it.
+func (c *MastersController) writableTable(r *http.Request, name string) (*MasterTable, error) {
+	t, err := c.table(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeWrite(r, c.Store, t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// authorizeWrite checks the [MasterTable.WritePermission] of t against the
+// group of the logged-in user.
+func authorizeWrite(r *http.Request, store Store, t *MasterTable) error {
+	if t.WritePermission == "" {
+		return nil
+	}
+	au, err := currentUser(r)
+	if err != nil {
+		return err
+	}
+	granted, err := store.Permissions().Has(r.Context(), au.GroupID, t.WritePermission)
+	if err != nil {
+		return err
+	}
+	if !granted {
+		return &ForbiddenError{Permission: t.WritePermission}
+	}
+	return nil
+}
+
+// ListMasterRecords returns a page of rows as a [MasterRecordsResponseGet],
+// ordered by id or by the column given in sort ("-" for descending).
+func (c *MastersController) ListMasterRecords(w http.ResponseWriter, r *http.Request, table MasterTableName, params ListMasterRecordsParams) {
+	t, err := c.table(table)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	limit, offset := pageOf(params.Limit, params.Offset)
+	f := MasterFilter{Limit: limit, Offset: offset}
+	if params.Sort != nil {
+		f.Sort, f.Desc = strings.CutPrefix(*params.Sort, "-")
+		if _, ok := t.Column(f.Sort); !ok {
+			writeError(w, &BadRequestError{Err: fmt.Errorf("unknown sort %q", *params.Sort)})
+			return
+		}
+	}
+	records, total, err := c.Store.Masters().List(r.Context(), t, f)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, MasterRecordsResponseGet{
+		Records: records,
+		Total:   total,
+		Limit:   limit,
+		Offset:  offset,
+	})
+}
+
+// GetMasterRecord returns one row.
+func (c *MastersController) GetMasterRecord(w http.ResponseWriter, r *http.Request, table MasterTableName, id ResourceID) {
+	t, err := c.table(table)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	rec, err := c.Store.Masters().Find(r.Context(), t, id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	setETag(w, recordVersion(rec))
+	writeJSON(w, http.StatusOK, rec)
+}
+
+// CreateMasterRecord inserts a row from a [MasterRecord] body and returns it
+// with 201 Created. Omitted columns get their default value.
+func (c *MastersController) CreateMasterRecord(w http.ResponseWriter, r *http.Request, table MasterTableName) {
+	t, err := c.writableTable(r, table)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	values, err := c.decodeValues(r, t, false)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var rec MasterRecord
+	err = c.Store.WithTx(r.Context(), func(tx Store) error {
+		id, err := tx.Masters().Create(r.Context(), t, values)
+		if err != nil {
+			return err
+		}
+		if rec, err = findInScope(r.Context(), tx, t, id); err != nil {
+			return err
+		}
+		return recordAudit(r.Context(), tx, AuditCreate, t.Name, id, nil, rec)
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	setETag(w, recordVersion(rec))
+	writeJSON(w, http.StatusCreated, rec)
+}
+
+// UpdateMasterRecord replaces the exposed columns of a row with a
+// [MasterRecord] body. Omitted columns are set to NULL.
+//
+// The If-Match header must carry the current ETag of the row.
+func (c *MastersController) UpdateMasterRecord(w http.ResponseWriter, r *http.Request, table MasterTableName, id ResourceID, params UpdateMasterRecordParams) {
+	t, err := c.writableTable(r, table)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := requireIfMatch(params.IfMatch); err != nil {
+		writeError(w, err)
+		return
+	}
+	values, err := c.decodeValues(r, t, true)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var rec MasterRecord
+	err = c.Store.WithTx(r.Context(), func(tx Store) error {
+		before, err := lockRecord(r.Context(), tx, t, id, params.IfMatch)
+		if err != nil {
+			return err
+		}
+		if err := tx.Masters().Update(r.Context(), t, id, values); err != nil {
+			return err
+		}
+		if rec, err = findInScope(r.Context(), tx, t, id); err != nil {
+			return err
+		}
+		return recordAudit(r.Context(), tx, AuditUpdate, t.Name, id, before, rec)
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	setETag(w, recordVersion(rec))
+	writeJSON(w, http.StatusOK, rec)
+}
+
+// DeleteMasterRecord deletes a row. Rows referenced by other tables are
+// kept and reported with 409 by [writeError].
+//
+// The If-Match header must carry the current ETag of the row.
+func (c *MastersController) DeleteMasterRecord(w http.ResponseWriter, r *http.Request, table MasterTableName, id ResourceID, params DeleteMasterRecordParams) {
+	t, err := c.writableTable(r, table)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := requireIfMatch(params.IfMatch); err != nil {
+		writeError(w, err)
+		return
+	}
+	err = c.Store.WithTx(r.Context(), func(tx Store) error {
+		before, err := lockRecord(r.Context(), tx, t, id, params.IfMatch)
+		if err != nil {
+			return err
+		}
+		if err := tx.Masters().Delete(r.Context(), t, id); err != nil {
+			return err
+		}
+		return recordAudit(r.Context(), tx, AuditDelete, t.Name, id, before, nil)
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// lockRecord locks a row for the transaction, checks its version against
+// the If-Match header and returns it as it is before the change.
+func lockRecord(ctx context.Context, tx Store, t *MasterTable, id int64, ifMatch *IfMatch) (MasterRecord, error) {
+	version, err := tx.Masters().Lock(ctx, t, id)
+	if err != nil {
+		return nil, err
+	}
+	rec, err := tx.Masters().Find(ctx, t, id)
+	if err != nil {
+		return nil, err
+	}
+	return rec, checkVersion(ifMatch, version, func() (any, error) {
+		return rec, nil
+	})
+}
+
+// recordVersion returns the version of a row read by the
+// [MasterRepository].
+func recordVersion(rec MasterRecord) int64 {
+	v, _ := rec[versionColumn].(int64)
+	return v
+}
+
+// GetTableMeta returns the column definitions of a table as a [TableMeta],
+// so that the list and edit screens need no code per table. Foreign keys to
+// served tables come with the rows they can refer to.
+func (c *MastersController) GetTableMeta(w http.ResponseWriter, r *http.Request, table MasterTableName) {
+	t, err := c.table(table)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	meta := TableMeta{Name: t.Name, Label: t.Label, Columns: make([]ColumnMeta, len(t.Columns))}
+	for i, col := range t.Columns {
+		cm := ColumnMeta{
+			Name:      col.Name,
+			Label:     col.Comment,
+			Type:      col.Type,
+			InputType: inputTypes[col.Kind()],
+			Required:  col.Required(),
+			ReadOnly:  col.AutoIncrement || col.Name == versionColumn,
+			Default:   col.DefaultValue(),
+		}
+		length, scale := col.Length(), col.Scale()
+		switch col.Kind() {
+		case schema.String:
+			cm.MaxLength = &length
+		case schema.Decimal:
+			cm.Precision, cm.Scale = &length, &scale
+		}
+		// 参照先が公開されていれば選択肢を付ける（他荷主の行は含まない）
+		if ref, ok := c.Tables[fkTable(col)]; ok {
+			options, err := c.Store.Masters().Options(r.Context(), ref)
+			if err != nil {
+				writeError(w, err)
+				return
+			}
+			cm.InputType, cm.Options = InputSelect, &options
+		}
+		meta.Columns[i] = cm
+	}
+	writeJSON(w, http.StatusOK, meta)
+}
+
+// inputTypes maps column kinds to the input of their value.
+var inputTypes = map[schema.Kind]ColumnMetaInputType{
+	schema.Integer:  InputNumber,
+	schema.Decimal:  InputDecimal,
+	schema.String:   InputText,
+	schema.Text:     InputTextarea,
+	schema.Boolean:  InputCheckbox,
+	schema.Date:     InputDate,
+	schema.DateTime: InputDatetime,
+}
+
+// fkTable returns the table referenced by the id of a foreign key, or "".
+func fkTable(col *schema.Column) string {
+	if col.FK == nil || col.FK.Column != "id" {
+		return ""
+	}
+	return col.FK.Table
+}
+
+// findInScope reads back a row just written. A row that the caller's
+// [Scope] no longer covers, such as a department moved to another shipper,
+// is rejected so that the transaction is rolled back.
+func findInScope(ctx context.Context, tx Store, t *MasterTable, id int64) (MasterRecord, error) {
+	rec, err := tx.Masters().Find(ctx, t, id)
+	var notFound *NotFoundError
+	if errors.As(err, &notFound) {
+		return nil, &ValidationError{Message: "the record would be outside of your shipper"}
+	}
+	return rec, err
+}
+
+// decodeValues reads a [MasterRecord] body and converts it to the values
+// written by the [MasterRepository].
+//
+// Unknown and unexposed columns are rejected; id and version are ignored. With replace
+// set, every NOT NULL column is required and omitted columns are set to
+// NULL; otherwise only the columns without a default are required. Foreign
+// keys must refer to existing rows within the caller's [Scope].
+func (c *MastersController) decodeValues(r *http.Request, t *MasterTable, replace bool) (map[string]any, error) {
+	var body MasterRecord
+	dec := json.NewDecoder(r.Body)
+	dec.UseNumber() // 桁数を検証できるよう数値は文字列のまま受け取る
+	if err := dec.Decode(&body); err != nil {
+		return nil, &BadRequestError{Err: err}
+	}
+
+	values := make(map[string]any, len(t.Columns))
+	for _, name := range slices.Sorted(maps.Keys(body)) {
+		col, ok := t.Column(name)
+		if !ok {
+			return nil, &ValidationError{Message: fmt.Sprintf("unknown column %q", name)}
+		}
+		if col.AutoIncrement || name == versionColumn {
+			continue // id は自動採番（更新時はパスの id）、version は更新時に加算する
+		}
+		v, err := col.Convert(body[name])
+		if err != nil {
+			return nil, &ValidationError{Message: fmt.Sprintf("%s %v", name, err)}
+		}
+		values[name] = v
+	}
+
+	for _, col := range t.Columns {
+		if _, ok := values[col.Name]; ok || col.AutoIncrement || col.Name == versionColumn {
+			continue
+		}
+		if replace && col.NotNull || !replace && col.Required() {
+			return nil, &ValidationError{Message: col.Name + " is required"}
+		}
+		if replace {
+			values[col.Name] = nil
+		}
+	}
+
+	// 参照先の存在を確認する（他荷主のデータは存在しないものとして扱う）
+	for _, col := range t.Columns {
+		v := values[col.Name]
+		if col.FK == nil || v == nil {
+			continue
+		}
+		ok, err := c.Store.Masters().Exists(r.Context(), col.FK.Table, col.FK.Column, v)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, &ValidationError{Message: fmt.Sprintf("%s %v does not exist", col.Name, v)}
+		}
+	}
+	return values, nil
+}
@@ -0,0 +1,235 @@
+package controllers
+
+import (
+	"backend-go/schema"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// mysqlMasters is the [MasterRepository] of [MySQLStore].
+//
+// Table and column names are quoted but put in the SQL text as they are;
+// they come from schema.yaml and are checked by [NewMasterTables].
+type mysqlMasters struct {
+	s *MySQLStore
+}
+
+func (r mysqlMasters) List(ctx context.Context, t *MasterTable, f MasterFilter) ([]MasterRecord, int, error) {
+	where, args := scopeOf(ctx).where(t.Name, "", nil, nil)
+	var total int
+	if err := r.s.q.QueryRowContext(ctx, "SELECT COUNT(*) FROM "+quote(t.Name)+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	dir := ""
+	if f.Desc {
+		dir = " DESC"
+	}
+	orderBy := "`id`" + dir
+	if f.Sort != "" && f.Sort != "id" {
+		orderBy = quote(f.Sort) + dir + ", `id`"
+	}
+	rows, err := r.s.q.QueryContext(ctx,
+		masterSelect(t)+where+" ORDER BY "+orderBy+" LIMIT ? OFFSET ?",
+		append(args, f.Limit, f.Offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	records := []MasterRecord{}
+	for rows.Next() {
+		rec, err := scanMaster(rows, t)
+		if err != nil {
+			return nil, 0, err
+		}
+		records = append(records, rec)
+	}
+	return records, total, rows.Err()
+}
+
+func (r mysqlMasters) Find(ctx context.Context, t *MasterTable, id int64) (MasterRecord, error) {
+	where, args := scopeOf(ctx).where(t.Name, "", []string{"`id` = ?"}, []any{id})
+	rec, err := scanMaster(r.s.q.QueryRowContext(ctx, masterSelect(t)+where, args...), t)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, &NotFoundError{Resource: t.Name, ID: id}
+	}
+	return rec, err
+}
+
+func (r mysqlMasters) Create(ctx context.Context, t *MasterTable, values map[string]any) (int64, error) {
+	var cols []string
+	var args []any
+	for _, c := range t.Columns {
+		if v, ok := values[c.Name]; ok {
+			cols = append(cols, quote(c.Name))
+			args = append(args, v)
+		}
+	}
+	// 公開していない NOT NULL 列（バイナリなど）は型の初期値で埋める
+	for _, c := range t.Filled {
+		cols = append(cols, quote(c.Name))
+		args = append(args, zeroValue(c))
+	}
+	query := "INSERT INTO " + quote(t.Name) + " () VALUES ()"
+	if len(cols) > 0 {
+		query = "INSERT INTO " + quote(t.Name) + " (" + strings.Join(cols, ", ") + ") VALUES (" +
+			strings.TrimSuffix(strings.Repeat("?, ", len(cols)), ", ") + ")"
+	}
+	res, err := r.s.q.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+func (r mysqlMasters) Lock(ctx context.Context, t *MasterTable, id int64) (int64, error) {
+	where, args := scopeOf(ctx).where(t.Name, "", []string{"`id` = ?"}, []any{id})
+	var version int64
+	err := r.s.q.QueryRowContext(ctx, "SELECT `version` FROM "+quote(t.Name)+where+" FOR UPDATE", args...).Scan(&version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, &NotFoundError{Resource: t.Name, ID: id}
+	}
+	return version, err
+}
+
+func (r mysqlMasters) Update(ctx context.Context, t *MasterTable, id int64, values map[string]any) error {
+	set := []string{"`version` = `version` + 1"}
+	var args []any
+	for _, c := range t.Columns {
+		if v, ok := values[c.Name]; ok {
+			set = append(set, quote(c.Name)+" = ?")
+			args = append(args, v)
+		}
+	}
+	where, args := scopeOf(ctx).where(t.Name, "", []string{"`id` = ?"}, append(args, id))
+	res, err := r.s.q.ExecContext(ctx, "UPDATE "+quote(t.Name)+" SET "+strings.Join(set, ", ")+where, args...)
+	if err != nil {
+		return err
+	}
+	return requireAffected(res, t.Name, id)
+}
+
+func (r mysqlMasters) Delete(ctx context.Context, t *MasterTable, id int64) error {
+	where, args := scopeOf(ctx).where(t.Name, "", []string{"`id` = ?"}, []any{id})
+	res, err := r.s.q.ExecContext(ctx, "DELETE FROM "+quote(t.Name)+where, args...)
+	if err != nil {
+		return err
+	}
+	return requireAffected(res, t.Name, id)
+}
+
+func (r mysqlMasters) Exists(ctx context.Context, table, column string, value any) (bool, error) {
+	where, args := scopeOf(ctx).where(table, "", []string{quote(column) + " = ?"}, []any{value})
+	return r.s.exists(ctx, "SELECT 1 FROM "+quote(table)+where, args...)
+}
+
+func (r mysqlMasters) Options(ctx context.Context, t *MasterTable) ([]ColumnOption, error) {
+	name := "CAST(`id` AS CHAR)"
+	if t.NameColumn != "" {
+		name = "COALESCE(" + quote(t.NameColumn) + ", '')"
+	}
+	where, args := scopeOf(ctx).where(t.Name, "", nil, nil)
+	rows, err := r.s.q.QueryContext(ctx,
+		"SELECT `id`, "+name+" FROM "+quote(t.Name)+where+" ORDER BY "+name+", `id`", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	options := []ColumnOption{}
+	for rows.Next() {
+		var o ColumnOption
+		if err := rows.Scan(&o.ID, &o.Name); err != nil {
+			return nil, err
+		}
+		options = append(options, o)
+	}
+	return options, rows.Err()
+}
+
+// quote quotes a table or column name. Some columns are named after
+// reserved words, such as order.
+func quote(name string) string {
+	return "`" + name + "`"
+}
+
+// masterSelect selects the exposed columns of t, in the order scanned by
+// [scanMaster].
+func masterSelect(t *MasterTable) string {
+	cols := make([]string, len(t.Columns))
+	for i, c := range t.Columns {
+		cols[i] = quote(c.Name)
+	}
+	return "SELECT " + strings.Join(cols, ", ") + " FROM " + quote(t.Name)
+}
+
+// scanMaster reads a row selected by [masterSelect].
+func scanMaster(s rowScanner, t *MasterTable) (MasterRecord, error) {
+	dest := make([]any, len(t.Columns))
+	for i, c := range t.Columns {
+		switch c.Kind() {
+		case schema.Integer:
+			dest[i] = new(sql.NullInt64)
+		case schema.Boolean:
+			dest[i] = new(sql.NullBool)
+		case schema.Date, schema.DateTime:
+			dest[i] = new(sql.NullTime)
+		default:
+			dest[i] = new(sql.NullString)
+		}
+	}
+	if err := s.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	rec := make(MasterRecord, len(t.Columns))
+	for i, c := range t.Columns {
+		var v any
+		switch d := dest[i].(type) {
+		case *sql.NullInt64:
+			if d.Valid {
+				v = d.Int64
+			}
+		case *sql.NullBool:
+			if d.Valid {
+				v = d.Bool
+			}
+		case *sql.NullTime:
+			if d.Valid && c.Kind() == schema.Date {
+				v = d.Time.Format(schema.DateLayout)
+			} else if d.Valid {
+				v = d.Time
+			}
+		case *sql.NullString:
+			if d.Valid && c.Kind() == schema.Decimal {
+				v = json.Number(d.String)
+			} else if d.Valid {
+				v = d.String
+			}
+		}
+		rec[c.Name] = v
+	}
+	return rec, nil
+}
+
+// zeroValue returns the value written to a NOT NULL column left out of an
+// insert.
+func zeroValue(c *schema.Column) any {
+	switch c.Kind() {
+	case schema.Integer, schema.Decimal:
+		return 0
+	case schema.Boolean:
+		return false
+	case schema.Date:
+		return "1000-01-01" // DATE の最小値
+	case schema.DateTime:
+		return "1970-01-01 09:00:01" // TIMESTAMP の最小値（Asia/Tokyo）
+	case schema.Binary:
+		return []byte{}
+	}
+	return ""
+}
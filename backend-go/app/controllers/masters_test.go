@@ -0,0 +1,173 @@
+package controllers
+
+import (
+	"backend-go/schema"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+// 共通ヘルパー: docs/schema.yaml から作ったマスタテーブル
+func loadMasterTables(t *testing.T) map[string]*MasterTable {
+	t.Helper()
+	s, err := schema.Load("../../docs/schema.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tables, err := NewMasterTables(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tables
+}
+
+func TestNewMasterTables(t *testing.T) {
+	tables := loadMasterTables(t)
+	items := tables["items_master"]
+	if _, ok := items.Column("photo_file_data"); ok {
+		t.Error("binary columns must not be exposed")
+	}
+	if _, ok := tables["users_master"]; ok {
+		t.Error("users_master must not be served")
+	}
+}
+
+func TestMastersController_CRUD(t *testing.T) {
+	ctrl := &MastersController{Store: newSeededStore(), Tables: loadMasterTables(t)}
+
+	req, w := newJSONRequest("POST", "/masters/kinds_master", map[string]any{"name": "納品書"})
+	ctrl.CreateMasterRecord(w, req, "kinds_master")
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var rec map[string]any
+	json.NewDecoder(w.Body).Decode(&rec)
+	id := int64(rec["id"].(float64))
+
+	req, w = newJSONRequest("PUT", "/masters/kinds_master", map[string]any{"name": "納品書（控）"})
+	ctrl.UpdateMasterRecord(w, req, "kinds_master", id, UpdateMasterRecordParams{IfMatch: ifMatchV1})
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	req, w = newJSONRequest("GET", "/masters/kinds_master", nil)
+	ctrl.ListMasterRecords(w, req, "kinds_master", ListMasterRecordsParams{Sort: ptr("-name")})
+	var list MasterRecordsResponseGet
+	json.NewDecoder(w.Body).Decode(&list)
+	if list.Total != 1 || list.Records[0]["name"] != "納品書（控）" {
+		t.Errorf("unexpected list: %+v", list)
+	}
+
+	req, w = newJSONRequest("DELETE", "/masters/kinds_master", nil)
+	ctrl.DeleteMasterRecord(w, req, "kinds_master", id, DeleteMasterRecordParams{IfMatch: ptr[IfMatch](`"2"`)})
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("Expected 204, got %d", w.Code)
+	}
+}
+
+func TestMastersController_Validation(t *testing.T) {
+	ctrl := &MastersController{Store: newSeededStore(), Tables: loadMasterTables(t)}
+	cases := []struct {
+		name  string
+		table string
+		body  map[string]any
+		want  int
+	}{
+		{"unknown table", "users_master", map[string]any{}, http.StatusNotFound},
+		{"unknown column", "kinds_master", map[string]any{"name": "a", "foo": 1}, http.StatusUnprocessableEntity},
+		{"required", "kinds_master", map[string]any{}, http.StatusUnprocessableEntity},
+		{"too long", "kinds_master", map[string]any{"name": string(make([]rune, 101))}, http.StatusUnprocessableEntity},
+		{"wrong type", "kinds_master", map[string]any{"name": 1}, http.StatusUnprocessableEntity},
+		{"missing fk", "departments_master", map[string]any{"name": "a", "shipping_id": 99}, http.StatusUnprocessableEntity},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, w := newJSONRequest("POST", "/masters/"+tc.table, tc.body)
+			ctrl.CreateMasterRecord(w, req, tc.table)
+			if w.Code != tc.want {
+				t.Errorf("Expected %d, got %d: %s", tc.want, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestMastersController_Scoped(t *testing.T) {
+	ctrl := &MastersController{Store: newSeededStore(), Tables: loadMasterTables(t)}
+
+	// 他荷主を参照先にはできない
+	req, w := newJSONRequest("POST", "/masters/departments_master", map[string]any{"name": "営業部", "shipping_id": 2})
+	ctrl.CreateMasterRecord(w, withShipper(req, 1), "departments_master")
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected 422, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// 他荷主の部門は見えない
+	req, w = newJSONRequest("GET", "/masters/departments_master", nil)
+	ctrl.GetMasterRecord(w, withShipper(req, 1), "departments_master", 3)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404, got %d", w.Code)
+	}
+}
+
+func TestMastersController_WritePermission(t *testing.T) {
+	store := newSeededStore()
+	store.AddPermission(Permission{ID: 100, Code: managePermission})
+	store.AddGroup(2, "荷主A担当")
+	store.Grant(1, managePermission)
+	ctrl := &MastersController{Store: store, Tables: loadMasterTables(t)}
+
+	// masters:write だけのグループはグループ・システムメールを変更できない
+	for _, table := range []string{"groups_master", "system_mail_settings_master"} {
+		req, w := newJSONRequest("POST", "/masters/"+table, map[string]any{"name": "管理者2"})
+		ctrl.CreateMasterRecord(w, withGroup(req, 2), table)
+		if w.Code != http.StatusForbidden {
+			t.Errorf("%s: expected 403, got %d", table, w.Code)
+		}
+	}
+	req, w := newJSONRequest("PUT", "/masters/groups_master", map[string]any{"name": "乗っ取り"})
+	ctrl.UpdateMasterRecord(w, withShipper(withGroup(req, 2), 1), "groups_master", 1, UpdateMasterRecordParams{IfMatch: ifMatchV1})
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected 403, got %d", w.Code)
+	}
+	ic := &ImportController{Store: store, Tables: ctrl.Tables}
+	req, w = newImportRequest("text/csv", []byte("name\n乗っ取り\n"))
+	ic.ImportMasterRecords(w, withGroup(req, 2), "groups_master", ImportMasterRecordsParams{})
+	if w.Code != http.StatusForbidden {
+		t.Errorf("import: expected 403, got %d", w.Code)
+	}
+
+	// permissions:manage があれば変更できる
+	req, w = newJSONRequest("POST", "/masters/groups_master", map[string]any{"name": "管理者2"})
+	ctrl.CreateMasterRecord(w, withGroup(req, 1), "groups_master")
+	if w.Code != http.StatusCreated {
+		t.Errorf("Expected 201, got %d: %s", w.Code, w.Body)
+	}
+}
+
+func TestMastersController_GetTableMeta(t *testing.T) {
+	ctrl := &MastersController{Store: newSeededStore(), Tables: loadMasterTables(t)}
+
+	req, w := newJSONRequest("GET", "/meta/tables/departments_master", nil)
+	ctrl.GetTableMeta(w, withShipper(req, 1), "departments_master")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var meta TableMeta
+	json.NewDecoder(w.Body).Decode(&meta)
+	if meta.Label != "部門マスタ" || meta.Columns[0].Name != "id" || !meta.Columns[0].ReadOnly {
+		t.Errorf("unexpected meta: %+v", meta)
+	}
+	for _, c := range meta.Columns {
+		switch c.Name {
+		case "shipping_id":
+			// 他荷主は選択肢に含まれない
+			if c.InputType != InputSelect || c.Options == nil || len(*c.Options) != 1 || (*c.Options)[0].Name != "荷主A" {
+				t.Errorf("unexpected shipping_id: %+v", c)
+			}
+		case "name":
+			if c.InputType != InputText || c.MaxLength == nil || *c.MaxLength != 100 || !c.Required {
+				t.Errorf("unexpected name: %+v", c)
+			}
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package controllers
+
+import "strings"
+
+// デフォルトの取得件数
+const defaultLimit = 20
+
+// maxLimit is the upper bound of the limit query parameter, mirroring the
+// maximum declared in manual/api.yml.
+const maxLimit = 100
+
+// pageOf applies defaults and bounds to the limit/offset query parameters.
+func pageOf(limit, offset *int) (int, int) {
+	l, o := defaultLimit, 0
+	if limit != nil {
+		l = min(max(*limit, 1), maxLimit)
+	}
+	if offset != nil {
+		o = max(*offset, 0)
+	}
+	return l, o
+}
+
+// likeEscaper escapes the LIKE wildcards so that user input is matched literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// escapeLike escapes s for use inside a LIKE pattern.
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
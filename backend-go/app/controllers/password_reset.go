@@ -0,0 +1,136 @@
+package controllers
+
+import (
+	"backend-go/mailer"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// passwordResetMailCode is the system_mail_settings_master.code of the
+// password reset mail.
+const passwordResetMailCode = "password_reset"
+
+// defaultPasswordResetMail is used when system_mail_settings_master has no
+// valid password reset template.
+var defaultPasswordResetMail = MailTemplate{
+	Title: "パスワード再設定のご案内",
+	Body: "{name} 様\n\n以下のURLから {expires_at} までに新しいパスワードを設定してください。\n\n{url}\n\n" +
+		"このメールに心当たりがない場合は破棄してください。\n",
+}
+
+// RequestPasswordReset e-mails a single-use reset link to the valid user
+// registered with the given address. Earlier unused tokens of the user are
+// invalidated.
+//
+// It always returns 202 Accepted so that the response does not reveal
+// whether the address is registered. Delivery failures are only logged.
+func (c *AuthController) RequestPasswordReset(w http.ResponseWriter, r *http.Request) {
+	var req PasswordResetRequest // 自動生成された型
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+	ctx := r.Context()
+
+	u, err := c.Store.Credentials().ResetRecipient(ctx, string(req.Email))
+	var notFound *NotFoundError
+	if errors.As(err, &notFound) {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	token, err := newSessionToken()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	now := time.Now()
+	expiresAt := now.Add(c.PasswordResetTTL).Truncate(time.Second)
+	if err := c.Store.Credentials().CreateResetToken(ctx, u.ID, hashToken(token), now, expiresAt); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	tmpl, found, err := c.Store.MailTemplates().Find(ctx, passwordResetMailCode)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if !found {
+		tmpl = defaultPasswordResetMail
+	}
+	link, err := url.Parse(c.PasswordResetURL)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	q := link.Query()
+	q.Set("token", token)
+	link.RawQuery = q.Encode()
+
+	replacer := strings.NewReplacer(
+		"{name}", u.Name,
+		"{url}", link.String(),
+		"{expires_at}", expiresAt.Format("2006-01-02 15:04"),
+	)
+	msg := mailer.Message{
+		From:    tmpl.From,
+		To:      []string{u.Email},
+		Subject: replacer.Replace(tmpl.Title),
+		Body:    replacer.Replace(tmpl.Body),
+	}
+	if msg.From == "" {
+		msg.From = c.MailFrom
+	}
+	if err := c.Mailer.Send(ctx, msg); err != nil {
+		slog.ErrorContext(ctx, "failed to send password reset mail", "user_id", u.ID, "error", err)
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// ConfirmPasswordReset sets a new password with an e-mailed token. The token
+// is consumed, every session of the user is revoked and any lockout is
+// cleared.
+//
+// Unknown, expired and already used tokens are rejected with 422.
+func (c *AuthController) ConfirmPasswordReset(w http.ResponseWriter, r *http.Request) {
+	var req PasswordResetConfirmRequest // 自動生成された型
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+	ctx := r.Context()
+
+	hash, err := hashPassword(req.NewPassword)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	err = c.Store.WithTx(ctx, func(tx Store) error {
+		userID, ok, err := tx.Credentials().ConsumeResetToken(ctx, hashToken(req.Token), time.Now())
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return &ValidationError{Message: "reset token is invalid or expired"}
+		}
+		if err := tx.Credentials().SetPassword(ctx, userID, hash, true); err != nil {
+			return err
+		}
+		return tx.Sessions().RevokeAll(ctx, userID, 0)
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,143 @@
+package controllers
+
+import (
+	"backend-go/mailer"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestAuthController_RequestPasswordReset(t *testing.T) {
+	ctrl, mock, teardown := setupAuth(t)
+	defer teardown()
+	capture := &mailer.Capture{}
+	ctrl.Mailer = capture
+	ctrl.MailFrom = "default@example.com"
+	ctrl.PasswordResetURL = "https://app.example.com/reset-password"
+
+	mock.ExpectQuery("SELECT id, name, email FROM users_master WHERE email").
+		WithArgs("alice@example.com").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email"}).AddRow(1, "Alice", "alice@example.com"))
+	// 未使用の古いトークンは無効にする
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE password_reset_tokens SET used_at").
+		WithArgs(sqlmock.AnyArg(), int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO password_reset_tokens").
+		WithArgs(int64(1), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(5, 1))
+	mock.ExpectCommit()
+	mock.ExpectQuery("FROM system_mail_settings_master WHERE code").
+		WithArgs("password_reset").
+		WillReturnRows(sqlmock.NewRows([]string{"title", "body", "email_from"}).
+			AddRow("再設定", "{name} 様 {url} ({expires_at})", "noreply@example.com"))
+
+	req, w := newJSONRequest("POST", "/auth/password-reset", PasswordResetRequest{Email: "alice@example.com"})
+	ctrl.RequestPasswordReset(w, req)
+
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("Expected 202, got %d: %s", w.Code, w.Body.String())
+	}
+	msgs := capture.Messages()
+	if len(msgs) != 1 {
+		t.Fatalf("Expected 1 mail, got %d", len(msgs))
+	}
+	msg := msgs[0]
+	if msg.From != "noreply@example.com" || msg.To[0] != "alice@example.com" || !strings.HasPrefix(msg.Body, "Alice 様 https://app.example.com/reset-password?token=") {
+		t.Errorf("unexpected mail %+v", msg)
+	}
+}
+
+func TestAuthController_RequestPasswordReset_UnknownEmail(t *testing.T) {
+	ctrl, mock, teardown := setupAuth(t)
+	defer teardown()
+	capture := &mailer.Capture{}
+	ctrl.Mailer = capture
+
+	mock.ExpectQuery("SELECT id, name, email FROM users_master WHERE email").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email"}))
+
+	req, w := newJSONRequest("POST", "/auth/password-reset", PasswordResetRequest{Email: "nobody@example.com"})
+	ctrl.RequestPasswordReset(w, req)
+
+	// 登録有無が分からないよう同じ 202 を返す
+	if w.Code != http.StatusAccepted {
+		t.Errorf("Expected 202, got %d", w.Code)
+	}
+	if len(capture.Messages()) != 0 {
+		t.Error("no mail must be sent for an unknown address")
+	}
+}
+
+func TestAuthController_RequestPasswordReset_SendFailure(t *testing.T) {
+	ctrl, mock, teardown := setupAuth(t)
+	defer teardown()
+	ctrl.Mailer = &mailer.Capture{Err: errors.New("smtp down")}
+	ctrl.MailFrom = "default@example.com"
+	ctrl.PasswordResetURL = "https://app.example.com/reset-password"
+
+	mock.ExpectQuery("SELECT id, name, email FROM users_master WHERE email").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email"}).AddRow(1, "Alice", "alice@example.com"))
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE password_reset_tokens SET used_at").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT INTO password_reset_tokens").WillReturnResult(sqlmock.NewResult(5, 1))
+	mock.ExpectCommit()
+	// テンプレートがなければ既定の文面を使う
+	mock.ExpectQuery("FROM system_mail_settings_master").
+		WillReturnRows(sqlmock.NewRows([]string{"title", "body", "email_from"}))
+
+	req, w := newJSONRequest("POST", "/auth/password-reset", PasswordResetRequest{Email: "alice@example.com"})
+	ctrl.RequestPasswordReset(w, req)
+
+	if w.Code != http.StatusAccepted {
+		t.Errorf("Expected 202, got %d", w.Code)
+	}
+}
+
+func TestAuthController_ConfirmPasswordReset(t *testing.T) {
+	ctrl, mock, teardown := setupAuth(t)
+	defer teardown()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("FROM password_reset_tokens t").
+		WithArgs(hashToken("reset-token"), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}).AddRow(5, 1))
+	mock.ExpectExec("UPDATE password_reset_tokens SET used_at = \\? WHERE id = \\?").
+		WithArgs(sqlmock.AnyArg(), int64(5)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE users_master SET password_hash = \\?, failed_login_count = 0, locked_until = NULL").
+		WithArgs(bcryptOf("new-password"), int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE user_sessions SET revoked_at").
+		WithArgs(sqlmock.AnyArg(), int64(1), int64(0)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	req, w := newJSONRequest("POST", "/auth/password-reset/confirm", PasswordResetConfirmRequest{Token: "reset-token", NewPassword: "new-password"})
+	ctrl.ConfirmPasswordReset(w, req)
+
+	if w.Code != http.StatusNoContent {
+		t.Errorf("Expected 204, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestAuthController_ConfirmPasswordReset_UsedToken(t *testing.T) {
+	ctrl, mock, teardown := setupAuth(t)
+	defer teardown()
+
+	// 使用済み・期限切れのトークンは検索条件で除外される
+	mock.ExpectBegin()
+	mock.ExpectQuery("t.used_at IS NULL AND t.expires_at > \\?").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}))
+	mock.ExpectRollback()
+
+	req, w := newJSONRequest("POST", "/auth/password-reset/confirm", PasswordResetConfirmRequest{Token: "used", NewPassword: "new-password"})
+	ctrl.ConfirmPasswordReset(w, req)
+
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected 422, got %d", w.Code)
+	}
+}
@@ -0,0 +1,146 @@
+package controllers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+)
+
+// mysqlPermissions is the [PermissionRepository] of [MySQLStore].
+type mysqlPermissions struct {
+	s *MySQLStore
+}
+
+func (r mysqlPermissions) List(ctx context.Context) ([]Permission, error) {
+	rows, err := r.s.q.QueryContext(ctx, "SELECT id, code, name FROM permissions_master ORDER BY code")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	permissions := []Permission{}
+	for rows.Next() {
+		var p Permission
+		if err := rows.Scan(&p.ID, &p.Code, &p.Name); err != nil {
+			return nil, err
+		}
+		permissions = append(permissions, p)
+	}
+	return permissions, rows.Err()
+}
+
+func (r mysqlPermissions) FindGroup(ctx context.Context, id int64, lock bool) (int64, error) {
+	query := "SELECT version FROM groups_master WHERE id = ?"
+	if lock {
+		query += " FOR UPDATE"
+	}
+	var version int64
+	err := r.s.q.QueryRowContext(ctx, query, id).Scan(&version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, &NotFoundError{Resource: "group", ID: id}
+	}
+	return version, err
+}
+
+func (r mysqlPermissions) GroupCodes(ctx context.Context, groupID int64) ([]string, error) {
+	rows, err := r.s.q.QueryContext(ctx,
+		`SELECT p.code FROM group_permissions gp
+		INNER JOIN permissions_master p ON p.id = gp.permission_id
+		WHERE gp.group_id = ? ORDER BY p.code`, groupID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	codes := []string{}
+	for rows.Next() {
+		var code string
+		if err := rows.Scan(&code); err != nil {
+			return nil, err
+		}
+		codes = append(codes, code)
+	}
+	return codes, rows.Err()
+}
+
+func (r mysqlPermissions) SetGroupCodes(ctx context.Context, groupID int64, codes []string) error {
+	return r.s.inTx(ctx, func(q dbtx) error {
+		ids, err := permissionIDs(ctx, q, codes)
+		if err != nil {
+			return err
+		}
+		if _, err := q.ExecContext(ctx, "UPDATE groups_master SET version = version + 1 WHERE id = ?", groupID); err != nil {
+			return err
+		}
+		if _, err := q.ExecContext(ctx, "DELETE FROM group_permissions WHERE group_id = ?", groupID); err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		query := "INSERT INTO group_permissions (group_id, permission_id) VALUES " +
+			strings.TrimSuffix(strings.Repeat("(?, ?), ", len(ids)), ", ")
+		args := make([]any, 0, len(ids)*2)
+		for _, pid := range ids {
+			args = append(args, groupID, pid)
+		}
+		_, err = q.ExecContext(ctx, query, args...)
+		return err
+	})
+}
+
+func (r mysqlPermissions) Has(ctx context.Context, groupID int64, code string) (bool, error) {
+	var n int
+	err := r.s.q.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM group_permissions gp
+		INNER JOIN permissions_master p ON p.id = gp.permission_id
+		WHERE gp.group_id = ? AND p.code = ?`, groupID, code).Scan(&n)
+	return n > 0, err
+}
+
+// permissionIDs resolves permission codes to permissions_master ids. Unknown
+// codes are reported as a [ValidationError].
+func permissionIDs(ctx context.Context, q dbtx, codes []string) ([]int64, error) {
+	if len(codes) == 0 {
+		return nil, nil
+	}
+	args := make([]any, len(codes))
+	for i, code := range codes {
+		args[i] = code
+	}
+	rows, err := q.QueryContext(ctx,
+		"SELECT id, code FROM permissions_master WHERE code IN ("+
+			strings.TrimSuffix(strings.Repeat("?, ", len(codes)), ", ")+")", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	found := make(map[string]int64, len(codes))
+	for rows.Next() {
+		var id int64
+		var code string
+		if err := rows.Scan(&id, &code); err != nil {
+			return nil, err
+		}
+		found[code] = id
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	ids := make([]int64, 0, len(codes))
+	var unknown []string
+	for _, code := range codes {
+		if id, ok := found[code]; ok {
+			ids = append(ids, id)
+		} else {
+			unknown = append(unknown, code)
+		}
+	}
+	if len(unknown) > 0 {
+		return nil, &ValidationError{Message: "unknown permission: " + strings.Join(unknown, ", ")}
+	}
+	return ids, nil
+}
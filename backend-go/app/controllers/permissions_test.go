@@ -0,0 +1,319 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-chi/chi/v5"
+	"gopkg.in/yaml.v3"
+)
+
+func setupPermissions(t *testing.T) (*PermissionsController, sqlmock.Sqlmock, func()) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %s", err)
+	}
+	ctrl := &PermissionsController{Store: NewMySQLStore(db)}
+	teardown := func() {
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unmet expectations: %s", err)
+		}
+		db.Close()
+	}
+	return ctrl, mock, teardown
+}
+
+// 共通ヘルパー: 指定グループに所属するログインユーザとしてリクエストする
+func withGroup(req *http.Request, groupID int64) *http.Request {
+	req = withAuth(req, 1, 10)
+	au, _ := AuthUserFrom(req.Context())
+	au.GroupID = groupID
+	return req
+}
+
+func TestRequiredPermissions(t *testing.T) {
+	swagger, err := GetSwagger()
+	if err != nil {
+		t.Fatal(err)
+	}
+	required, err := RequiredPermissions(swagger)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]string{
+		"GET /users":                   "users:read",
+		"DELETE /users/{id}":           "users:write",
+		"PUT /groups/{id}/permissions": "permissions:manage",
+		"POST /auth/login":             "",
+		"GET /auth/me":                 "",
+	}
+	for route, want := range tests {
+		if got := required[route]; got != want {
+			t.Errorf("%s: got %q, want %q", route, got, want)
+		}
+	}
+}
+
+// 仕様書の x-permission がすべて permissions_master の初期データにあること
+func TestRequiredPermissions_Seeded(t *testing.T) {
+	swagger, err := GetSwagger()
+	if err != nil {
+		t.Fatal(err)
+	}
+	required, err := RequiredPermissions(swagger)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile("../../docs/schema.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var schema struct {
+		Tables []struct {
+			Name     string           `yaml:"name"`
+			SeedData []map[string]any `yaml:"seed_data"`
+		} `yaml:"tables"`
+	}
+	if err := yaml.Unmarshal(data, &schema); err != nil {
+		t.Fatal(err)
+	}
+	var seeded []string
+	for _, table := range schema.Tables {
+		if table.Name == "permissions_master" {
+			for _, row := range table.SeedData {
+				seeded = append(seeded, row["code"].(string))
+			}
+		}
+	}
+	for route, code := range required {
+		if !slices.Contains(seeded, code) {
+			t.Errorf("%s requires %q, which is not seeded in permissions_master", route, code)
+		}
+	}
+}
+
+func TestPermissionsController_Authorize(t *testing.T) {
+	tests := []struct {
+		name    string
+		granted int
+		want    int
+	}{
+		{"granted", 1, http.StatusOK},
+		{"denied", 0, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, mock, teardown := setupPermissions(t)
+			defer teardown()
+			ctrl.Required = map[string]string{"GET /users/{id}": "users:read"}
+
+			mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM group_permissions").
+				WithArgs(int64(2), "users:read").
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(tt.granted))
+
+			r := chi.NewRouter()
+			r.With(ctrl.Authorize).Get("/users/{id}", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+			req := withGroup(httptest.NewRequest("GET", "/users/3", nil), 2)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Fatalf("Expected %d, got %d", tt.want, w.Code)
+			}
+			if tt.want == http.StatusForbidden {
+				var resp ErrorResponse
+				json.NewDecoder(w.Body).Decode(&resp)
+				if resp.Code != http.StatusForbidden || resp.Message != "permission users:read is required" {
+					t.Errorf("unexpected error response %+v", resp)
+				}
+			}
+		})
+	}
+}
+
+func TestPermissionsController_Authorize_NotRequired(t *testing.T) {
+	ctrl, _, teardown := setupPermissions(t)
+	defer teardown()
+	ctrl.Required = map[string]string{"GET /users/{id}": "users:read"}
+
+	// 権限指定のない操作はDBを参照せず通す
+	r := chi.NewRouter()
+	r.With(ctrl.Authorize).Get("/auth/me", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/auth/me", nil))
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected 200, got %d", w.Code)
+	}
+}
+
+func TestPermissionsController_GetGroupPermissions(t *testing.T) {
+	ctrl, mock, teardown := setupPermissions(t)
+	defer teardown()
+
+	mock.ExpectQuery("SELECT version FROM groups_master WHERE id = \\?").WithArgs(int64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(4))
+	mock.ExpectQuery("SELECT p.code FROM group_permissions").WithArgs(int64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"code"}).AddRow("users:read").AddRow("users:write"))
+
+	req, w := newJSONRequest("GET", "/groups/2/permissions", nil)
+	ctrl.GetGroupPermissions(w, withGroup(req, 1), 2)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp GroupPermissions
+	json.NewDecoder(w.Body).Decode(&resp)
+	if resp.GroupID != 2 || !slices.Equal(resp.Permissions, []string{"users:read", "users:write"}) || resp.Version != 4 {
+		t.Errorf("unexpected response %+v", resp)
+	}
+	if etag := w.Header().Get("ETag"); etag != `"4"` {
+		t.Errorf("unexpected ETag %q", etag)
+	}
+}
+
+func TestPermissionsController_GetGroupPermissions_NotFound(t *testing.T) {
+	ctrl, mock, teardown := setupPermissions(t)
+	defer teardown()
+
+	mock.ExpectQuery("SELECT version FROM groups_master").WillReturnRows(sqlmock.NewRows([]string{"version"}))
+
+	req, w := newJSONRequest("GET", "/groups/99/permissions", nil)
+	ctrl.GetGroupPermissions(w, withGroup(req, 1), 99)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404, got %d", w.Code)
+	}
+}
+
+func TestPermissionsController_UpdateGroupPermissions(t *testing.T) {
+	ctrl, mock, teardown := setupPermissions(t)
+	defer teardown()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT version FROM groups_master WHERE id = \\? FOR UPDATE").WithArgs(int64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(1))
+	mock.ExpectQuery("SELECT version FROM groups_master WHERE id = \\?").WithArgs(int64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(1))
+	mock.ExpectQuery("SELECT p.code FROM group_permissions").WithArgs(int64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"code"}).AddRow("users:write"))
+	mock.ExpectQuery("SELECT id, code FROM permissions_master WHERE code IN \\(\\?, \\?\\)").
+		WithArgs("items:read", "users:read").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "code"}).AddRow(1, "users:read").AddRow(4, "items:read"))
+	mock.ExpectExec("UPDATE groups_master SET version = version \\+ 1 WHERE id = \\?").WithArgs(int64(2)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM group_permissions WHERE group_id = \\?").WithArgs(int64(2)).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec("INSERT INTO group_permissions \\(group_id, permission_id\\) VALUES \\(\\?, \\?\\), \\(\\?, \\?\\)").
+		WithArgs(int64(2), int64(4), int64(2), int64(1)).
+		WillReturnResult(sqlmock.NewResult(1, 2))
+	mock.ExpectQuery("SELECT version FROM groups_master WHERE id = \\?").WithArgs(int64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(2))
+	mock.ExpectQuery("SELECT p.code FROM group_permissions").WithArgs(int64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"code"}).AddRow("items:read").AddRow("users:read"))
+	expectAudit(mock, AuditUpdate, "group_permissions", 2)
+	mock.ExpectCommit()
+
+	// 重複は無視し、コード順に並べて返す
+	body := GroupPermissionsRequestPut{Permissions: []string{"users:read", "items:read", "users:read"}}
+	req, w := newJSONRequest("PUT", "/groups/2/permissions", body)
+	ctrl.UpdateGroupPermissions(w, withGroup(req, 1), 2, UpdateGroupPermissionsParams{IfMatch: ifMatchV1})
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp GroupPermissions
+	json.NewDecoder(w.Body).Decode(&resp)
+	if !slices.Equal(resp.Permissions, []string{"items:read", "users:read"}) || resp.Version != 2 {
+		t.Errorf("unexpected response %+v", resp)
+	}
+}
+
+func TestPermissionsController_UpdateGroupPermissions_Clear(t *testing.T) {
+	ctrl, mock, teardown := setupPermissions(t)
+	defer teardown()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT version FROM groups_master").WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(1))
+	mock.ExpectQuery("SELECT version FROM groups_master").WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(1))
+	mock.ExpectQuery("SELECT p.code FROM group_permissions").WillReturnRows(sqlmock.NewRows([]string{"code"}))
+	mock.ExpectExec("UPDATE groups_master SET version").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM group_permissions").WithArgs(int64(2)).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectQuery("SELECT version FROM groups_master").WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(2))
+	mock.ExpectQuery("SELECT p.code FROM group_permissions").WillReturnRows(sqlmock.NewRows([]string{"code"}))
+	expectAudit(mock, AuditUpdate, "group_permissions", 2)
+	mock.ExpectCommit()
+
+	req, w := newJSONRequest("PUT", "/groups/2/permissions", GroupPermissionsRequestPut{Permissions: []string{}})
+	ctrl.UpdateGroupPermissions(w, withGroup(req, 1), 2, UpdateGroupPermissionsParams{IfMatch: ifMatchV1})
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestPermissionsController_UpdateGroupPermissions_UnknownCode(t *testing.T) {
+	ctrl, mock, teardown := setupPermissions(t)
+	defer teardown()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT version FROM groups_master").WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(1))
+	mock.ExpectQuery("SELECT version FROM groups_master").WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(1))
+	mock.ExpectQuery("SELECT p.code FROM group_permissions").WillReturnRows(sqlmock.NewRows([]string{"code"}))
+	mock.ExpectQuery("SELECT id, code FROM permissions_master").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "code"}).AddRow(1, "users:read"))
+	mock.ExpectRollback()
+
+	body := GroupPermissionsRequestPut{Permissions: []string{"users:read", "users:delete"}}
+	req, w := newJSONRequest("PUT", "/groups/2/permissions", body)
+	ctrl.UpdateGroupPermissions(w, withGroup(req, 1), 2, UpdateGroupPermissionsParams{IfMatch: ifMatchV1})
+
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("Expected 422, got %d", w.Code)
+	}
+	var resp ErrorResponse
+	json.NewDecoder(w.Body).Decode(&resp)
+	if resp.Message != "unknown permission: users:delete" {
+		t.Errorf("unexpected message %q", resp.Message)
+	}
+}
+
+func TestPermissionsController_UpdateGroupPermissions_OwnGroup(t *testing.T) {
+	ctrl, _, teardown := setupPermissions(t)
+	defer teardown()
+
+	// 自分のグループから permissions:manage を外すと管理者がいなくなる
+	body := GroupPermissionsRequestPut{Permissions: []string{"users:read"}}
+	req, w := newJSONRequest("PUT", "/groups/1/permissions", body)
+	ctrl.UpdateGroupPermissions(w, withGroup(req, 1), 1, UpdateGroupPermissionsParams{IfMatch: ifMatchV1})
+
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected 422, got %d", w.Code)
+	}
+}
+
+func TestAuthorizeBasePath(t *testing.T) {
+	c := &PermissionsController{Required: map[string]string{"GET /users/{id}": "users:read"}, BasePath: "/api"}
+	r := chi.NewRouter()
+	r.Route("/api", func(r chi.Router) {
+		r.With(c.Authorize).Get("/users/{id}", func(w http.ResponseWriter, r *http.Request) {})
+	})
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/api/users/1", nil))
+	// ログインユーザがいないため、権限の確認に進めば 401
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected the permission to be checked, got %d", w.Code)
+	}
+}
@@ -0,0 +1,163 @@
+package controllers
+
+import (
+	"backend-go/holiday"
+	"backend-go/rrule"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// recurrencePadDays is how far around the requested period occurrences are
+// generated, so that occurrences moved into the period by the business
+// calendar or by an override are not missed.
+const recurrencePadDays = 31
+
+// recurrencePeriod returns the period recurring events are expanded for
+// from the bounds of f, see [eventPeriod]. Like [derivedPeriod] it must not
+// exceed [maxDerivedMonths] months, so that a request cannot make a series
+// generate an unbounded number of occurrences.
+func recurrencePeriod(f EventFilter, now time.Time) (time.Time, time.Time, error) {
+	from, to := eventPeriod(f, now)
+	if to.After(from.AddDate(0, maxDerivedMonths, 0)) {
+		return from, to, &BadRequestError{Err: fmt.Errorf("recurring events can be expanded for up to %d months; narrow the period", maxDerivedMonths)}
+	}
+	return from, to, nil
+}
+
+// expandEvents returns the occurrences of the recurring events recs
+// overlapping [from, to). The business calendar is only read when one of
+// them is adjusted to business days.
+func expandEvents(ctx context.Context, store Store, recs []EventRecord, from, to time.Time) ([]datedEvent, error) {
+	var cal holiday.Calendar
+	if slices.ContainsFunc(recs, func(rec EventRecord) bool { return rec.BusinessDay != EventBusinessDayNone }) {
+		business, err := loadBusinessCalendar(ctx, store, nil,
+			holiday.DateOf(from.In(tokyo)).AddDays(-2*recurrencePadDays), holiday.DateOf(to.In(tokyo)).AddDays(2*recurrencePadDays))
+		if err != nil {
+			return nil, err
+		}
+		cal = business.Calendar
+	}
+	var out []datedEvent
+	for _, rec := range recs {
+		occs, err := occurrences(rec, cal, from, to)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, occs...)
+	}
+	return out, nil
+}
+
+// occurrences returns the occurrences of the recurring event rec
+// overlapping [from, to), in the order they are generated.
+//
+// An occurrence is generated for each date of the rule that is not an
+// exdate. It is then moved or left out according to rec.BusinessDay and
+// finally changed by the override of that date, if any. Occurrences keep
+// the duration of the event.
+func occurrences(rec EventRecord, cal holiday.Calendar, from, to time.Time) ([]datedEvent, error) {
+	rule, err := rrule.Parse(*rec.RRule, tokyo)
+	if err != nil {
+		return nil, fmt.Errorf("event %d: %w", rec.ID, err)
+	}
+	var duration time.Duration
+	if rec.End != nil {
+		duration = rec.End.Sub(rec.Start)
+	}
+	// 期間より前に始まっても期間にかかる回があるため、長さと移動の分だけ手前から調べる
+	earliest := from.AddDate(0, 0, -recurrencePadDays).Add(-duration)
+
+	var out []datedEvent
+	for _, t := range rule.Between(rec.Start, earliest, to.AddDate(0, 0, recurrencePadDays)) {
+		day := eventDay(t.In(tokyo))
+		if slices.ContainsFunc(rec.ExDates, day.Equal) {
+			continue
+		}
+		start := t.In(tokyo)
+		if d := holiday.DateOf(start); rec.BusinessDay != EventBusinessDayNone && !cal.IsBusinessDay(d) {
+			switch rec.BusinessDay {
+			case EventBusinessDaySkip:
+				continue
+			case EventBusinessDayPrevious:
+				d = cal.PrevBusinessDay(d)
+			case EventBusinessDayNext:
+				d = cal.NextBusinessDay(d)
+			}
+			start = time.Date(d.Year, d.Month, d.Day, start.Hour(), start.Minute(), start.Second(), 0, tokyo)
+		}
+
+		occ := rec
+		occ.Start = start
+		if rec.End != nil {
+			end := start.Add(duration)
+			occ.End = &end
+		}
+		if i := slices.IndexFunc(rec.Overrides, func(o OccurrenceOverride) bool { return o.Date.Equal(day) }); i >= 0 {
+			occ.EventInput = overrideOccurrence(occ.EventInput, rec.Overrides[i], duration)
+		}
+		if !occ.Start.Before(to) || occ.Start.Before(from) && (occ.End == nil || !occ.End.After(from)) {
+			continue
+		}
+
+		e := event(EventRecord{EventInput: occ.EventInput, ID: rec.ID, Version: rec.Version})
+		e.ID = fmt.Sprintf("%d-%s", rec.ID, day.Format("20060102"))
+		e.Rrule, e.Exdates, e.BusinessDay, e.Overrides = nil, nil, nil, nil
+		e.SeriesID = &rec.ID
+		recurrence := apiDate(day)
+		e.RecurrenceID = &recurrence
+		out = append(out, datedEvent{Event: e, start: occ.Start})
+	}
+	return out, nil
+}
+
+// overrideOccurrence applies o to the occurrence in. An occurrence whose
+// start is changed without an end keeps its duration.
+func overrideOccurrence(in EventInput, o OccurrenceOverride, duration time.Duration) EventInput {
+	if o.Title != nil {
+		in.Title = *o.Title
+	}
+	if o.Start != nil {
+		in.Start = *o.Start
+		if in.End != nil {
+			end := in.Start.Add(duration)
+			in.End = &end
+		}
+	}
+	if o.End != nil {
+		in.End = o.End
+	}
+	if o.Status != nil {
+		in.Status = *o.Status
+	}
+	if o.Description != nil {
+		in.Description = o.Description
+	}
+	return in
+}
+
+// isOccurrence reports whether the recurring event rec has an occurrence
+// generated for day, a midnight in Asia/Tokyo, that is not an exdate.
+func isOccurrence(rec EventRecord, day time.Time) (bool, error) {
+	if rec.RRule == nil || slices.ContainsFunc(rec.ExDates, day.Equal) {
+		return false, nil
+	}
+	rule, err := rrule.Parse(*rec.RRule, tokyo)
+	if err != nil {
+		return false, err
+	}
+	for _, t := range rule.Before(rec.Start, day.AddDate(0, 0, 1)) {
+		if eventDay(t.In(tokyo)).Equal(day) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// eventDay returns the midnight in Asia/Tokyo of the calendar date of t,
+// taken in the location of t. Dates read from DATE columns and from the API
+// keep their day this way.
+func eventDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, tokyo)
+}
@@ -0,0 +1,138 @@
+package controllers
+
+import (
+	"backend-go/holiday"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+func TestEvents_Recurring(t *testing.T) {
+	store := newSeededStore()
+	c := &EventsController{Store: store}
+	// 毎月末 10:00〜11:00。2026-05-31 は日曜日のため前営業日に繰り上げ
+	e, code := postEvent(t, c, EventRequest{
+		Title:       "月末棚卸",
+		Start:       "2026-01-31T10:00:00",
+		End:         ptr("2026-01-31T11:00:00"),
+		Rrule:       ptr("freq=monthly;bymonthday=-1"),
+		Exdates:     &[]openapi_types.Date{*date(2026, 3, 31)},
+		BusinessDay: ptr(EventBusinessDayPrevious),
+	}, nil)
+	if code != http.StatusCreated || e.Rrule == nil || *e.Rrule != "FREQ=MONTHLY;BYMONTHDAY=-1" {
+		t.Fatalf("Expected 201 with a normalised rule, got %d %+v", code, e)
+	}
+	id := mustID(t, e.ID)
+
+	starts := func(res EventsResponse) string {
+		var out []string
+		for _, e := range res.Events {
+			out = append(out, e.ID+"@"+e.Start)
+		}
+		return strings.Join(out, " ")
+	}
+	res := listEvents(t, c, ListEventsParams{From: date(2026, 2, 1), To: date(2026, 5, 31), Derived: ptr(false)})
+	// 1月31日は土曜日のため 1月30日、3月は除外日
+	want := e.ID + "-20260228@2026-02-27T10:00:00 " + e.ID + "-20260430@2026-04-30T10:00:00 " + e.ID + "-20260531@2026-05-29T10:00:00"
+	if got := starts(res); got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+	if o := res.Events[0]; o.SeriesID == nil || *o.SeriesID != id || o.RecurrenceID == nil || o.RecurrenceID.String() != "2026-02-28" ||
+		o.End == nil || *o.End != "2026-02-27T11:00:00" || o.Rrule != nil {
+		t.Errorf("unexpected occurrence %+v", o)
+	}
+
+	// 4月の回だけ変更する
+	req, w := newJSONRequest("PUT", "/events/1/occurrences/2026-04-30", EventOccurrenceRequest{Start: ptr("2026-04-28T15:00:00"), Status: ptr(EventStatusError)})
+	c.UpdateEventOccurrence(w, withAuth(req, 7, 10), id, *date(2026, 4, 30), UpdateEventOccurrenceParams{IfMatch: ifMatchV1})
+	if w.Code != http.StatusOK || w.Header().Get("ETag") != `"2"` {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var series Event
+	json.NewDecoder(w.Body).Decode(&series)
+	if series.Overrides == nil || len(*series.Overrides) != 1 || *(*series.Overrides)[0].Start != "2026-04-28T15:00:00" {
+		t.Errorf("unexpected series %+v", series)
+	}
+	res = listEvents(t, c, ListEventsParams{From: date(2026, 4, 1), To: date(2026, 4, 30), Status: &[]EventStatus{EventStatusError}, Derived: ptr(false)})
+	if got := starts(res); got != e.ID+"-20260430@2026-04-28T15:00:00" || *res.Events[0].End != "2026-04-28T16:00:00" {
+		t.Errorf("unexpected override %s", got)
+	}
+
+	// 回のない日付は 404
+	req, w = newJSONRequest("PUT", "/events/1/occurrences/2026-04-29", EventOccurrenceRequest{Title: ptr("x")})
+	c.UpdateEventOccurrence(w, withAuth(req, 7, 10), id, *date(2026, 4, 29), UpdateEventOccurrenceParams{IfMatch: ptr[IfMatch](`"2"`)})
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404, got %d", w.Code)
+	}
+	// 終了が開始より前は 422
+	req, w = newJSONRequest("PUT", "/events/1/occurrences/2026-04-30", EventOccurrenceRequest{End: ptr("2026-04-30T09:00:00")})
+	c.UpdateEventOccurrence(w, withAuth(req, 7, 10), id, *date(2026, 4, 30), UpdateEventOccurrenceParams{IfMatch: ptr[IfMatch](`"2"`)})
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected 422, got %d", w.Code)
+	}
+
+	// 4月の回を取り消すと除外日になり、変更も破棄される
+	req, w = newJSONRequest("DELETE", "/events/1/occurrences/2026-04-30", nil)
+	c.CancelEventOccurrence(w, withAuth(req, 7, 10), id, *date(2026, 4, 30), CancelEventOccurrenceParams{IfMatch: ptr[IfMatch](`"2"`)})
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("Expected 204, got %d: %s", w.Code, w.Body.String())
+	}
+	rec, _ := store.Events().Find(t.Context(), id)
+	if len(rec.ExDates) != 2 || len(rec.Overrides) != 0 || rec.Version != 3 {
+		t.Errorf("unexpected record %+v", rec)
+	}
+	res = listEvents(t, c, ListEventsParams{From: date(2026, 4, 1), To: date(2026, 4, 30), Derived: ptr(false)})
+	if res.Count != 0 {
+		t.Errorf("cancelled occurrence is listed: %s", starts(res))
+	}
+
+	// skip は休業日の回を除く
+	e, _ = postEvent(t, c, EventRequest{Title: "朝礼", Start: "2026-05-01T08:30:00", Rrule: ptr("FREQ=DAILY;COUNT=7"), BusinessDay: ptr(EventBusinessDaySkip)}, nil)
+	res = listEvents(t, c, ListEventsParams{From: date(2026, 5, 1), To: date(2026, 5, 7), Derived: ptr(false)})
+	if got := starts(res); got != e.ID+"-20260501@2026-05-01T08:30:00 "+e.ID+"-20260507@2026-05-07T08:30:00" {
+		t.Errorf("unexpected skip %s", got)
+	}
+
+	// 繰り返しのない回の操作や、繰り返しなしの除外日は受け付けない
+	if _, code := postEvent(t, c, EventRequest{Title: "x", Start: "2026-05-01", Exdates: &[]openapi_types.Date{*date(2026, 5, 1)}}, nil); code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected 422 for exdates without rrule, got %d", code)
+	}
+	if _, code := postEvent(t, c, EventRequest{Title: "x", Start: "2026-05-01", Rrule: ptr("FREQ=HOURLY")}, nil); code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected 422 for an unsupported rule, got %d", code)
+	}
+}
+
+func TestOccurrences_Window(t *testing.T) {
+	start := time.Date(2026, 1, 30, 0, 0, 0, 0, tokyo)
+	end := start.AddDate(0, 0, 3)
+	rec := EventRecord{ID: 9, EventInput: EventInput{Title: "連休", Start: start, End: &end, AllDay: true, RRule: ptr("FREQ=WEEKLY"), BusinessDay: EventBusinessDayNone}}
+	// 期間の前に始まり期間にかかる回も返す
+	occs, err := occurrences(rec, holiday.Calendar{}, time.Date(2026, 2, 1, 0, 0, 0, 0, tokyo), time.Date(2026, 2, 7, 0, 0, 0, 0, tokyo))
+	if err != nil || len(occs) != 2 || occs[0].Start != "2026-01-30" || *occs[0].End != "2026-02-02" {
+		t.Errorf("unexpected occurrences %+v, %v", occs, err)
+	}
+}
+
+func TestEvents_RecurringPeriod(t *testing.T) {
+	c := &EventsController{Store: newSeededStore()}
+	if _, code := postEvent(t, c, EventRequest{Title: "日次", Start: "2026-01-01T09:00:00", Rrule: ptr("FREQ=DAILY")}, nil); code != http.StatusCreated {
+		t.Fatalf("Expected 201, got %d", code)
+	}
+
+	// 繰り返しの展開も期間を制限する
+	req, w := newJSONRequest("GET", "/events", nil)
+	c.ListEvents(w, withAuth(req, 7, 10), ListEventsParams{From: date(2026, 1, 1), To: date(9999, 12, 31), Derived: ptr(false)})
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400, got %d", w.Code)
+	}
+
+	// 遠い期間でも開始日からではなく期間の分だけ展開する
+	res := listEvents(t, c, ListEventsParams{From: date(9999, 12, 1), To: date(9999, 12, 3), Derived: ptr(false)})
+	if res.Count != 3 || res.Events[0].Start != "9999-12-01T09:00:00" {
+		t.Errorf("unexpected occurrences %+v", res.Events)
+	}
+}
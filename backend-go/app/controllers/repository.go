@@ -0,0 +1,440 @@
+package controllers
+
+import (
+	"context"
+	"time"
+)
+
+// Store gives the controllers access to the repositories. Controllers depend
+// on this interface only; [MySQLStore] is used by the server and
+// [MemoryStore] by tests.
+//
+// Every repository method takes the request context, which carries the
+// [Scope] of the caller, the deadline and the tracing span.
+type Store interface {
+	Users() UserRepository
+	Sessions() SessionRepository
+	Credentials() CredentialRepository
+	Permissions() PermissionRepository
+	MailTemplates() MailTemplateRepository
+	Masters() MasterRepository
+	Audit() AuditRepository
+	Events() EventRepository
+	Calendar() CalendarRepository
+
+	// WithTx runs fn in a transaction, committing when it returns nil and
+	// rolling back otherwise. The repositories of tx share the transaction.
+	// Calling WithTx on tx joins the running transaction.
+	//
+	// fn may be run more than once when the transaction is aborted by a
+	// deadlock, so it must not have side effects outside tx.
+	WithTx(ctx context.Context, fn func(tx Store) error) error
+}
+
+// UserFilter selects the users returned by [UserRepository.List].
+type UserFilter struct {
+	// Q matches part of the name, user_id or e-mail address.
+	Q            string
+	GroupID      *int64
+	DepartmentID *int64
+	ValidFlag    *bool
+	// Sort defaults to ascending id.
+	Sort   ListUsersParamsSort
+	Limit  int
+	Offset int
+}
+
+// UserInput holds the writable columns of users_master.
+type UserInput struct {
+	GroupID      int64
+	UserID       string
+	Name         string
+	Email        string
+	DepartmentID int64
+	// ShippingID is the shipper of a shipper-side user, or nil for warehouse
+	// staff.
+	ShippingID *int64
+	ValidFlag  bool
+	// PasswordHash is the new bcrypt hash, or nil to keep the current one.
+	PasswordHash *string
+}
+
+// UserRepository stores users_master. Every method is restricted to the
+// [Scope] of ctx: users of other shippers are reported as missing.
+type UserRepository interface {
+	// List returns a page of users and the number of users matching f.
+	List(ctx context.Context, f UserFilter) ([]User, int, error)
+	// Find returns a [NotFoundError] when the user does not exist.
+	Find(ctx context.Context, id int64) (User, error)
+	// Create returns the ID of the new user.
+	Create(ctx context.Context, in UserInput) (int64, error)
+	// Lock locks the user until the end of the transaction and returns its
+	// version.
+	Lock(ctx context.Context, id int64) (int64, error)
+	// Update and Disable increment the version.
+	Update(ctx context.Context, id int64, in UserInput) error
+	// Disable clears valid_flag.
+	Disable(ctx context.Context, id int64) error
+	// Unlock clears a login lock and the failure count. The version is
+	// kept, as is on failed logins.
+	Unlock(ctx context.Context, id int64) error
+
+	// GroupExists, DepartmentExists and ShippingExists check the references
+	// of a user before it is written.
+	GroupExists(ctx context.Context, id int64) (bool, error)
+	DepartmentExists(ctx context.Context, id int64) (bool, error)
+	ShippingExists(ctx context.Context, id int64) (bool, error)
+	// DepartmentOfShipping reports whether the department belongs to the
+	// shipper.
+	DepartmentOfShipping(ctx context.Context, departmentID, shippingID int64) (bool, error)
+	// UserIDTaken and EmailTaken report whether a user other than exceptID
+	// already uses the value.
+	UserIDTaken(ctx context.Context, userID string, exceptID int64) (bool, error)
+	EmailTaken(ctx context.Context, email string, exceptID int64) (bool, error)
+}
+
+// NewSession is a session to be stored by [SessionRepository.Create].
+type NewSession struct {
+	UserID    int64
+	TokenHash string
+	// UserAgent and IPAddress may be empty.
+	UserAgent string
+	IPAddress string
+	ExpiresAt time.Time
+}
+
+// SessionRepository stores the login sessions (user_sessions).
+type SessionRepository interface {
+	// Lookup returns the valid owner of the active session with the token
+	// hash, or an [UnauthorizedError].
+	Lookup(ctx context.Context, tokenHash string, now time.Time) (*AuthUser, error)
+	Create(ctx context.Context, s NewSession) error
+	// DeleteExpired removes the sessions of userID that expired by now.
+	DeleteExpired(ctx context.Context, userID int64, now time.Time) error
+	// ListActive returns the active sessions of userID, newest first.
+	ListActive(ctx context.Context, userID int64, now time.Time) ([]Session, error)
+	// Revoke revokes a session of userID, returning a [NotFoundError] when
+	// userID has no such active session.
+	Revoke(ctx context.Context, id, userID int64) error
+	// RevokeAll revokes every active session of userID except keep (0
+	// revokes them all).
+	RevokeAll(ctx context.Context, userID, keep int64) error
+}
+
+// Credentials are the login secrets of a user.
+type Credentials struct {
+	ID           int64
+	PasswordHash string
+	ValidFlag    bool
+	LockedUntil  *time.Time
+	TOTPEnabled  bool
+	// TOTPSecret is also set while an enrolment is in progress.
+	TOTPSecret string
+}
+
+// ResetRecipient is the user a password reset mail is sent to.
+type ResetRecipient struct {
+	ID    int64
+	Name  string
+	Email string
+}
+
+// CredentialRepository stores passwords, login failures, two-factor secrets
+// and password reset tokens. It is used before login and is not restricted
+// by the [Scope].
+type CredentialRepository interface {
+	// ByUserID returns a [NotFoundError] when no user has the login ID.
+	ByUserID(ctx context.Context, userID string) (Credentials, error)
+	ByID(ctx context.Context, id int64) (Credentials, error)
+	// LoginFailed counts a failed login, locking the user until lockUntil
+	// when the count reaches max.
+	LoginFailed(ctx context.Context, id int64, max int, lockUntil time.Time) error
+	// SetPassword replaces the password hash. With unlock set, a login lock
+	// is cleared as well.
+	SetPassword(ctx context.Context, id int64, hash string, unlock bool) error
+
+	// StartTOTP stores the secret of an enrolment unless two-factor
+	// authentication is already enabled.
+	StartTOTP(ctx context.Context, id int64, secret string) error
+	// EnableTOTP enables two-factor authentication, marking step as used.
+	EnableTOTP(ctx context.Context, id, step int64) error
+	// DisableTOTP clears the secret and deletes the recovery codes.
+	DisableTOTP(ctx context.Context, id int64) error
+	// UseTOTPStep records step as used and reports false when it, or a
+	// later step, was used before.
+	UseTOTPStep(ctx context.Context, id, step int64) (bool, error)
+	// ReplaceRecoveryCodes discards the recovery codes of the user and
+	// stores the given hashes.
+	ReplaceRecoveryCodes(ctx context.Context, id int64, hashes []string) error
+	// UseRecoveryCode marks an unused recovery code as used and reports
+	// false when there is none with the hash.
+	UseRecoveryCode(ctx context.Context, id int64, hash string, now time.Time) (bool, error)
+
+	// ResetRecipient returns the valid user registered with the address, or
+	// a [NotFoundError].
+	ResetRecipient(ctx context.Context, email string) (ResetRecipient, error)
+	// CreateResetToken invalidates the unused reset tokens of the user and
+	// stores a new one.
+	CreateResetToken(ctx context.Context, userID int64, tokenHash string, now, expiresAt time.Time) error
+	// ConsumeResetToken marks an unused, unexpired token of a valid user as
+	// used and returns the user's ID. It reports false when there is no such
+	// token. It must run in a transaction so that a token is used once.
+	ConsumeResetToken(ctx context.Context, tokenHash string, now time.Time) (int64, bool, error)
+
+	// ReplaceFeedToken stores the calendar feed token of the user, replacing
+	// the previous one.
+	ReplaceFeedToken(ctx context.Context, userID int64, tokenHash string) error
+	// DeleteFeedToken deletes the calendar feed token of the user and
+	// reports false when there was none.
+	DeleteFeedToken(ctx context.Context, userID int64) (bool, error)
+	// FeedTokenUser returns the valid owner of the calendar feed token with
+	// the hash, or a [NotFoundError].
+	FeedTokenUser(ctx context.Context, tokenHash string) (*AuthUser, error)
+}
+
+// PermissionRepository stores permissions_master and group_permissions.
+type PermissionRepository interface {
+	// List returns every permission ordered by code.
+	List(ctx context.Context) ([]Permission, error)
+	// FindGroup returns the version of the group, or a [NotFoundError]. With
+	// lock set, concurrent updates of the group wait for the transaction.
+	FindGroup(ctx context.Context, id int64, lock bool) (int64, error)
+	// GroupCodes returns the codes granted to a group in ascending order.
+	GroupCodes(ctx context.Context, groupID int64) ([]string, error)
+	// SetGroupCodes replaces the permissions of a group and increments the
+	// version of the group. Unknown codes are reported as a
+	// [ValidationError].
+	SetGroupCodes(ctx context.Context, groupID int64, codes []string) error
+	// Has reports whether the group has been granted the permission.
+	Has(ctx context.Context, groupID int64, code string) (bool, error)
+}
+
+// MailTemplate is a row of system_mail_settings_master.
+type MailTemplate struct {
+	Title string
+	Body  string
+	// From may be empty to use the default sender.
+	From string
+}
+
+// MailTemplateRepository stores system_mail_settings_master.
+type MailTemplateRepository interface {
+	// Find returns the valid template with the code and reports false when
+	// there is none.
+	Find(ctx context.Context, code string) (MailTemplate, bool, error)
+}
+
+// MasterFilter selects the rows returned by [MasterRepository.List].
+type MasterFilter struct {
+	// Sort is an exposed column of the table; rows are ordered by id after
+	// it. Empty orders by id only.
+	Sort   string
+	Desc   bool
+	Limit  int
+	Offset int
+}
+
+// MasterRepository stores the tables served by the master maintenance API.
+// Every method is restricted to the [Scope] of ctx.
+//
+// Records read hold the exposed columns of the table as JSON values:
+// integers as int64, decimals as json.Number, dates as "2006-01-02"
+// strings and date-times as [time.Time]. Values written are the arguments
+// returned by schema.Column.Convert, keyed by column name.
+type MasterRepository interface {
+	// List returns a page of rows and the number of rows in the table.
+	List(ctx context.Context, t *MasterTable, f MasterFilter) ([]MasterRecord, int, error)
+	// Find returns a [NotFoundError] when the row does not exist.
+	Find(ctx context.Context, t *MasterTable, id int64) (MasterRecord, error)
+	// Create inserts a row, writing the zero value to the NOT NULL columns
+	// that are not exposed, and returns its ID.
+	Create(ctx context.Context, t *MasterTable, values map[string]any) (int64, error)
+	// Lock locks the row until the end of the transaction and returns its
+	// version.
+	Lock(ctx context.Context, t *MasterTable, id int64) (int64, error)
+	// Update writes values to the row and increments its version, returning
+	// a [NotFoundError] when it does not exist.
+	Update(ctx context.Context, t *MasterTable, id int64, values map[string]any) error
+	// Delete deletes the row, returning a [NotFoundError] when it does not
+	// exist.
+	Delete(ctx context.Context, t *MasterTable, id int64) error
+	// Exists reports whether a row of table has value in column. It checks
+	// the target of a foreign key.
+	Exists(ctx context.Context, table, column string, value any) (bool, error)
+	// Options returns the id and name of every row, ordered by name, for the
+	// choices of a foreign key to t.
+	Options(ctx context.Context, t *MasterTable) ([]ColumnOption, error)
+}
+
+// AuditFilter selects the entries returned by [AuditRepository.List] and
+// [AuditRepository.Each]. Zero fields do not filter.
+type AuditFilter struct {
+	Table    string
+	RecordID *int64
+	UserID   *int64
+	// From and To bound occurred_at; To is exclusive.
+	From *time.Time
+	To   *time.Time
+	// Limit and Offset are ignored by Each.
+	Limit  int
+	Offset int
+}
+
+// AuditRepository stores audit_logs. Entries are restricted to the [Scope]
+// of ctx by the shipper of the user who made the change.
+type AuditRepository interface {
+	// Record stores an entry made by the caller, setting its shipper from
+	// the [Scope] of ctx. The ID of e is ignored. It must run in the
+	// transaction of the change.
+	Record(ctx context.Context, e AuditLog) error
+	// List returns a page of entries, newest first, and the number of
+	// entries matching f.
+	List(ctx context.Context, f AuditFilter) ([]AuditLog, int, error)
+	// Find returns a [NotFoundError] when the entry does not exist.
+	Find(ctx context.Context, id int64) (AuditLog, error)
+	// Each calls fn with every entry matching f, oldest first, stopping at
+	// the first error.
+	Each(ctx context.Context, f AuditFilter, fn func(AuditLog) error) error
+}
+
+// EventFilter selects the events returned by [EventRepository.List]. Zero
+// fields do not filter.
+type EventFilter struct {
+	// From and To bound the period the events must overlap; To is
+	// exclusive. An event without an end lasts for an instant.
+	From *time.Time
+	To   *time.Time
+	// Statuses matches any of the statuses.
+	Statuses []EventStatus
+	Kind     EventKind
+}
+
+// EventInput holds the writable columns of events.
+type EventInput struct {
+	// ShippingID is the shipper the event is for, or nil for all shippers.
+	ShippingID *int64
+	Title      string
+	Start      time.Time
+	// End is exclusive for all-day events.
+	End         *time.Time
+	AllDay      bool
+	Status      EventStatus
+	Kind        EventKind
+	Description *string
+	// RRule is the normalised recurrence rule of a recurring event, whose
+	// first occurrence is Start.
+	RRule *string
+	// ExDates are the dates, midnights in Asia/Tokyo, of the occurrences
+	// left out of a recurring event.
+	ExDates []time.Time
+	// BusinessDay is how occurrences falling on non-business days are
+	// handled.
+	BusinessDay EventBusinessDay
+}
+
+// EventRecord is a row of events.
+type EventRecord struct {
+	EventInput
+	ID      int64
+	Version int64
+	// Overrides are the rows of event_overrides of a recurring event,
+	// ordered by date.
+	Overrides []OccurrenceOverride
+}
+
+// OccurrenceOverride is a row of event_overrides: the changes to a single
+// occurrence of a recurring event. Nil fields keep the values of the event.
+type OccurrenceOverride struct {
+	// Date is the date the occurrence is generated for, a midnight in
+	// Asia/Tokyo.
+	Date        time.Time
+	Title       *string
+	Start       *time.Time
+	End         *time.Time
+	Status      *EventStatus
+	Description *string
+}
+
+// EventRepository stores events. Every method is restricted to the [Scope]
+// of ctx: shipper-side callers see the events of their shipper and the
+// events shared by all shippers.
+type EventRepository interface {
+	// List returns the events matching f ordered by start, then id.
+	// Recurring events starting before f.To are returned regardless of
+	// f.From and f.Statuses, which their occurrences must be checked
+	// against.
+	List(ctx context.Context, f EventFilter) ([]EventRecord, error)
+	// Find returns a [NotFoundError] when the event does not exist.
+	Find(ctx context.Context, id int64) (EventRecord, error)
+	// Create returns the ID of the new event, recording the caller as its
+	// creator.
+	Create(ctx context.Context, in EventInput) (int64, error)
+	// Lock locks the event until the end of the transaction and returns its
+	// version.
+	Lock(ctx context.Context, id int64) (int64, error)
+	// Update increments the version.
+	Update(ctx context.Context, id int64, in EventInput) error
+	// Delete deletes the overrides of the event with it.
+	Delete(ctx context.Context, id int64) error
+	// SetOverride replaces the override of the occurrence of o.Date and
+	// increments the version of the event.
+	SetOverride(ctx context.Context, id int64, o OccurrenceOverride) error
+	// DeleteOverride deletes the override of the occurrence of date, if
+	// any.
+	DeleteOverride(ctx context.Context, id int64, date time.Time) error
+}
+
+// BillingSchedule is a row of billings_master with the days it refers to.
+type BillingSchedule struct {
+	BillingID    int64
+	ShippingID   int64
+	ShippingName string
+	// ClosingDay and BillingDay are days of the month; days past the end
+	// of a month, such as 31, stand for its last day.
+	ClosingDay int
+	// BillingMonth is the name of billing_months_master, such as 当月.
+	BillingMonth string
+	BillingDay   int
+}
+
+// OrderDeadline is a row of order_deadlines_master.
+type OrderDeadline struct {
+	ID   int64
+	Name string
+	// Time is HH:MM. Hours of 24 or more are counted from the order day.
+	Time string
+}
+
+// ServiceBilling is a row of services_useds_master.
+type ServiceBilling struct {
+	ID          int64
+	Name        string
+	BillingDate time.Time
+}
+
+// CalendarRepository reads the master data the business calendar is derived
+// from. Every method is restricted to the [Scope] of ctx.
+// CalendarOverride is a row of business_calendars_master: a closure or an
+// extra working day of one warehouse, or of all of them.
+type CalendarOverride struct {
+	ID int64
+	// WarehouseCode is nil for the days of all warehouses.
+	WarehouseCode *string
+	Date          time.Time
+	Working       bool
+	Name          string
+}
+
+type CalendarRepository interface {
+	// BillingSchedules returns every row of billings_master in id order.
+	BillingSchedules(ctx context.Context) ([]BillingSchedule, error)
+	// OrderDeadlines returns the valid rows of order_deadlines_master in id
+	// order.
+	OrderDeadlines(ctx context.Context) ([]OrderDeadline, error)
+	// ServiceBillings returns the services billed on a day in [from, to),
+	// ordered by billing date, then id.
+	ServiceBillings(ctx context.Context, from, to time.Time) ([]ServiceBilling, error)
+	// Overrides returns the rows of business_calendars_master on a day in
+	// [from, to), ordered by date, then id.
+	Overrides(ctx context.Context, from, to time.Time) ([]CalendarOverride, error)
+}
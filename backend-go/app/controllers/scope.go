@@ -0,0 +1,79 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Scope is the set of shippers whose rows a request may read or modify.
+//
+// Users linked to a shipper (users_master.shipping_id) are confined to the
+// departments of that shipper. Other users, such as warehouse staff, and
+// requests made before login are not restricted.
+type Scope struct {
+	// ShippingID is the shipper the caller belongs to, or nil for all
+	// shippers.
+	ShippingID *int64
+}
+
+// scopeOf returns the scope of the user logged in to ctx.
+func scopeOf(ctx context.Context) Scope {
+	if au, ok := AuthUserFrom(ctx); ok {
+		return Scope{ShippingID: au.ShippingID}
+	}
+	return Scope{}
+}
+
+// departmentsOfShipper selects the departments_master ids of the shipper
+// bound to the placeholder.
+const departmentsOfShipper = "SELECT id FROM departments_master WHERE shipping_id = ?"
+
+// scopedTables maps each table owned by a shipper to the condition that
+// limits it to the shipper bound to the single placeholder. %s (or %[1]s when
+// used more than once) stands for the table qualifier, including its
+// trailing dot, and may be empty.
+//
+// Every query on these tables must go through [Scope.Filter]; a table missing
+// here is shared by all shippers.
+var scopedTables = map[string]string{
+	"shippings_master":      "%sid = ?",
+	"departments_master":    "%sshipping_id = ?",
+	"billings_master":       "%sshipping_id = ?",
+	"audit_logs":            "%sshipping_id = ?",
+	"events":                "(%[1]sshipping_id = ? OR %[1]sshipping_id IS NULL)", // NULL は全荷主共通
+	"users_master":          "%sdepartment_id IN (" + departmentsOfShipper + ")",
+	"items_master":          "%sdepartment_id IN (" + departmentsOfShipper + ")",
+	"set_items_master":      "%sdepartment_id IN (" + departmentsOfShipper + ")",
+	"approval_flows_master": "%sdepartment_id IN (" + departmentsOfShipper + ")",
+	"set_items_product_units_master": "%sset_item_id IN (SELECT s.id FROM set_items_master s " +
+		"INNER JOIN departments_master d ON d.id = s.department_id WHERE d.shipping_id = ?)",
+}
+
+// Filter returns the SQL condition restricting table, referred to as alias
+// (or unqualified when alias is empty), to the scope, and its arguments.
+// It returns an empty condition when nothing is filtered out.
+func (s Scope) Filter(table, alias string) (string, []any) {
+	cond, ok := scopedTables[table]
+	if !ok || s.ShippingID == nil {
+		return "", nil
+	}
+	if alias != "" {
+		alias += "."
+	}
+	return fmt.Sprintf(cond, alias), []any{*s.ShippingID}
+}
+
+// where joins conds and the scope filter of table into a WHERE clause,
+// returning "" when there is no condition at all. args holds the arguments
+// of conds; the filter arguments are appended to them.
+func (s Scope) where(table, alias string, conds []string, args []any) (string, []any) {
+	if cond, fargs := s.Filter(table, alias); cond != "" {
+		conds = append(conds, cond)
+		args = append(args, fargs...)
+	}
+	if len(conds) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(conds, " AND "), args
+}
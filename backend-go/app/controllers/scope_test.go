@@ -0,0 +1,307 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+// 共通ヘルパー: 荷主側ユーザとしてリクエストする
+func withShipper(req *http.Request, shippingID int64) *http.Request {
+	req = withAuth(req, 1, 10)
+	au, _ := AuthUserFrom(req.Context())
+	au.ShippingID = &shippingID
+	return req
+}
+
+// 荷主で絞り込む条件（users_master の所属部門）
+const usersOfShipper = `department_id IN \(SELECT id FROM departments_master WHERE shipping_id = \?\)`
+
+func TestScope_Filter(t *testing.T) {
+	shipper := int64(5)
+	tests := []struct {
+		name         string
+		scope        Scope
+		table, alias string
+		want         string
+	}{
+		{"warehouse staff", Scope{}, "users_master", "u", ""},
+		{"shared table", Scope{ShippingID: &shipper}, "groups_master", "g", ""},
+		{"department", Scope{ShippingID: &shipper}, "departments_master", "d", "d.shipping_id = ?"},
+		{"unqualified", Scope{ShippingID: &shipper}, "items_master", "",
+			"department_id IN (SELECT id FROM departments_master WHERE shipping_id = ?)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, args := tt.scope.Filter(tt.table, tt.alias)
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+			if (got == "") != (len(args) == 0) {
+				t.Errorf("unexpected args %v", args)
+			}
+		})
+	}
+}
+
+func TestScopeOf_Anonymous(t *testing.T) {
+	if s := scopeOf(httptest.NewRequest("GET", "/", nil).Context()); s.ShippingID != nil {
+		t.Errorf("requests without a login must not be scoped, got %v", *s.ShippingID)
+	}
+}
+
+func TestUsersController_ListUsers_Scoped(t *testing.T) {
+	ctrl, mock, teardown := setup(t)
+	defer teardown()
+
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM users_master u WHERE u." + usersOfShipper).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery("WHERE u."+usersOfShipper+" ORDER BY").
+		WithArgs(int64(1), 20, 0).
+		WillReturnRows(userRow(2, "Bob"))
+
+	req := withShipper(httptest.NewRequest("GET", "/users", nil), 1)
+	w := httptest.NewRecorder()
+	ctrl.ListUsers(w, req, ListUsersParams{})
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestUsersController_GetUser_OtherShipper(t *testing.T) {
+	ctrl, mock, teardown := setup(t)
+	defer teardown()
+
+	// 他荷主のユーザは検索条件で除外され、存在しないものとして扱う
+	mock.ExpectQuery("WHERE u.id = \\? AND u."+usersOfShipper).
+		WithArgs(int64(9), int64(1)).
+		WillReturnRows(sqlmock.NewRows(userRowColumns))
+
+	req := withShipper(httptest.NewRequest("GET", "/users/9", nil), 1)
+	w := httptest.NewRecorder()
+	ctrl.GetUser(w, req, 9)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404, got %d", w.Code)
+	}
+}
+
+func TestUsersController_GetUser_Warehouse(t *testing.T) {
+	ctrl, mock, teardown := setup(t)
+	defer teardown()
+
+	// 倉庫側ユーザは荷主で絞り込まない
+	mock.ExpectQuery("WHERE u.id = \\?$").WithArgs(int64(9)).WillReturnRows(userRow(9, "Carol"))
+
+	req := withAuth(httptest.NewRequest("GET", "/users/9", nil), 1, 10)
+	w := httptest.NewRecorder()
+	ctrl.GetUser(w, req, 9)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestUsersController_DeleteUser_OtherShipper(t *testing.T) {
+	ctrl, mock, teardown := setup(t)
+	defer teardown()
+
+	mock.ExpectBegin()
+	// 他荷主のユーザは行ロックの時点で見つからず 404
+	mock.ExpectQuery("SELECT version FROM users_master WHERE id = \\? AND "+usersOfShipper+" FOR UPDATE").
+		WithArgs(int64(9), int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}))
+	mock.ExpectRollback()
+
+	req := withShipper(httptest.NewRequest("DELETE", "/users/9", nil), 1)
+	w := httptest.NewRecorder()
+	ctrl.DeleteUser(w, req, 9, DeleteUserParams{IfMatch: ifMatchV1})
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404, got %d", w.Code)
+	}
+}
+
+func TestUsersController_UnlockUser_OtherShipper(t *testing.T) {
+	ctrl, mock, teardown := setup(t)
+	defer teardown()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("WHERE u.id = \\? AND u."+usersOfShipper).
+		WithArgs(int64(9), int64(1)).
+		WillReturnRows(sqlmock.NewRows(userRowColumns))
+	mock.ExpectRollback()
+
+	req := withShipper(httptest.NewRequest("DELETE", "/users/9/lock", nil), 1)
+	w := httptest.NewRecorder()
+	ctrl.UnlockUser(w, req, 9)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404, got %d", w.Code)
+	}
+}
+
+func TestUsersController_RevokeUserSessions_OtherShipper(t *testing.T) {
+	ctrl, mock, teardown := setup(t)
+	defer teardown()
+
+	mock.ExpectQuery("WHERE u.id = \\? AND u."+usersOfShipper).
+		WithArgs(int64(9), int64(1)).
+		WillReturnRows(sqlmock.NewRows(userRowColumns))
+
+	req := withShipper(httptest.NewRequest("DELETE", "/users/9/sessions", nil), 1)
+	w := httptest.NewRecorder()
+	ctrl.RevokeUserSessions(w, req, 9)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404, got %d", w.Code)
+	}
+}
+
+func TestUsersController_UpdateUser_OtherShipper(t *testing.T) {
+	ctrl, mock, teardown := setup(t)
+	defer teardown()
+
+	mock.ExpectQuery("SELECT 1 FROM groups_master").WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+	mock.ExpectQuery("SELECT 1 FROM departments_master WHERE id = \\? AND shipping_id = \\?").
+		WithArgs(int64(2), int64(1)).WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+	mock.ExpectQuery("SELECT 1 FROM shippings_master WHERE id = \\? AND id = \\?").
+		WithArgs(int64(1), int64(1)).WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+	mock.ExpectQuery("SELECT 1 FROM departments_master WHERE id = \\? AND shipping_id = \\?").
+		WithArgs(int64(2), int64(1)).WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+	mock.ExpectQuery("SELECT 1 FROM users_master WHERE user_id").WillReturnRows(sqlmock.NewRows([]string{"1"}))
+	mock.ExpectQuery("SELECT 1 FROM users_master WHERE email").WillReturnRows(sqlmock.NewRows([]string{"1"}))
+	mock.ExpectBegin()
+	// 更新対象が他荷主のユーザなら行ロックの時点で見つからず 404
+	mock.ExpectQuery("SELECT version FROM users_master WHERE id = \\? AND "+usersOfShipper+" FOR UPDATE").
+		WithArgs(int64(9), int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}))
+	mock.ExpectRollback()
+
+	req, w := newJSONRequest("PUT", "/users/9", UsersRequestPut{
+		GroupID: 1, UserID: "jdoe", Name: "John Doe", Email: "jdoe@example.com", DepartmentID: 2, ValidFlag: true,
+	})
+	ctrl.UpdateUser(w, withShipper(req, 1), 9, UpdateUserParams{IfMatch: ifMatchV1})
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestUsersController_CreateUser_OtherShipperDepartment(t *testing.T) {
+	ctrl, mock, teardown := setup(t)
+	defer teardown()
+
+	mock.ExpectQuery("SELECT 1 FROM groups_master").WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+	// 他荷主の部門は存在しないものとして 422
+	mock.ExpectQuery("SELECT 1 FROM departments_master WHERE id = \\? AND shipping_id = \\?").
+		WithArgs(int64(2), int64(1)).WillReturnRows(sqlmock.NewRows([]string{"1"}))
+
+	req, w := newJSONRequest("POST", "/users", newUserPost())
+	ctrl.CreateUser(w, withShipper(req, 1))
+
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected 422, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestUsersController_CreateUser_OtherShipper(t *testing.T) {
+	ctrl, mock, teardown := setup(t)
+	defer teardown()
+
+	mock.ExpectQuery("SELECT 1 FROM groups_master").WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+	mock.ExpectQuery("SELECT 1 FROM departments_master").WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+	mock.ExpectQuery("SELECT 1 FROM shippings_master WHERE id = \\? AND id = \\?").
+		WithArgs(int64(2), int64(1)).WillReturnRows(sqlmock.NewRows([]string{"1"}))
+
+	body := newUserPost()
+	body.ShippingID = ptr(int64(2))
+	req, w := newJSONRequest("POST", "/users", body)
+	ctrl.CreateUser(w, withShipper(req, 1))
+
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected 422, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestUsersController_CreateUser_DefaultsToOwnShipper(t *testing.T) {
+	ctrl, mock, teardown := setup(t)
+	defer teardown()
+
+	mock.ExpectQuery("SELECT 1 FROM groups_master").WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+	mock.ExpectQuery("SELECT 1 FROM departments_master").WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+	mock.ExpectQuery("SELECT 1 FROM shippings_master").WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+	mock.ExpectQuery("SELECT 1 FROM departments_master WHERE id = \\? AND shipping_id = \\?").
+		WithArgs(int64(2), int64(1)).WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+	mock.ExpectQuery("SELECT 1 FROM users_master WHERE user_id").WillReturnRows(sqlmock.NewRows([]string{"1"}))
+	mock.ExpectQuery("SELECT 1 FROM users_master WHERE email").WillReturnRows(sqlmock.NewRows([]string{"1"}))
+	// 付与先グループの権限は自分のグループの権限に含まれる
+	expectGroupCodes(mock, 0, "users:read", "users:write")
+	expectGroupCodes(mock, 1, "users:read")
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO users_master").
+		WithArgs(int64(1), "jdoe", "John Doe", "jdoe@example.com", int64(2), int64(1), true, nil).
+		WillReturnResult(sqlmock.NewResult(4, 1))
+	mock.ExpectQuery("SELECT u.id").WithArgs(int64(4), int64(1)).WillReturnRows(userRow(4, "John Doe"))
+	expectAudit(mock, AuditCreate, "users_master", 4)
+	mock.ExpectCommit()
+
+	req, w := newJSONRequest("POST", "/users", newUserPost())
+	ctrl.CreateUser(w, withShipper(req, 1))
+
+	if w.Code != http.StatusCreated {
+		t.Errorf("Expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestUsersController_CreateUser_GroupWithMorePermissions(t *testing.T) {
+	ctrl, mock, teardown := setup(t)
+	defer teardown()
+
+	mock.ExpectQuery("SELECT 1 FROM groups_master").WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+	mock.ExpectQuery("SELECT 1 FROM departments_master").WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+	mock.ExpectQuery("SELECT 1 FROM shippings_master").WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+	mock.ExpectQuery("SELECT 1 FROM departments_master WHERE id = \\? AND shipping_id = \\?").
+		WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+	mock.ExpectQuery("SELECT 1 FROM users_master WHERE user_id").WillReturnRows(sqlmock.NewRows([]string{"1"}))
+	mock.ExpectQuery("SELECT 1 FROM users_master WHERE email").WillReturnRows(sqlmock.NewRows([]string{"1"}))
+	// 荷主側の users:write では自分にない権限を持つグループのユーザを作れない
+	expectGroupCodes(mock, 0, "users:read", "users:write")
+	expectGroupCodes(mock, 1, "masters:write", "users:read", "users:write")
+
+	req, w := newJSONRequest("POST", "/users", newUserPost())
+	ctrl.CreateUser(w, withShipper(req, 1))
+
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("Expected 403, got %d: %s", w.Code, w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), "masters:write") {
+		t.Errorf("unexpected message: %s", w.Body.String())
+	}
+}
+
+func TestUsersController_CreateUser_DepartmentOfOtherShipper(t *testing.T) {
+	ctrl, mock, teardown := setup(t)
+	defer teardown()
+
+	// 倉庫側ユーザが登録する場合も、部門は指定した荷主のものでなければならない
+	mock.ExpectQuery("SELECT 1 FROM groups_master").WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+	mock.ExpectQuery("SELECT 1 FROM departments_master WHERE id = \\?$").WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+	mock.ExpectQuery("SELECT 1 FROM shippings_master WHERE id = \\?$").WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+	mock.ExpectQuery("SELECT 1 FROM departments_master WHERE id = \\? AND shipping_id = \\?").
+		WithArgs(int64(2), int64(3)).WillReturnRows(sqlmock.NewRows([]string{"1"}))
+
+	body := newUserPost()
+	body.ShippingID = ptr(int64(3))
+	req, w := newJSONRequest("POST", "/users", body)
+	ctrl.CreateUser(w, withAuth(req, 1, 10))
+
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected 422, got %d: %s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,17 @@
+package controllers
+
+// Server implements [ServerInterface] by embedding one controller per
+// resource. Pass it to [HandlerWithOptions].
+type Server struct {
+	*AuditController
+	*AuthController
+	*BusinessDaysController
+	*CalendarFeedController
+	*EventsController
+	*ImportController
+	*MastersController
+	*PermissionsController
+	*UsersController
+}
+
+var _ ServerInterface = (*Server)(nil)
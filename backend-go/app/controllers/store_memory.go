@@ -0,0 +1,1480 @@
+package controllers
+
+import (
+	"backend-go/schema"
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MemoryStore is a [Store] keeping its data in memory, for tests. It
+// enforces the [Scope] and the unique keys of the MySQL schema, and rolls
+// back failed transactions, but runs transactions one at a time.
+//
+// Reference data is seeded with the Add methods; users are created through
+// [UserRepository.Create].
+type MemoryStore struct {
+	// mu guards d of the store returned by [NewMemoryStore]. It is held for
+	// the whole of a transaction.
+	mu *sync.Mutex
+	d  *memoryData
+	// bound is set on the store passed to a [MemoryStore.WithTx] callback,
+	// whose d is a copy committed when the callback succeeds.
+	bound bool
+}
+
+var _ Store = (*MemoryStore)(nil)
+
+// NewMemoryStore returns an empty store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{mu: &sync.Mutex{}, d: &memoryData{
+		groups:        map[int64]string{},
+		groupVersions: map[int64]int64{},
+		shippings:     map[int64]string{},
+		departments:   map[int64]memoryDepartment{},
+		users:         map[int64]memoryUser{},
+		sessions:      map[int64]memorySession{},
+		resetTokens:   map[int64]memoryResetToken{},
+		feedTokens:    map[int64]string{},
+		permissions:   map[int64]Permission{},
+		grants:        map[[2]int64]bool{},
+		mailTemplates: map[string]MailTemplate{},
+		masters:       map[string]map[int64]MasterRecord{},
+		events:        map[int64]EventRecord{},
+	}}
+}
+
+// memoryData holds the rows of a [MemoryStore]. All values are copied by
+// value so that clone yields an independent snapshot.
+type memoryData struct {
+	// lastID is the last ID handed out by next, shared by all tables.
+	lastID        int64
+	groups        map[int64]string
+	groupVersions map[int64]int64
+	shippings     map[int64]string
+	departments   map[int64]memoryDepartment
+	users         map[int64]memoryUser
+	sessions      map[int64]memorySession
+	recoveryCodes []memoryRecoveryCode
+	resetTokens   map[int64]memoryResetToken
+	// feedTokens maps user IDs to the hashes of their calendar feed tokens.
+	feedTokens  map[int64]string
+	permissions map[int64]Permission
+	// grants holds the {group id, permission id} pairs of group_permissions.
+	grants        map[[2]int64]bool
+	mailTemplates map[string]MailTemplate
+	// masters holds the rows written through [MasterRepository] by table.
+	// Records are replaced, never modified.
+	masters map[string]map[int64]MasterRecord
+	// audit holds audit_logs in insertion order.
+	audit  []memoryAuditLog
+	events map[int64]EventRecord
+}
+
+type memoryDepartment struct {
+	Name       string
+	ShippingID int64
+}
+
+type memoryUser struct {
+	UserInput
+	ID           int64
+	Version      int64
+	FailedLogins int
+	LockedUntil  *time.Time
+	TOTPEnabled  bool
+	TOTPSecret   string
+	TOTPLastStep *int64
+}
+
+type memorySession struct {
+	NewSession
+	ID        int64
+	CreatedAt time.Time
+	RevokedAt *time.Time
+}
+
+type memoryRecoveryCode struct {
+	UserID int64
+	Hash   string
+	UsedAt *time.Time
+}
+
+type memoryAuditLog struct {
+	AuditLog
+	ShippingID *int64
+}
+
+type memoryResetToken struct {
+	UserID    int64
+	Hash      string
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+}
+
+func (d *memoryData) clone() *memoryData {
+	c := *d
+	c.groups = maps.Clone(d.groups)
+	c.groupVersions = maps.Clone(d.groupVersions)
+	c.shippings = maps.Clone(d.shippings)
+	c.departments = maps.Clone(d.departments)
+	c.users = maps.Clone(d.users)
+	c.sessions = maps.Clone(d.sessions)
+	c.recoveryCodes = slices.Clone(d.recoveryCodes)
+	c.resetTokens = maps.Clone(d.resetTokens)
+	c.feedTokens = maps.Clone(d.feedTokens)
+	c.permissions = maps.Clone(d.permissions)
+	c.grants = maps.Clone(d.grants)
+	c.mailTemplates = maps.Clone(d.mailTemplates)
+	c.audit = slices.Clone(d.audit)
+	c.events = maps.Clone(d.events)
+	c.masters = make(map[string]map[int64]MasterRecord, len(d.masters))
+	for table, rows := range d.masters {
+		c.masters[table] = maps.Clone(rows)
+	}
+	return &c
+}
+
+// next returns a new ID.
+func (d *memoryData) next() int64 {
+	d.lastID++
+	return d.lastID
+}
+
+// seen keeps IDs given by the caller from being handed out by next.
+func (d *memoryData) seen(id int64) {
+	d.lastID = max(d.lastID, id)
+}
+
+// inScope reports whether the user belongs to the shipper of the scope.
+func (d *memoryData) inScope(sc Scope, u memoryUser) bool {
+	return sc.ShippingID == nil || d.departments[u.DepartmentID].ShippingID == *sc.ShippingID
+}
+
+// user returns the row of users_master as returned by the API. An expired
+// lock is reported as no lock.
+func (d *memoryData) user(u memoryUser) User {
+	out := User{
+		ID:             u.ID,
+		GroupID:        u.GroupID,
+		GroupName:      d.groups[u.GroupID],
+		UserID:         u.UserID,
+		Name:           u.Name,
+		Email:          u.Email,
+		DepartmentID:   u.DepartmentID,
+		DepartmentName: d.departments[u.DepartmentID].Name,
+		ShippingID:     u.ShippingID,
+		ValidFlag:      u.ValidFlag,
+		TotpEnabled:    u.TOTPEnabled,
+		Version:        u.Version,
+	}
+	if u.LockedUntil != nil && u.LockedUntil.After(time.Now()) {
+		out.LockedUntil = u.LockedUntil
+	}
+	return out
+}
+
+// do runs fn with the data of s, locking it unless s is bound to a
+// transaction, which holds the lock already.
+func (s *MemoryStore) do(fn func(d *memoryData) error) error {
+	if !s.bound {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+	}
+	return fn(s.d)
+}
+
+// AddGroup adds a row to groups_master.
+func (s *MemoryStore) AddGroup(id int64, name string) {
+	s.do(func(d *memoryData) error {
+		d.seen(id)
+		d.groups[id] = name
+		d.groupVersions[id] = 1
+		return nil
+	})
+}
+
+// AddShipping adds a row to shippings_master.
+func (s *MemoryStore) AddShipping(id int64, name string) {
+	s.do(func(d *memoryData) error {
+		d.seen(id)
+		d.shippings[id] = name
+		return nil
+	})
+}
+
+// AddDepartment adds a row to departments_master belonging to the shipper.
+func (s *MemoryStore) AddDepartment(id int64, name string, shippingID int64) {
+	s.do(func(d *memoryData) error {
+		d.seen(id)
+		d.departments[id] = memoryDepartment{Name: name, ShippingID: shippingID}
+		return nil
+	})
+}
+
+// AddPermission adds a row to permissions_master.
+func (s *MemoryStore) AddPermission(p Permission) {
+	s.do(func(d *memoryData) error {
+		d.seen(p.ID)
+		d.permissions[p.ID] = p
+		return nil
+	})
+}
+
+// Grant grants the permissions with the codes to a group.
+func (s *MemoryStore) Grant(groupID int64, codes ...string) {
+	s.do(func(d *memoryData) error {
+		for _, p := range d.permissions {
+			if slices.Contains(codes, p.Code) {
+				d.grants[[2]int64{groupID, p.ID}] = true
+			}
+		}
+		return nil
+	})
+}
+
+// AddMailTemplate adds a valid row to system_mail_settings_master.
+func (s *MemoryStore) AddMailTemplate(code string, t MailTemplate) {
+	s.do(func(d *memoryData) error {
+		d.mailTemplates[code] = t
+		return nil
+	})
+}
+
+func (s *MemoryStore) Users() UserRepository                 { return memoryUsers{s} }
+func (s *MemoryStore) Sessions() SessionRepository           { return memorySessions{s} }
+func (s *MemoryStore) Credentials() CredentialRepository     { return memoryCredentials{s} }
+func (s *MemoryStore) Permissions() PermissionRepository     { return memoryPermissions{s} }
+func (s *MemoryStore) MailTemplates() MailTemplateRepository { return memoryMailTemplates{s} }
+func (s *MemoryStore) Masters() MasterRepository             { return memoryMasters{s} }
+func (s *MemoryStore) Audit() AuditRepository                { return memoryAudit{s} }
+func (s *MemoryStore) Events() EventRepository               { return memoryEvents{s} }
+func (s *MemoryStore) Calendar() CalendarRepository          { return memoryCalendar{s} }
+
+// WithTx implements [Store]. fn works on a copy of the data, which replaces
+// the data when fn succeeds.
+func (s *MemoryStore) WithTx(ctx context.Context, fn func(tx Store) error) error {
+	if s.bound {
+		return fn(s)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	tx := &MemoryStore{mu: s.mu, d: s.d.clone(), bound: true}
+	if err := fn(tx); err != nil {
+		return err
+	}
+	s.d = tx.d
+	return nil
+}
+
+// memoryUsers is the [UserRepository] of [MemoryStore].
+type memoryUsers struct {
+	s *MemoryStore
+}
+
+// userSortKeys orders users like [userSortColumns].
+var userSortKeys = map[ListUsersParamsSort]func(a, b User) int{
+	UserSortIDAsc:      func(a, b User) int { return cmp.Compare(a.ID, b.ID) },
+	UserSortIDDesc:     func(a, b User) int { return cmp.Compare(b.ID, a.ID) },
+	UserSortUserIDAsc:  func(a, b User) int { return cmp.Compare(a.UserID, b.UserID) },
+	UserSortUserIDDesc: func(a, b User) int { return cmp.Compare(b.UserID, a.UserID) },
+	UserSortNameAsc: func(a, b User) int {
+		return cmp.Or(cmp.Compare(a.Name, b.Name), cmp.Compare(a.ID, b.ID))
+	},
+	UserSortNameDesc: func(a, b User) int {
+		return cmp.Or(cmp.Compare(b.Name, a.Name), cmp.Compare(a.ID, b.ID))
+	},
+}
+
+func (r memoryUsers) List(ctx context.Context, f UserFilter) ([]User, int, error) {
+	sort := userSortKeys[UserSortIDAsc]
+	if f.Sort != "" {
+		var ok bool
+		if sort, ok = userSortKeys[f.Sort]; !ok {
+			return nil, 0, &BadRequestError{Err: fmt.Errorf("unknown sort %q", f.Sort)}
+		}
+	}
+	sc := scopeOf(ctx)
+	users := []User{}
+	err := r.s.do(func(d *memoryData) error {
+		for _, u := range d.users {
+			if !d.inScope(sc, u) ||
+				f.Q != "" && !strings.Contains(u.Name, f.Q) && !strings.Contains(u.UserID, f.Q) && !strings.Contains(u.Email, f.Q) ||
+				f.GroupID != nil && u.GroupID != *f.GroupID ||
+				f.DepartmentID != nil && u.DepartmentID != *f.DepartmentID ||
+				f.ValidFlag != nil && u.ValidFlag != *f.ValidFlag {
+				continue
+			}
+			users = append(users, d.user(u))
+		}
+		return nil
+	})
+	slices.SortFunc(users, sort)
+	total := len(users)
+	start := min(f.Offset, total)
+	return users[start:min(start+f.Limit, total)], total, err
+}
+
+func (r memoryUsers) Find(ctx context.Context, id int64) (User, error) {
+	var out User
+	err := r.s.do(func(d *memoryData) error {
+		u, ok := d.users[id]
+		if !ok || !d.inScope(scopeOf(ctx), u) {
+			return &NotFoundError{Resource: "user", ID: id}
+		}
+		out = d.user(u)
+		return nil
+	})
+	return out, err
+}
+
+func (r memoryUsers) Create(ctx context.Context, in UserInput) (int64, error) {
+	var id int64
+	err := r.s.do(func(d *memoryData) error {
+		if err := d.checkUser(0, in); err != nil {
+			return err
+		}
+		id = d.next()
+		d.users[id] = memoryUser{UserInput: in, ID: id, Version: 1}
+		return nil
+	})
+	return id, err
+}
+
+func (r memoryUsers) Update(ctx context.Context, id int64, in UserInput) error {
+	return r.update(ctx, id, func(d *memoryData, u *memoryUser) error {
+		if err := d.checkUser(id, in); err != nil {
+			return err
+		}
+		// パスワード未指定（nil）の場合は現在のハッシュを維持する
+		if in.PasswordHash == nil {
+			in.PasswordHash = u.PasswordHash
+		}
+		u.UserInput = in
+		u.Version++
+		return nil
+	})
+}
+
+func (r memoryUsers) Lock(ctx context.Context, id int64) (int64, error) {
+	var version int64
+	err := r.update(ctx, id, func(_ *memoryData, u *memoryUser) error {
+		version = u.Version
+		return nil
+	})
+	return version, err
+}
+
+func (r memoryUsers) Disable(ctx context.Context, id int64) error {
+	return r.update(ctx, id, func(_ *memoryData, u *memoryUser) error {
+		u.ValidFlag = false
+		u.Version++
+		return nil
+	})
+}
+
+func (r memoryUsers) Unlock(ctx context.Context, id int64) error {
+	return r.update(ctx, id, func(_ *memoryData, u *memoryUser) error {
+		u.FailedLogins, u.LockedUntil = 0, nil
+		return nil
+	})
+}
+
+// update applies fn to the user identified by id within the scope.
+func (r memoryUsers) update(ctx context.Context, id int64, fn func(d *memoryData, u *memoryUser) error) error {
+	return r.s.do(func(d *memoryData) error {
+		u, ok := d.users[id]
+		if !ok || !d.inScope(scopeOf(ctx), u) {
+			return &NotFoundError{Resource: "user", ID: id}
+		}
+		if err := fn(d, &u); err != nil {
+			return err
+		}
+		d.users[id] = u
+		return nil
+	})
+}
+
+// checkUser enforces the unique keys and foreign keys of users_master the
+// way MySQL reports them through [writeError].
+func (d *memoryData) checkUser(id int64, in UserInput) error {
+	for _, u := range d.users {
+		if u.ID != id && (u.UserID == in.UserID || u.Email == in.Email) {
+			return &ConflictError{Message: "duplicate entry"}
+		}
+	}
+	_, group := d.groups[in.GroupID]
+	_, department := d.departments[in.DepartmentID]
+	shipping := true
+	if in.ShippingID != nil {
+		_, shipping = d.shippings[*in.ShippingID]
+	}
+	if !group || !department || !shipping {
+		return &ValidationError{Message: "referenced record does not exist"}
+	}
+	return nil
+}
+
+func (r memoryUsers) GroupExists(ctx context.Context, id int64) (bool, error) {
+	var ok bool
+	err := r.s.do(func(d *memoryData) error {
+		_, ok = d.groups[id]
+		return nil
+	})
+	return ok, err
+}
+
+func (r memoryUsers) DepartmentExists(ctx context.Context, id int64) (bool, error) {
+	var ok bool
+	err := r.s.do(func(d *memoryData) error {
+		dep, found := d.departments[id]
+		sc := scopeOf(ctx)
+		ok = found && (sc.ShippingID == nil || dep.ShippingID == *sc.ShippingID)
+		return nil
+	})
+	return ok, err
+}
+
+func (r memoryUsers) ShippingExists(ctx context.Context, id int64) (bool, error) {
+	var ok bool
+	err := r.s.do(func(d *memoryData) error {
+		_, found := d.shippings[id]
+		sc := scopeOf(ctx)
+		ok = found && (sc.ShippingID == nil || id == *sc.ShippingID)
+		return nil
+	})
+	return ok, err
+}
+
+func (r memoryUsers) DepartmentOfShipping(ctx context.Context, departmentID, shippingID int64) (bool, error) {
+	var ok bool
+	err := r.s.do(func(d *memoryData) error {
+		dep, found := d.departments[departmentID]
+		ok = found && dep.ShippingID == shippingID
+		return nil
+	})
+	return ok, err
+}
+
+func (r memoryUsers) UserIDTaken(ctx context.Context, userID string, exceptID int64) (bool, error) {
+	return r.taken(func(u memoryUser) bool { return u.UserID == userID && u.ID != exceptID })
+}
+
+func (r memoryUsers) EmailTaken(ctx context.Context, email string, exceptID int64) (bool, error) {
+	return r.taken(func(u memoryUser) bool { return u.Email == email && u.ID != exceptID })
+}
+
+// taken reports whether any user, regardless of the scope, matches.
+func (r memoryUsers) taken(match func(u memoryUser) bool) (bool, error) {
+	var ok bool
+	err := r.s.do(func(d *memoryData) error {
+		for _, u := range d.users {
+			ok = ok || match(u)
+		}
+		return nil
+	})
+	return ok, err
+}
+
+// memorySessions is the [SessionRepository] of [MemoryStore].
+type memorySessions struct {
+	s *MemoryStore
+}
+
+func (r memorySessions) Lookup(ctx context.Context, tokenHash string, now time.Time) (*AuthUser, error) {
+	var au *AuthUser
+	err := r.s.do(func(d *memoryData) error {
+		for _, s := range d.sessions {
+			u, ok := d.users[s.UserID]
+			if s.TokenHash == tokenHash && s.RevokedAt == nil && s.ExpiresAt.After(now) && ok && u.ValidFlag {
+				au = &AuthUser{User: d.user(u), SessionID: s.ID}
+				return nil
+			}
+		}
+		return &UnauthorizedError{Message: "session is invalid or expired"}
+	})
+	return au, err
+}
+
+func (r memorySessions) Create(ctx context.Context, s NewSession) error {
+	return r.s.do(func(d *memoryData) error {
+		id := d.next()
+		d.sessions[id] = memorySession{NewSession: s, ID: id, CreatedAt: time.Now()}
+		return nil
+	})
+}
+
+func (r memorySessions) DeleteExpired(ctx context.Context, userID int64, now time.Time) error {
+	return r.s.do(func(d *memoryData) error {
+		maps.DeleteFunc(d.sessions, func(_ int64, s memorySession) bool {
+			return s.UserID == userID && !s.ExpiresAt.After(now)
+		})
+		return nil
+	})
+}
+
+func (r memorySessions) ListActive(ctx context.Context, userID int64, now time.Time) ([]Session, error) {
+	sessions := []Session{}
+	err := r.s.do(func(d *memoryData) error {
+		for _, s := range d.sessions {
+			if s.UserID != userID || s.RevokedAt != nil || !s.ExpiresAt.After(now) {
+				continue
+			}
+			out := Session{ID: s.ID, CreatedAt: s.CreatedAt, ExpiresAt: s.ExpiresAt}
+			if s.UserAgent != "" {
+				out.UserAgent = &s.UserAgent
+			}
+			if s.IPAddress != "" {
+				out.IPAddress = &s.IPAddress
+			}
+			sessions = append(sessions, out)
+		}
+		return nil
+	})
+	slices.SortFunc(sessions, func(a, b Session) int {
+		return cmp.Or(b.CreatedAt.Compare(a.CreatedAt), cmp.Compare(b.ID, a.ID))
+	})
+	return sessions, err
+}
+
+func (r memorySessions) Revoke(ctx context.Context, id, userID int64) error {
+	return r.s.do(func(d *memoryData) error {
+		s, ok := d.sessions[id]
+		if !ok || s.UserID != userID || s.RevokedAt != nil {
+			return &NotFoundError{Resource: "session", ID: id}
+		}
+		now := time.Now()
+		s.RevokedAt = &now
+		d.sessions[id] = s
+		return nil
+	})
+}
+
+func (r memorySessions) RevokeAll(ctx context.Context, userID, keep int64) error {
+	return r.s.do(func(d *memoryData) error {
+		now := time.Now()
+		for id, s := range d.sessions {
+			if s.UserID == userID && id != keep && s.RevokedAt == nil {
+				s.RevokedAt = &now
+				d.sessions[id] = s
+			}
+		}
+		return nil
+	})
+}
+
+// memoryCredentials is the [CredentialRepository] of [MemoryStore].
+type memoryCredentials struct {
+	s *MemoryStore
+}
+
+func (r memoryCredentials) ByUserID(ctx context.Context, userID string) (Credentials, error) {
+	var c Credentials
+	err := r.s.do(func(d *memoryData) error {
+		for _, u := range d.users {
+			if u.UserID == userID {
+				c = u.credentials()
+				return nil
+			}
+		}
+		return &NotFoundError{Resource: "user", ID: userID}
+	})
+	return c, err
+}
+
+func (r memoryCredentials) ByID(ctx context.Context, id int64) (Credentials, error) {
+	var c Credentials
+	err := r.update(id, func(u *memoryUser) {
+		c = u.credentials()
+	})
+	return c, err
+}
+
+func (u memoryUser) credentials() Credentials {
+	c := Credentials{
+		ID:          u.ID,
+		ValidFlag:   u.ValidFlag,
+		LockedUntil: u.LockedUntil,
+		TOTPEnabled: u.TOTPEnabled,
+		TOTPSecret:  u.TOTPSecret,
+	}
+	if u.PasswordHash != nil {
+		c.PasswordHash = *u.PasswordHash
+	}
+	return c
+}
+
+// update applies fn to the user identified by id, regardless of the scope.
+func (r memoryCredentials) update(id int64, fn func(u *memoryUser)) error {
+	return r.s.do(func(d *memoryData) error {
+		u, ok := d.users[id]
+		if !ok {
+			return &NotFoundError{Resource: "user", ID: id}
+		}
+		fn(&u)
+		d.users[id] = u
+		return nil
+	})
+}
+
+// ignoreMissing drops the [NotFoundError] of an update that, in SQL, would
+// simply change no rows.
+func ignoreMissing(err error) error {
+	if _, ok := err.(*NotFoundError); ok {
+		return nil
+	}
+	return err
+}
+
+func (r memoryCredentials) LoginFailed(ctx context.Context, id int64, max int, lockUntil time.Time) error {
+	return ignoreMissing(r.update(id, func(u *memoryUser) {
+		u.FailedLogins++
+		if u.FailedLogins >= max {
+			u.FailedLogins, u.LockedUntil = 0, &lockUntil
+		}
+	}))
+}
+
+func (r memoryCredentials) SetPassword(ctx context.Context, id int64, hash string, unlock bool) error {
+	return ignoreMissing(r.update(id, func(u *memoryUser) {
+		u.PasswordHash = &hash
+		if unlock {
+			u.FailedLogins, u.LockedUntil = 0, nil
+		}
+	}))
+}
+
+func (r memoryCredentials) StartTOTP(ctx context.Context, id int64, secret string) error {
+	return ignoreMissing(r.update(id, func(u *memoryUser) {
+		if !u.TOTPEnabled {
+			u.TOTPSecret, u.TOTPLastStep = secret, nil
+		}
+	}))
+}
+
+func (r memoryCredentials) EnableTOTP(ctx context.Context, id, step int64) error {
+	return ignoreMissing(r.update(id, func(u *memoryUser) {
+		u.TOTPEnabled, u.TOTPLastStep = true, &step
+	}))
+}
+
+func (r memoryCredentials) DisableTOTP(ctx context.Context, id int64) error {
+	return r.s.do(func(d *memoryData) error {
+		if u, ok := d.users[id]; ok {
+			u.TOTPSecret, u.TOTPEnabled, u.TOTPLastStep = "", false, nil
+			d.users[id] = u
+		}
+		d.recoveryCodes = slices.DeleteFunc(d.recoveryCodes, func(c memoryRecoveryCode) bool { return c.UserID == id })
+		return nil
+	})
+}
+
+func (r memoryCredentials) UseTOTPStep(ctx context.Context, id, step int64) (bool, error) {
+	var used bool
+	err := r.update(id, func(u *memoryUser) {
+		if u.TOTPLastStep == nil || *u.TOTPLastStep < step {
+			u.TOTPLastStep, used = &step, true
+		}
+	})
+	return used, ignoreMissing(err)
+}
+
+func (r memoryCredentials) ReplaceRecoveryCodes(ctx context.Context, id int64, hashes []string) error {
+	return r.s.do(func(d *memoryData) error {
+		d.recoveryCodes = slices.DeleteFunc(d.recoveryCodes, func(c memoryRecoveryCode) bool { return c.UserID == id })
+		for _, hash := range hashes {
+			d.recoveryCodes = append(d.recoveryCodes, memoryRecoveryCode{UserID: id, Hash: hash})
+		}
+		return nil
+	})
+}
+
+func (r memoryCredentials) UseRecoveryCode(ctx context.Context, id int64, hash string, now time.Time) (bool, error) {
+	var used bool
+	err := r.s.do(func(d *memoryData) error {
+		for i, c := range d.recoveryCodes {
+			if c.UserID == id && c.Hash == hash && c.UsedAt == nil {
+				d.recoveryCodes[i].UsedAt, used = &now, true
+				break
+			}
+		}
+		return nil
+	})
+	return used, err
+}
+
+func (r memoryCredentials) ResetRecipient(ctx context.Context, email string) (ResetRecipient, error) {
+	var out ResetRecipient
+	err := r.s.do(func(d *memoryData) error {
+		for _, u := range d.users {
+			if u.Email == email && u.ValidFlag {
+				out = ResetRecipient{ID: u.ID, Name: u.Name, Email: u.Email}
+				return nil
+			}
+		}
+		return &NotFoundError{Resource: "user", ID: email}
+	})
+	return out, err
+}
+
+func (r memoryCredentials) CreateResetToken(ctx context.Context, userID int64, tokenHash string, now, expiresAt time.Time) error {
+	return r.s.do(func(d *memoryData) error {
+		for id, t := range d.resetTokens {
+			if t.UserID == userID && t.UsedAt == nil {
+				t.UsedAt = &now
+				d.resetTokens[id] = t
+			}
+		}
+		d.resetTokens[d.next()] = memoryResetToken{UserID: userID, Hash: tokenHash, ExpiresAt: expiresAt}
+		return nil
+	})
+}
+
+func (r memoryCredentials) ConsumeResetToken(ctx context.Context, tokenHash string, now time.Time) (int64, bool, error) {
+	var userID int64
+	var ok bool
+	err := r.s.do(func(d *memoryData) error {
+		for id, t := range d.resetTokens {
+			if t.Hash == tokenHash && t.UsedAt == nil && t.ExpiresAt.After(now) && d.users[t.UserID].ValidFlag {
+				t.UsedAt = &now
+				d.resetTokens[id] = t
+				userID, ok = t.UserID, true
+				return nil
+			}
+		}
+		return nil
+	})
+	return userID, ok, err
+}
+
+func (r memoryCredentials) ReplaceFeedToken(ctx context.Context, userID int64, tokenHash string) error {
+	return r.s.do(func(d *memoryData) error {
+		d.feedTokens[userID] = tokenHash
+		return nil
+	})
+}
+
+func (r memoryCredentials) DeleteFeedToken(ctx context.Context, userID int64) (bool, error) {
+	var ok bool
+	err := r.s.do(func(d *memoryData) error {
+		_, ok = d.feedTokens[userID]
+		delete(d.feedTokens, userID)
+		return nil
+	})
+	return ok, err
+}
+
+func (r memoryCredentials) FeedTokenUser(ctx context.Context, tokenHash string) (*AuthUser, error) {
+	var au *AuthUser
+	err := r.s.do(func(d *memoryData) error {
+		for userID, hash := range d.feedTokens {
+			if u, ok := d.users[userID]; ok && hash == tokenHash && u.ValidFlag {
+				au = &AuthUser{User: d.user(u)}
+				return nil
+			}
+		}
+		return &NotFoundError{Resource: "calendar feed", ID: "token"}
+	})
+	return au, err
+}
+
+// memoryPermissions is the [PermissionRepository] of [MemoryStore].
+type memoryPermissions struct {
+	s *MemoryStore
+}
+
+func (r memoryPermissions) List(ctx context.Context) ([]Permission, error) {
+	var permissions []Permission
+	err := r.s.do(func(d *memoryData) error {
+		permissions = slices.SortedFunc(maps.Values(d.permissions), func(a, b Permission) int {
+			return cmp.Compare(a.Code, b.Code)
+		})
+		return nil
+	})
+	if permissions == nil {
+		permissions = []Permission{}
+	}
+	return permissions, err
+}
+
+func (r memoryPermissions) FindGroup(ctx context.Context, id int64, lock bool) (int64, error) {
+	var version int64
+	err := r.s.do(func(d *memoryData) error {
+		if _, ok := d.groups[id]; !ok {
+			return &NotFoundError{Resource: "group", ID: id}
+		}
+		version = d.groupVersions[id]
+		return nil
+	})
+	return version, err
+}
+
+func (r memoryPermissions) GroupCodes(ctx context.Context, groupID int64) ([]string, error) {
+	codes := []string{}
+	err := r.s.do(func(d *memoryData) error {
+		for key := range d.grants {
+			if key[0] == groupID {
+				codes = append(codes, d.permissions[key[1]].Code)
+			}
+		}
+		return nil
+	})
+	slices.Sort(codes)
+	return codes, err
+}
+
+func (r memoryPermissions) SetGroupCodes(ctx context.Context, groupID int64, codes []string) error {
+	return r.s.do(func(d *memoryData) error {
+		found := make(map[string]int64, len(d.permissions))
+		for _, p := range d.permissions {
+			found[p.Code] = p.ID
+		}
+		ids := make([]int64, 0, len(codes))
+		var unknown []string
+		for _, code := range codes {
+			if id, ok := found[code]; ok {
+				ids = append(ids, id)
+			} else {
+				unknown = append(unknown, code)
+			}
+		}
+		if len(unknown) > 0 {
+			return &ValidationError{Message: "unknown permission: " + strings.Join(unknown, ", ")}
+		}
+		d.groupVersions[groupID]++
+		maps.DeleteFunc(d.grants, func(key [2]int64, _ bool) bool { return key[0] == groupID })
+		for _, id := range ids {
+			d.grants[[2]int64{groupID, id}] = true
+		}
+		return nil
+	})
+}
+
+func (r memoryPermissions) Has(ctx context.Context, groupID int64, code string) (bool, error) {
+	var ok bool
+	err := r.s.do(func(d *memoryData) error {
+		for key := range d.grants {
+			ok = ok || key[0] == groupID && d.permissions[key[1]].Code == code
+		}
+		return nil
+	})
+	return ok, err
+}
+
+// memoryMailTemplates is the [MailTemplateRepository] of [MemoryStore].
+type memoryMailTemplates struct {
+	s *MemoryStore
+}
+
+func (r memoryMailTemplates) Find(ctx context.Context, code string) (MailTemplate, bool, error) {
+	var t MailTemplate
+	var ok bool
+	err := r.s.do(func(d *memoryData) error {
+		t, ok = d.mailTemplates[code]
+		return nil
+	})
+	return t, ok, err
+}
+
+// memoryMasters is the [MasterRepository] of [MemoryStore]. It enforces the
+// unique indexes, but not the foreign keys, of the tables.
+type memoryMasters struct {
+	s *MemoryStore
+}
+
+// memoryScopeRefs mirrors scopedTables: the column of each shipper-owned
+// table holding its shipper, or referring to the table that leads to it.
+var memoryScopeRefs = map[string]struct{ column, table string }{
+	"shippings_master":               {"id", ""},
+	"departments_master":             {"shipping_id", ""},
+	"billings_master":                {"shipping_id", ""},
+	"users_master":                   {"department_id", "departments_master"},
+	"items_master":                   {"department_id", "departments_master"},
+	"set_items_master":               {"department_id", "departments_master"},
+	"approval_flows_master":          {"department_id", "departments_master"},
+	"set_items_product_units_master": {"set_item_id", "set_items_master"},
+}
+
+// masterRow returns a row of table, including the groups, shippers,
+// departments and users seeded outside of [MasterRepository].
+func (d *memoryData) masterRow(table string, id int64) (MasterRecord, bool) {
+	if rec, ok := d.masters[table][id]; ok {
+		return rec, true
+	}
+	switch table {
+	case "groups_master":
+		if name, ok := d.groups[id]; ok {
+			return MasterRecord{"id": id, "name": name, "version": d.groupVersions[id]}, true
+		}
+	case "shippings_master":
+		if name, ok := d.shippings[id]; ok {
+			return MasterRecord{"id": id, "name": name}, true
+		}
+	case "departments_master":
+		if dep, ok := d.departments[id]; ok {
+			return MasterRecord{"id": id, "name": dep.Name, "shipping_id": dep.ShippingID}, true
+		}
+	case "users_master":
+		if u, ok := d.users[id]; ok {
+			return MasterRecord{"id": id, "department_id": u.DepartmentID}, true
+		}
+	}
+	return nil, false
+}
+
+// masterRows returns every row of table, including the seeded ones of
+// [memoryData.masterRow], in id order.
+func (d *memoryData) masterRows(table string) []MasterRecord {
+	ids := slices.Collect(maps.Keys(d.masters[table]))
+	switch table {
+	case "groups_master":
+		ids = slices.AppendSeq(ids, maps.Keys(d.groups))
+	case "shippings_master":
+		ids = slices.AppendSeq(ids, maps.Keys(d.shippings))
+	case "departments_master":
+		ids = slices.AppendSeq(ids, maps.Keys(d.departments))
+	case "users_master":
+		ids = slices.AppendSeq(ids, maps.Keys(d.users))
+	}
+	slices.Sort(ids)
+	rows := []MasterRecord{}
+	for _, id := range slices.Compact(ids) {
+		rec, _ := d.masterRow(table, id)
+		rows = append(rows, rec)
+	}
+	return rows
+}
+
+// masterInScope reports whether a row of table belongs to the shipper of
+// the scope.
+func (d *memoryData) masterInScope(sc Scope, table string, rec MasterRecord) bool {
+	ref, ok := memoryScopeRefs[table]
+	if sc.ShippingID == nil || !ok {
+		return true
+	}
+	id, _ := rec[ref.column].(int64)
+	if ref.table == "" {
+		return id == *sc.ShippingID
+	}
+	parent, ok := d.masterRow(ref.table, id)
+	return ok && d.masterInScope(sc, ref.table, parent)
+}
+
+// find returns a row of t within the scope of ctx.
+func (r memoryMasters) find(ctx context.Context, d *memoryData, t *MasterTable, id int64) (MasterRecord, error) {
+	rec, ok := d.masters[t.Name][id]
+	if !ok || !d.masterInScope(scopeOf(ctx), t.Name, rec) {
+		return nil, &NotFoundError{Resource: t.Name, ID: id}
+	}
+	return rec, nil
+}
+
+// put stores rec as the row id of t after checking the unique indexes.
+func (r memoryMasters) put(d *memoryData, t *MasterTable, id int64, rec MasterRecord) error {
+	for other, row := range d.masters[t.Name] {
+		for _, cols := range t.Unique {
+			if other != id && !slices.ContainsFunc(cols, func(c string) bool { return row[c] != rec[c] }) {
+				return &ConflictError{Message: "duplicate entry"}
+			}
+		}
+	}
+	if d.masters[t.Name] == nil {
+		d.masters[t.Name] = map[int64]MasterRecord{}
+	}
+	d.masters[t.Name][id] = rec
+	return nil
+}
+
+func (r memoryMasters) List(ctx context.Context, t *MasterTable, f MasterFilter) ([]MasterRecord, int, error) {
+	records := []MasterRecord{}
+	err := r.s.do(func(d *memoryData) error {
+		for _, rec := range d.masterRows(t.Name) {
+			if d.masterInScope(scopeOf(ctx), t.Name, rec) {
+				records = append(records, rec)
+			}
+		}
+		return nil
+	})
+	sort := cmp.Or(f.Sort, "id")
+	slices.SortFunc(records, func(a, b MasterRecord) int {
+		c := compareValues(a[sort], b[sort])
+		if f.Desc {
+			c = -c
+		}
+		return cmp.Or(c, cmp.Compare(a["id"].(int64), b["id"].(int64)))
+	})
+	total := len(records)
+	start := min(f.Offset, total)
+	return records[start:min(start+f.Limit, total)], total, err
+}
+
+// compareValues orders the values of a [MasterRecord] column like MySQL,
+// with NULL first.
+func compareValues(a, b any) int {
+	switch {
+	case a == nil || b == nil:
+		return cmp.Compare(boolInt(a != nil), boolInt(b != nil))
+	}
+	switch a := a.(type) {
+	case int64:
+		return cmp.Compare(a, b.(int64))
+	case bool:
+		return cmp.Compare(boolInt(a), boolInt(b.(bool)))
+	case time.Time:
+		return a.Compare(b.(time.Time))
+	case json.Number:
+		x, _ := a.Float64()
+		y, _ := b.(json.Number).Float64()
+		return cmp.Compare(x, y)
+	}
+	return cmp.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func (r memoryMasters) Find(ctx context.Context, t *MasterTable, id int64) (MasterRecord, error) {
+	var rec MasterRecord
+	err := r.s.do(func(d *memoryData) error {
+		var err error
+		rec, err = r.find(ctx, d, t, id)
+		return err
+	})
+	return rec, err
+}
+
+func (r memoryMasters) Create(ctx context.Context, t *MasterTable, values map[string]any) (int64, error) {
+	var id int64
+	err := r.s.do(func(d *memoryData) error {
+		next := d.lastID + 1
+		rec := MasterRecord{"id": next}
+		for _, c := range t.Columns[1:] {
+			v, ok := values[c.Name]
+			if !ok {
+				v = c.Default
+			}
+			rec[c.Name] = memoryValue(c, v)
+		}
+		if err := r.put(d, t, next, rec); err != nil {
+			return err
+		}
+		id = d.next()
+		return nil
+	})
+	return id, err
+}
+
+func (r memoryMasters) Lock(ctx context.Context, t *MasterTable, id int64) (int64, error) {
+	rec, err := r.Find(ctx, t, id)
+	if err != nil {
+		return 0, err
+	}
+	return recordVersion(rec), nil
+}
+
+func (r memoryMasters) Update(ctx context.Context, t *MasterTable, id int64, values map[string]any) error {
+	return r.s.do(func(d *memoryData) error {
+		old, err := r.find(ctx, d, t, id)
+		if err != nil {
+			return err
+		}
+		rec := maps.Clone(old)
+		rec[versionColumn] = recordVersion(old) + 1
+		for _, c := range t.Columns {
+			if v, ok := values[c.Name]; ok {
+				rec[c.Name] = memoryValue(c, v)
+			}
+		}
+		return r.put(d, t, id, rec)
+	})
+}
+
+func (r memoryMasters) Delete(ctx context.Context, t *MasterTable, id int64) error {
+	return r.s.do(func(d *memoryData) error {
+		if _, err := r.find(ctx, d, t, id); err != nil {
+			return err
+		}
+		delete(d.masters[t.Name], id)
+		return nil
+	})
+}
+
+func (r memoryMasters) Exists(ctx context.Context, table, column string, value any) (bool, error) {
+	var ok bool
+	err := r.s.do(func(d *memoryData) error {
+		sc := scopeOf(ctx)
+		if id, isID := value.(int64); isID && column == "id" {
+			rec, found := d.masterRow(table, id)
+			ok = found && d.masterInScope(sc, table, rec)
+			return nil
+		}
+		for _, rec := range d.masterRows(table) {
+			ok = ok || rec[column] == value && d.masterInScope(sc, table, rec)
+		}
+		return nil
+	})
+	return ok, err
+}
+
+func (r memoryMasters) Options(ctx context.Context, t *MasterTable) ([]ColumnOption, error) {
+	options := []ColumnOption{}
+	err := r.s.do(func(d *memoryData) error {
+		for _, rec := range d.masterRows(t.Name) {
+			if !d.masterInScope(scopeOf(ctx), t.Name, rec) {
+				continue
+			}
+			o := ColumnOption{ID: rec["id"].(int64), Name: fmt.Sprint(rec["id"])}
+			if t.NameColumn != "" {
+				o.Name, _ = rec[t.NameColumn].(string)
+			}
+			options = append(options, o)
+		}
+		return nil
+	})
+	slices.SortStableFunc(options, func(a, b ColumnOption) int { return cmp.Compare(a.Name, b.Name) })
+	return options, err
+}
+
+// tokyo is the time zone the MySQL connection assumes.
+var tokyo = time.FixedZone("Asia/Tokyo", 9*60*60)
+
+// memoryValue converts a value written to a column, or its schema.yaml
+// default, to the form read from MySQL.
+func memoryValue(c *schema.Column, v any) any {
+	switch v := v.(type) {
+	case int:
+		return int64(v)
+	case string:
+		switch c.Kind() {
+		case schema.Decimal:
+			return json.Number(v)
+		case schema.DateTime:
+			t, err := time.ParseInLocation(schema.DateTimeLayout, v, tokyo)
+			if err != nil {
+				return nil // CURRENT_TIMESTAMP などの既定値
+			}
+			return t
+		}
+	}
+	return v
+}
+
+// memoryAudit is the [AuditRepository] of [MemoryStore].
+type memoryAudit struct {
+	s *MemoryStore
+}
+
+func (r memoryAudit) Record(ctx context.Context, e AuditLog) error {
+	return r.s.do(func(d *memoryData) error {
+		e.ID = d.next()
+		e.Changes = maps.Clone(e.Changes)
+		d.audit = append(d.audit, memoryAuditLog{AuditLog: e, ShippingID: scopeOf(ctx).ShippingID})
+		return nil
+	})
+}
+
+func (r memoryAudit) List(ctx context.Context, f AuditFilter) ([]AuditLog, int, error) {
+	logs := []AuditLog{}
+	err := r.Each(ctx, f, func(e AuditLog) error {
+		logs = append(logs, e)
+		return nil
+	})
+	slices.Reverse(logs)
+	total := len(logs)
+	start := min(f.Offset, total)
+	return logs[start:min(start+f.Limit, total)], total, err
+}
+
+func (r memoryAudit) Find(ctx context.Context, id int64) (AuditLog, error) {
+	var out AuditLog
+	err := r.s.do(func(d *memoryData) error {
+		for _, e := range d.audit {
+			if e.ID == id && auditInScope(scopeOf(ctx), e) {
+				out = e.AuditLog
+				return nil
+			}
+		}
+		return &NotFoundError{Resource: "audit log", ID: id}
+	})
+	return out, err
+}
+
+func (r memoryAudit) Each(ctx context.Context, f AuditFilter, fn func(AuditLog) error) error {
+	var logs []AuditLog
+	r.s.do(func(d *memoryData) error {
+		for _, e := range d.audit {
+			if !auditInScope(scopeOf(ctx), e) ||
+				f.Table != "" && e.Table != f.Table ||
+				f.RecordID != nil && e.RecordID != *f.RecordID ||
+				f.UserID != nil && (e.UserID == nil || *e.UserID != *f.UserID) ||
+				f.From != nil && e.OccurredAt.Before(*f.From) ||
+				f.To != nil && !e.OccurredAt.Before(*f.To) {
+				continue
+			}
+			logs = append(logs, e.AuditLog)
+		}
+		return nil
+	})
+	// fn がストアを使えるよう、ロックを外してから呼ぶ
+	for _, e := range logs {
+		if err := fn(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// auditInScope reports whether the entry was made by a user of the shipper
+// of the scope.
+func auditInScope(sc Scope, e memoryAuditLog) bool {
+	return sc.ShippingID == nil || e.ShippingID != nil && *e.ShippingID == *sc.ShippingID
+}
+
+// memoryEvents is the [EventRepository] of [MemoryStore].
+type memoryEvents struct {
+	s *MemoryStore
+}
+
+func (r memoryEvents) List(ctx context.Context, f EventFilter) ([]EventRecord, error) {
+	sc := scopeOf(ctx)
+	events := []EventRecord{}
+	err := r.s.do(func(d *memoryData) error {
+		for _, e := range d.events {
+			recurring := e.RRule != nil
+			if !eventInScope(sc, e) ||
+				f.To != nil && !e.Start.Before(*f.To) ||
+				!recurring && f.From != nil && e.Start.Before(*f.From) && (e.End == nil || !e.End.After(*f.From)) ||
+				!recurring && len(f.Statuses) > 0 && !slices.Contains(f.Statuses, e.Status) ||
+				f.Kind != "" && e.Kind != f.Kind {
+				continue
+			}
+			events = append(events, e)
+		}
+		return nil
+	})
+	slices.SortFunc(events, func(a, b EventRecord) int {
+		return cmp.Or(a.Start.Compare(b.Start), cmp.Compare(a.ID, b.ID))
+	})
+	return events, err
+}
+
+func (r memoryEvents) Find(ctx context.Context, id int64) (EventRecord, error) {
+	var out EventRecord
+	err := r.update(ctx, id, func(_ *memoryData, e *EventRecord) error {
+		out = *e
+		return nil
+	})
+	return out, err
+}
+
+func (r memoryEvents) Create(ctx context.Context, in EventInput) (int64, error) {
+	var id int64
+	err := r.s.do(func(d *memoryData) error {
+		if err := d.checkEvent(in); err != nil {
+			return err
+		}
+		id = d.next()
+		d.events[id] = EventRecord{EventInput: in, ID: id, Version: 1}
+		return nil
+	})
+	return id, err
+}
+
+func (r memoryEvents) Lock(ctx context.Context, id int64) (int64, error) {
+	var version int64
+	err := r.update(ctx, id, func(_ *memoryData, e *EventRecord) error {
+		version = e.Version
+		return nil
+	})
+	return version, err
+}
+
+func (r memoryEvents) Update(ctx context.Context, id int64, in EventInput) error {
+	return r.update(ctx, id, func(d *memoryData, e *EventRecord) error {
+		if err := d.checkEvent(in); err != nil {
+			return err
+		}
+		e.EventInput = in
+		e.Version++
+		return nil
+	})
+}
+
+func (r memoryEvents) Delete(ctx context.Context, id int64) error {
+	return r.s.do(func(d *memoryData) error {
+		if e, ok := d.events[id]; !ok || !eventInScope(scopeOf(ctx), e) {
+			return &NotFoundError{Resource: "event", ID: id}
+		}
+		delete(d.events, id)
+		return nil
+	})
+}
+
+func (r memoryEvents) SetOverride(ctx context.Context, id int64, o OccurrenceOverride) error {
+	return r.update(ctx, id, func(_ *memoryData, e *EventRecord) error {
+		// 複製したデータと配列を共有しないよう、常に新しいスライスを作る
+		overrides := []OccurrenceOverride{o}
+		for _, x := range e.Overrides {
+			if !x.Date.Equal(o.Date) {
+				overrides = append(overrides, x)
+			}
+		}
+		slices.SortFunc(overrides, func(a, b OccurrenceOverride) int { return a.Date.Compare(b.Date) })
+		e.Overrides = overrides
+		e.Version++
+		return nil
+	})
+}
+
+func (r memoryEvents) DeleteOverride(ctx context.Context, id int64, date time.Time) error {
+	return r.update(ctx, id, func(_ *memoryData, e *EventRecord) error {
+		var overrides []OccurrenceOverride
+		for _, x := range e.Overrides {
+			if !x.Date.Equal(date) {
+				overrides = append(overrides, x)
+			}
+		}
+		e.Overrides = overrides
+		return nil
+	})
+}
+
+// update applies fn to the event identified by id within the scope.
+func (r memoryEvents) update(ctx context.Context, id int64, fn func(d *memoryData, e *EventRecord) error) error {
+	return r.s.do(func(d *memoryData) error {
+		e, ok := d.events[id]
+		if !ok || !eventInScope(scopeOf(ctx), e) {
+			return &NotFoundError{Resource: "event", ID: id}
+		}
+		if err := fn(d, &e); err != nil {
+			return err
+		}
+		d.events[id] = e
+		return nil
+	})
+}
+
+// checkEvent enforces the foreign keys of events the way MySQL reports them
+// through [writeError].
+func (d *memoryData) checkEvent(in EventInput) error {
+	if in.ShippingID != nil {
+		if _, ok := d.shippings[*in.ShippingID]; !ok {
+			return &ValidationError{Message: "referenced record does not exist"}
+		}
+	}
+	return nil
+}
+
+// eventInScope reports whether the event is for the shipper of the scope or
+// for all shippers.
+func eventInScope(sc Scope, e EventRecord) bool {
+	return sc.ShippingID == nil || e.ShippingID == nil || *e.ShippingID == *sc.ShippingID
+}
+
+// memoryCalendar is the [CalendarRepository] of [MemoryStore]. It reads the
+// rows written through [MasterRepository], skipping those whose references
+// are missing.
+type memoryCalendar struct {
+	s *MemoryStore
+}
+
+func (r memoryCalendar) BillingSchedules(ctx context.Context) ([]BillingSchedule, error) {
+	var out []BillingSchedule
+	err := r.s.do(func(d *memoryData) error {
+		for _, b := range d.masterRows("billings_master") {
+			if !d.masterInScope(scopeOf(ctx), "billings_master", b) {
+				continue
+			}
+			shipping, ok1 := d.masterRow("shippings_master", memoryInt(b["shipping_id"]))
+			closing, ok2 := d.masterRow("closing_dates_master", memoryInt(b["closing_date_id"]))
+			month, ok3 := d.masterRow("billing_months_master", memoryInt(b["billing_date_kind"]))
+			day, ok4 := d.masterRow("billing_days_master", memoryInt(b["billing_date_id"]))
+			if !ok1 || !ok2 || !ok3 || !ok4 {
+				continue
+			}
+			out = append(out, BillingSchedule{
+				BillingID:    memoryInt(b["id"]),
+				ShippingID:   memoryInt(b["shipping_id"]),
+				ShippingName: fmt.Sprint(shipping["name"]),
+				ClosingDay:   int(memoryInt(closing["day"])),
+				BillingMonth: fmt.Sprint(month["name"]),
+				BillingDay:   int(memoryInt(day["day"])),
+			})
+		}
+		return nil
+	})
+	return out, err
+}
+
+func (r memoryCalendar) OrderDeadlines(ctx context.Context) ([]OrderDeadline, error) {
+	var out []OrderDeadline
+	err := r.s.do(func(d *memoryData) error {
+		for _, rec := range d.masterRows("order_deadlines_master") {
+			if valid, _ := rec["valid_flag"].(bool); valid {
+				out = append(out, OrderDeadline{
+					ID:   memoryInt(rec["id"]),
+					Name: fmt.Sprint(rec["name"]),
+					Time: fmt.Sprint(rec["time"]),
+				})
+			}
+		}
+		return nil
+	})
+	return out, err
+}
+
+func (r memoryCalendar) ServiceBillings(ctx context.Context, from, to time.Time) ([]ServiceBilling, error) {
+	var out []ServiceBilling
+	err := r.s.do(func(d *memoryData) error {
+		for _, rec := range d.masterRows("services_useds_master") {
+			date, err := time.ParseInLocation(schema.DateLayout, fmt.Sprint(rec["billing_date"]), tokyo)
+			if err != nil || date.Before(from) || !date.Before(to) {
+				continue
+			}
+			out = append(out, ServiceBilling{ID: memoryInt(rec["id"]), Name: fmt.Sprint(rec["name"]), BillingDate: date})
+		}
+		return nil
+	})
+	slices.SortStableFunc(out, func(a, b ServiceBilling) int { return a.BillingDate.Compare(b.BillingDate) })
+	return out, err
+}
+
+func (r memoryCalendar) Overrides(ctx context.Context, from, to time.Time) ([]CalendarOverride, error) {
+	var out []CalendarOverride
+	err := r.s.do(func(d *memoryData) error {
+		for _, rec := range d.masterRows("business_calendars_master") {
+			date, err := time.ParseInLocation(schema.DateLayout, fmt.Sprint(rec["calendar_date"]), tokyo)
+			if err != nil || date.Before(from) || !date.Before(to) {
+				continue
+			}
+			o := CalendarOverride{ID: memoryInt(rec["id"]), Date: date, Name: fmt.Sprint(rec["name"])}
+			if code, ok := rec["warehouse_code"].(string); ok {
+				o.WarehouseCode = &code
+			}
+			o.Working, _ = rec["working"].(bool)
+			out = append(out, o)
+		}
+		return nil
+	})
+	slices.SortStableFunc(out, func(a, b CalendarOverride) int { return a.Date.Compare(b.Date) })
+	return out, err
+}
+
+// memoryInt returns an integer column of a row, or 0 when it is NULL.
+func memoryInt(v any) int64 {
+	n, _ := v.(int64)
+	return n
+}
@@ -0,0 +1,142 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// 共通ヘルパー: 荷主1・2の部門とグループを登録したストア
+func newSeededStore() *MemoryStore {
+	s := NewMemoryStore()
+	s.AddGroup(1, "管理者")
+	s.AddShipping(1, "荷主A")
+	s.AddShipping(2, "荷主B")
+	s.AddDepartment(2, "総務部", 1)
+	s.AddDepartment(3, "物流部", 2)
+	return s
+}
+
+func TestMemoryStore_UsersController(t *testing.T) {
+	store := newSeededStore()
+	ctrl := &UsersController{Store: store}
+
+	req, w := newJSONRequest("POST", "/users", UsersRequestPost{
+		GroupID: 1, UserID: "jdoe", Name: "John Doe", Email: "jdoe@example.com", DepartmentID: 3, Password: ptr("secret-pass"),
+	})
+	ctrl.CreateUser(w, withAuth(req, 1, 10))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var created User
+	json.NewDecoder(w.Body).Decode(&created)
+	if created.DepartmentName != "物流部" || created.GroupName != "管理者" {
+		t.Errorf("names are not joined: %+v", created)
+	}
+
+	// 同じメールアドレスは登録できない
+	req, w = newJSONRequest("POST", "/users", UsersRequestPost{
+		GroupID: 1, UserID: "other", Name: "Other", Email: "jdoe@example.com", DepartmentID: 2,
+	})
+	ctrl.CreateUser(w, withAuth(req, 1, 10))
+	if w.Code != http.StatusConflict {
+		t.Errorf("Expected 409, got %d", w.Code)
+	}
+
+	// 他荷主のユーザは見えない
+	req, w = newJSONRequest("GET", "/users", nil)
+	ctrl.GetUser(w, withShipper(req, 1), created.ID)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404, got %d", w.Code)
+	}
+
+	req, w = newJSONRequest("DELETE", "/users", nil)
+	ctrl.DeleteUser(w, req, created.ID, DeleteUserParams{IfMatch: ifMatchV1})
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("Expected 204, got %d: %s", w.Code, w.Body.String())
+	}
+	u, err := store.Users().Find(context.Background(), created.ID)
+	if err != nil || u.ValidFlag {
+		t.Errorf("user is not disabled: %+v, %v", u, err)
+	}
+}
+
+func TestMemoryStore_Login(t *testing.T) {
+	store := newSeededStore()
+	ctx := context.Background()
+	hash := mustHash(t, "secret-pass")
+	id, err := store.Users().Create(ctx, UserInput{GroupID: 1, UserID: "alice", Name: "Alice", Email: "alice@example.com",
+		DepartmentID: 2, ValidFlag: true, PasswordHash: &hash})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctrl := &AuthController{Store: store, SessionTTL: time.Hour, MaxFailedLogins: 2, LockoutDuration: time.Minute}
+
+	for range 2 {
+		req, w := newJSONRequest("POST", "/auth/login", LoginRequest{UserID: "alice", Password: "guess"})
+		ctrl.Login(w, req)
+		if w.Code != http.StatusUnauthorized {
+			t.Fatalf("Expected 401, got %d", w.Code)
+		}
+	}
+	// 連続失敗でロックされる
+	req, w := newJSONRequest("POST", "/auth/login", LoginRequest{UserID: "alice", Password: "secret-pass"})
+	ctrl.Login(w, req)
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("Expected 429, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if err := store.Users().Unlock(ctx, id); err != nil {
+		t.Fatal(err)
+	}
+	req, w = newJSONRequest("POST", "/auth/login", LoginRequest{UserID: "alice", Password: "secret-pass"})
+	ctrl.Login(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp LoginResponse
+	json.NewDecoder(w.Body).Decode(&resp)
+	au, err := store.Sessions().Lookup(ctx, hashToken(resp.Token), time.Now())
+	if err != nil || au.ID != id {
+		t.Errorf("session is not stored: %+v, %v", au, err)
+	}
+}
+
+func TestMemoryStore_WithTxRollback(t *testing.T) {
+	store := newSeededStore()
+	ctx := context.Background()
+	id, err := store.Users().Create(ctx, UserInput{GroupID: 1, UserID: "bob", Name: "Bob", Email: "bob@example.com", DepartmentID: 2, ValidFlag: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	errAbort := errors.New("abort")
+	err = store.WithTx(ctx, func(tx Store) error {
+		if err := tx.Users().Disable(ctx, id); err != nil {
+			return err
+		}
+		// 入れ子の WithTx は同じトランザクションに参加する
+		return tx.WithTx(ctx, func(tx Store) error {
+			if u, _ := tx.Users().Find(ctx, id); u.ValidFlag {
+				t.Error("the change is not visible inside the transaction")
+			}
+			return errAbort
+		})
+	})
+	if !errors.Is(err, errAbort) {
+		t.Fatalf("Expected errAbort, got %v", err)
+	}
+	if u, _ := store.Users().Find(ctx, id); !u.ValidFlag {
+		t.Error("the change was not rolled back")
+	}
+
+	if err := store.WithTx(ctx, func(tx Store) error { return tx.Users().Disable(ctx, id) }); err != nil {
+		t.Fatal(err)
+	}
+	if u, _ := store.Users().Find(ctx, id); u.ValidFlag {
+		t.Error("the change was not committed")
+	}
+}
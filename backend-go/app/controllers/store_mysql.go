@@ -0,0 +1,129 @@
+package controllers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// Deadlocked transactions are retried up to txAttempts times in total,
+// waiting txRetryDelay times the attempt number in between.
+const (
+	txAttempts   = 3
+	txRetryDelay = 20 * time.Millisecond
+)
+
+// dbtx is implemented by *sql.DB and *sql.Tx.
+type dbtx interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// MySQLStore is the [Store] backed by the application database.
+type MySQLStore struct {
+	db *sql.DB
+	// q is db, or the transaction the store is bound to.
+	q dbtx
+	// bound is set on the store passed to a [MySQLStore.WithTx] callback.
+	bound bool
+}
+
+var _ Store = (*MySQLStore)(nil)
+
+// NewMySQLStore returns a store using db. The connection must use
+// clientFoundRows=true so that updates leaving a row unchanged still count
+// as affecting it.
+func NewMySQLStore(db *sql.DB) *MySQLStore {
+	return &MySQLStore{db: db, q: db}
+}
+
+func (s *MySQLStore) Users() UserRepository                 { return mysqlUsers{s} }
+func (s *MySQLStore) Sessions() SessionRepository           { return mysqlSessions{s} }
+func (s *MySQLStore) Credentials() CredentialRepository     { return mysqlCredentials{s} }
+func (s *MySQLStore) Permissions() PermissionRepository     { return mysqlPermissions{s} }
+func (s *MySQLStore) MailTemplates() MailTemplateRepository { return mysqlMailTemplates{s} }
+func (s *MySQLStore) Masters() MasterRepository             { return mysqlMasters{s} }
+func (s *MySQLStore) Audit() AuditRepository                { return mysqlAudit{s} }
+func (s *MySQLStore) Events() EventRepository               { return mysqlEvents{s} }
+func (s *MySQLStore) Calendar() CalendarRepository          { return mysqlCalendar{s} }
+
+// WithTx implements [Store]. A transaction aborted by a deadlock (MySQL
+// error 1213) is rolled back and run again, up to txAttempts times.
+func (s *MySQLStore) WithTx(ctx context.Context, fn func(tx Store) error) error {
+	if s.bound {
+		return fn(s)
+	}
+	for attempt := 1; ; attempt++ {
+		err := s.runTx(ctx, fn)
+		if attempt == txAttempts || !isDeadlock(err) {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(time.Duration(attempt) * txRetryDelay):
+		}
+	}
+}
+
+func (s *MySQLStore) runTx(ctx context.Context, fn func(tx Store) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if err := fn(&MySQLStore{db: s.db, q: tx, bound: true}); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// inTx runs fn with the transaction of s, starting one when s is not bound
+// to a transaction. It is used by repository methods that issue more than
+// one statement.
+func (s *MySQLStore) inTx(ctx context.Context, fn func(q dbtx) error) error {
+	return s.WithTx(ctx, func(tx Store) error {
+		return fn(tx.(*MySQLStore).q)
+	})
+}
+
+// isDeadlock reports whether err was caused by InnoDB choosing the
+// transaction as a deadlock victim.
+func isDeadlock(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrDeadlock
+}
+
+// exists reports whether query returns at least one row.
+func (s *MySQLStore) exists(ctx context.Context, query string, args ...any) (bool, error) {
+	var one int
+	err := s.q.QueryRowContext(ctx, query, args...).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// requireAffected returns a [NotFoundError] when res changed no rows.
+//
+// MySQL reports 0 affected rows for an UPDATE that leaves the row unchanged,
+// so the connection must use clientFoundRows=true for this to be reliable.
+func requireAffected(res sql.Result, resource string, id any) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return &NotFoundError{Resource: resource, ID: id}
+	}
+	return nil
+}
+
+// rowScanner is implemented by *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
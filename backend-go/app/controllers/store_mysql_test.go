@@ -0,0 +1,75 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+)
+
+func TestMySQLStore_WithTxRetriesDeadlock(t *testing.T) {
+	ctrl, mock, teardown := setup(t)
+	defer teardown()
+
+	// 1回目はデッドロックで中断され、2回目でコミットされる
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE users_master SET valid_flag = false").
+		WillReturnError(&mysql.MySQLError{Number: mysqlErrDeadlock, Message: "Deadlock found"})
+	mock.ExpectRollback()
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE users_master SET valid_flag = false").WithArgs(int64(5)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	calls := 0
+	err := ctrl.Store.WithTx(context.Background(), func(tx Store) error {
+		calls++
+		return tx.Users().Disable(context.Background(), 5)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("Expected 2 attempts, got %d", calls)
+	}
+}
+
+func TestMySQLStore_WithTxGivesUpAfterDeadlocks(t *testing.T) {
+	ctrl, mock, teardown := setup(t)
+	defer teardown()
+
+	for range txAttempts {
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE users_master SET valid_flag = false").
+			WillReturnError(&mysql.MySQLError{Number: mysqlErrDeadlock, Message: "Deadlock found"})
+		mock.ExpectRollback()
+	}
+
+	err := ctrl.Store.WithTx(context.Background(), func(tx Store) error {
+		return tx.Users().Disable(context.Background(), 5)
+	})
+	if !isDeadlock(err) {
+		t.Errorf("Expected the deadlock error, got %v", err)
+	}
+}
+
+func TestMySQLStore_WithTxJoinsTransaction(t *testing.T) {
+	ctrl, mock, teardown := setup(t)
+	defer teardown()
+
+	// 入れ子の WithTx と複数文のメソッドは新しいトランザクションを開始しない
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE users_master SET one_time_passwd = ''").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM user_recovery_codes").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	err := ctrl.Store.WithTx(context.Background(), func(tx Store) error {
+		return tx.WithTx(context.Background(), func(tx Store) error {
+			return tx.Credentials().DisableTOTP(context.Background(), 5)
+		})
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
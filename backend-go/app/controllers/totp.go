@@ -0,0 +1,281 @@
+package controllers
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/subtle"
+	"image/png"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+)
+
+// TOTP parameters (RFC 6238). They are the defaults of common authenticator
+// apps, which ignore anything else.
+const (
+	totpPeriod = 30
+	// totpSkew is the number of periods accepted before and after the current
+	// one to tolerate clock drift.
+	totpSkew = 1
+	// totpQRSize is the width and height of the enrolment QR code in pixels.
+	totpQRSize = 256
+)
+
+var totpOpts = totp.ValidateOpts{
+	Period:    totpPeriod,
+	Digits:    otp.DigitsSix,
+	Algorithm: otp.AlgorithmSHA1,
+}
+
+// Recovery codes are ten codes of the form xxxxx-xxxxx drawn from an
+// alphabet without look-alike characters.
+const (
+	recoveryCodeCount    = 10
+	recoveryCodeAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+)
+
+// StartTotpEnrollment issues a new TOTP secret for the logged-in user and
+// returns it with its otpauth URI and QR code. Two-factor authentication is
+// enabled only after [AuthController.VerifyTotpEnrollment] confirms a code.
+//
+// It returns 409 when two-factor authentication is already enabled.
+func (c *AuthController) StartTotpEnrollment(w http.ResponseWriter, r *http.Request) {
+	au, err := currentUser(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if au.TotpEnabled {
+		writeError(w, &ConflictError{Message: "two-factor authentication is already enabled"})
+		return
+	}
+
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      c.TOTPIssuer,
+		AccountName: au.UserID,
+		Period:      totpPeriod,
+		Digits:      totpOpts.Digits,
+		Algorithm:   totpOpts.Algorithm,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	img, err := key.Image(totpQRSize, totpQRSize)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var qr bytes.Buffer
+	if err := png.Encode(&qr, img); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	// 確認が済むまでは totp_enabled=false のまま保存する（再実行すると上書き）
+	if err := c.Store.Credentials().StartTOTP(r.Context(), au.ID, key.Secret()); err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, TotpEnrollment{
+		Secret:     key.Secret(),
+		OtpauthURI: key.URL(),
+		QrPng:      qr.Bytes(),
+	})
+}
+
+// VerifyTotpEnrollment enables two-factor authentication once the user
+// proves their authenticator app works, and returns fresh recovery codes.
+//
+// It returns 409 when no enrolment is in progress and 422 when the code is
+// wrong.
+func (c *AuthController) VerifyTotpEnrollment(w http.ResponseWriter, r *http.Request) {
+	au, err := currentUser(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var req TotpCodeRequest // 自動生成された型
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+	ctx := r.Context()
+
+	cred, err := c.Store.Credentials().ByID(ctx, au.ID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if cred.TOTPEnabled || cred.TOTPSecret == "" {
+		writeError(w, &ConflictError{Message: "no two-factor enrolment is in progress"})
+		return
+	}
+	step, ok := verifyTOTP(cred.TOTPSecret, req.Code, time.Now())
+	if !ok {
+		writeError(w, &ValidationError{Message: "code is incorrect"})
+		return
+	}
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	err = c.Store.WithTx(ctx, func(tx Store) error {
+		if err := tx.Credentials().EnableTOTP(ctx, au.ID, step); err != nil {
+			return err
+		}
+		return tx.Credentials().ReplaceRecoveryCodes(ctx, au.ID, hashes)
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, RecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// DisableTotp turns two-factor authentication off after checking the
+// password, and deletes the recovery codes.
+//
+// A wrong password is rejected with 422.
+func (c *AuthController) DisableTotp(w http.ResponseWriter, r *http.Request) {
+	au, err := currentUser(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var req PasswordConfirmRequest // 自動生成された型
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+	ctx := r.Context()
+
+	cred, err := c.Store.Credentials().ByID(ctx, au.ID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if !checkPassword(cred.PasswordHash, req.Password) {
+		writeError(w, &ValidationError{Message: "password is incorrect"})
+		return
+	}
+	if err := c.Store.Credentials().DisableTOTP(ctx, au.ID); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes of the logged-in user
+// after checking a code from their authenticator app.
+//
+// It returns 409 when two-factor authentication is not enabled and 422 when
+// the code is wrong or already used.
+func (c *AuthController) RegenerateRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	au, err := currentUser(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var req TotpCodeRequest // 自動生成された型
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+	ctx := r.Context()
+
+	cred, err := c.Store.Credentials().ByID(ctx, au.ID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if !cred.TOTPEnabled {
+		writeError(w, &ConflictError{Message: "two-factor authentication is not enabled"})
+		return
+	}
+	step, ok := verifyTOTP(cred.TOTPSecret, req.Code, time.Now())
+	if ok {
+		ok, err = c.Store.Credentials().UseTOTPStep(ctx, au.ID, step)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+	}
+	if !ok {
+		writeError(w, &ValidationError{Message: "code is incorrect"})
+		return
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := c.Store.Credentials().ReplaceRecoveryCodes(ctx, au.ID, hashes); err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, RecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// verifyTOTP checks code against secret at now, allowing totpSkew periods of
+// clock drift, and returns the matching time step.
+func verifyTOTP(secret, code string, now time.Time) (int64, bool) {
+	current := now.Unix() / totpPeriod
+	for _, step := range []int64{current, current - totpSkew, current + totpSkew} {
+		want, err := totp.GenerateCodeCustom(secret, time.Unix(step*totpPeriod, 0), totpOpts)
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(want), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// newRecoveryCodes returns recoveryCodeCount new recovery codes in plain
+// text and their hashes to be stored.
+func newRecoveryCodes() (codes, hashes []string, err error) {
+	codes = make([]string, recoveryCodeCount)
+	hashes = make([]string, recoveryCodeCount)
+	for i := range codes {
+		if codes[i], err = newRecoveryCode(); err != nil {
+			return nil, nil, err
+		}
+		hashes[i] = hashRecoveryCode(codes[i])
+	}
+	return codes, hashes, nil
+}
+
+// newRecoveryCode returns a random code such as "k7m2p-x9qrt".
+func newRecoveryCode() (string, error) {
+	b := make([]byte, 10)
+	n := big.NewInt(int64(len(recoveryCodeAlphabet)))
+	for i := range b {
+		v, err := rand.Int(rand.Reader, n)
+		if err != nil {
+			return "", err
+		}
+		b[i] = recoveryCodeAlphabet[v.Int64()]
+	}
+	return string(b[:5]) + "-" + string(b[5:]), nil
+}
+
+// hashRecoveryCode returns the value stored in user_recovery_codes.code_hash.
+// Case, spaces and hyphens are ignored so that codes can be typed loosely.
+func hashRecoveryCode(code string) string {
+	normalized := strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, strings.ToLower(code))
+	return hashToken(normalized)
+}
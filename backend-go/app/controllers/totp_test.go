@@ -0,0 +1,160 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/pquerna/otp/totp"
+)
+
+func TestVerifyTOTP(t *testing.T) {
+	const secret = "JBSWY3DPEHPK3PXP"
+	now := time.Date(2026, 4, 1, 9, 0, 15, 0, time.UTC)
+
+	// 前後1ステップまでの時計のずれは許容する
+	for _, d := range []time.Duration{-totpPeriod * time.Second, 0, totpPeriod * time.Second} {
+		code, _ := totp.GenerateCodeCustom(secret, now.Add(d), totpOpts)
+		step, ok := verifyTOTP(secret, code, now)
+		if !ok || step != now.Add(d).Unix()/totpPeriod {
+			t.Errorf("offset %v: got step %d, ok %v", d, step, ok)
+		}
+	}
+	code, _ := totp.GenerateCodeCustom(secret, now.Add(-2*totpPeriod*time.Second), totpOpts)
+	if _, ok := verifyTOTP(secret, code, now); ok {
+		t.Error("code two periods old must be rejected")
+	}
+}
+
+func TestNewRecoveryCode(t *testing.T) {
+	code, err := newRecoveryCode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !regexp.MustCompile(`^[a-z2-9]{5}-[a-z2-9]{5}$`).MatchString(code) {
+		t.Errorf("unexpected format %q", code)
+	}
+	if hashRecoveryCode(code) != hashRecoveryCode(strings.ToUpper(strings.ReplaceAll(code, "-", ""))) {
+		t.Error("hash must ignore case and hyphens")
+	}
+}
+
+func TestAuthController_StartTotpEnrollment(t *testing.T) {
+	ctrl, mock, teardown := setupAuth(t)
+	defer teardown()
+	ctrl.TOTPIssuer = "backend-go"
+
+	mock.ExpectExec("UPDATE users_master SET one_time_passwd = \\?, totp_last_step = NULL").
+		WithArgs(sqlmock.AnyArg(), int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	req, w := newJSONRequest("POST", "/auth/totp", nil)
+	req = withAuth(req, 1, 10)
+	au, _ := AuthUserFrom(req.Context())
+	au.UserID = "alice"
+	ctrl.StartTotpEnrollment(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp TotpEnrollment
+	json.NewDecoder(w.Body).Decode(&resp)
+	if !strings.HasPrefix(resp.OtpauthURI, "otpauth://totp/backend-go:alice?") || !strings.Contains(resp.OtpauthURI, resp.Secret) {
+		t.Errorf("unexpected URI %q", resp.OtpauthURI)
+	}
+	if len(resp.QrPng) < 8 || string(resp.QrPng[1:4]) != "PNG" {
+		t.Error("qr_png is not a PNG image")
+	}
+}
+
+func TestAuthController_StartTotpEnrollment_AlreadyEnabled(t *testing.T) {
+	ctrl, _, teardown := setupAuth(t)
+	defer teardown()
+
+	req, w := newJSONRequest("POST", "/auth/totp", nil)
+	req = withAuth(req, 1, 10)
+	au, _ := AuthUserFrom(req.Context())
+	au.TotpEnabled = true
+	ctrl.StartTotpEnrollment(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Errorf("Expected 409, got %d", w.Code)
+	}
+}
+
+func TestAuthController_VerifyTotpEnrollment(t *testing.T) {
+	ctrl, mock, teardown := setupAuth(t)
+	defer teardown()
+
+	const secret = "JBSWY3DPEHPK3PXP"
+	code, _ := totp.GenerateCodeCustom(secret, time.Now(), totpOpts)
+
+	mock.ExpectQuery("SELECT id, password_hash(.+) WHERE id").WithArgs(int64(1)).
+		WillReturnRows(loginRow(nil, true, nil, false, secret))
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE users_master SET totp_enabled = true").
+		WithArgs(sqlmock.AnyArg(), int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM user_recovery_codes").WithArgs(int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	for range recoveryCodeCount {
+		mock.ExpectExec("INSERT INTO user_recovery_codes").
+			WithArgs(int64(1), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+	}
+	mock.ExpectCommit()
+
+	req, w := newJSONRequest("POST", "/auth/totp/verify", TotpCodeRequest{Code: code})
+	ctrl.VerifyTotpEnrollment(w, withAuth(req, 1, 10))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp RecoveryCodesResponse
+	json.NewDecoder(w.Body).Decode(&resp)
+	if len(resp.RecoveryCodes) != recoveryCodeCount {
+		t.Errorf("Expected %d recovery codes, got %d", recoveryCodeCount, len(resp.RecoveryCodes))
+	}
+}
+
+func TestAuthController_VerifyTotpEnrollment_WrongCode(t *testing.T) {
+	ctrl, mock, teardown := setupAuth(t)
+	defer teardown()
+
+	mock.ExpectQuery("SELECT id, password_hash(.+) WHERE id").
+		WillReturnRows(loginRow(nil, true, nil, false, "JBSWY3DPEHPK3PXP"))
+
+	req, w := newJSONRequest("POST", "/auth/totp/verify", TotpCodeRequest{Code: "000000"})
+	ctrl.VerifyTotpEnrollment(w, withAuth(req, 1, 10))
+
+	// 000000 が偶然一致する確率は無視できる
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected 422, got %d", w.Code)
+	}
+}
+
+func TestAuthController_DisableTotp(t *testing.T) {
+	ctrl, mock, teardown := setupAuth(t)
+	defer teardown()
+
+	mock.ExpectQuery("SELECT id, password_hash(.+) WHERE id").WithArgs(int64(1)).
+		WillReturnRows(loginRow(mustHash(t, "secret-pass"), true, nil, true, "JBSWY3DPEHPK3PXP"))
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE users_master SET one_time_passwd = '', totp_enabled = false").
+		WithArgs(int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM user_recovery_codes").WithArgs(int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 10))
+	mock.ExpectCommit()
+
+	req, w := newJSONRequest("POST", "/auth/totp/disable", PasswordConfirmRequest{Password: "secret-pass"})
+	ctrl.DisableTotp(w, withAuth(req, 1, 10))
+
+	if w.Code != http.StatusNoContent {
+		t.Errorf("Expected 204, got %d: %s", w.Code, w.Body.String())
+	}
+}
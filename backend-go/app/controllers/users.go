@@ -1,109 +1,499 @@
 package controllers
 
 import (
-	"database/sql"
-	"encoding/json"
+	"backend-go/metrics"
+	"context"
 	"fmt"
 	"net/http"
+	"net/mail"
 
 	_ "github.com/go-sql-driver/mysql" // MySQLドライバのインポート
 	// 必要に応じてDB接続用のパッケージなどをインポート
 )
 
-// UsersController 構造体にDBを保持させる
+// UsersController 構造体にStoreを保持させる
 //
 // # Database Interaction
-// All methods in this controller use the embedded *sql.DB to perform
-// CRUD operations on the 'users' table.
+// All methods in this controller read and write the 'users_master' table
+// through the [UserRepository] of the embedded [Store].
 // UsersController handles HTTP requests for user management.
-// It implements the [ServerInterface] and interacts directly with the database.
+// It implements the [ServerInterface].
 type UsersController struct {
-	// DB is the active SQL database connection.
-	DB *sql.DB
+	Store Store
+	// BasePath is the path the operations are mounted under, which the
+	// Location of a created user starts with.
+	BasePath string
 }
 
-// PostUsers creates a new user in the database.
+// userInput holds a user as sent by the client.
+type userInput struct {
+	UserInput
+	// Password is the new plain-text password, or nil to keep the current one.
+	Password *string
+}
+
+// ListUsers returns a page of users as a [UsersResponseGet].
 //
-// It expects a [UsersRequestPost] JSON body and returns the created [User]
-// object with a 201 Created status.
-func (c *UsersController) PostUsers(w http.ResponseWriter, r *http.Request) {
-	var req UsersRequestPost // 自動生成された型
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		return // 本来はValidatorミドルウェアが先に弾くのでここには来ない
-	}
-	res, _ := c.DB.Exec("INSERT INTO users (name) VALUES (?)", req.Name)
-	lastID, _ := res.LastInsertId()
-	fmt.Fprintf(w, "User created with ID: %d\n", lastID)
-	newID := int(lastID)
-	response := UsersResponsePost{
-		ID:   newID,
-		Name: req.Name, // ポインタ型なら & を付ける
-	}
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated) // 201 Created
-	json.NewEncoder(w).Encode(response)
-}
-
-// 2. GET /users の実装
-func (c *UsersController) GetUsers(w http.ResponseWriter, r *http.Request) {
-	// DBから取得して UsersResponseGet (自動生成) を返すロジック
-	rows, _ := c.DB.Query("SELECT id, name FROM users")
-	defer rows.Close()
-	var userList []User
-	for rows.Next() {
-		var id int
-		var name string
-		rows.Scan(&id, &name)
-		// ポインタとして格納
-		u := User{
-			ID:   id,
-			Name: name,
-		}
-		tempID := id
-		tempName := name
-		u.ID = tempID
-		u.Name = tempName
-		userList = append(userList, u)
+// Users can be filtered by a partial match on name, user_id or email (q), by
+// group, department and valid_flag, and ordered by id, user_id or name.
+// The response carries the total number of matching users for paging.
+// Shipper-side callers only see the users of their own shipper.
+func (c *UsersController) ListUsers(w http.ResponseWriter, r *http.Request, params ListUsersParams) {
+	limit, offset := pageOf(params.Limit, params.Offset)
+	f := UserFilter{
+		GroupID:      params.GroupID,
+		DepartmentID: params.DepartmentID,
+		ValidFlag:    params.ValidFlag,
+		Limit:        limit,
+		Offset:       offset,
+	}
+	if params.Q != nil {
+		f.Q = *params.Q
+	}
+	if params.Sort != nil {
+		f.Sort = *params.Sort
+	}
+	userList, total, err := c.Store.Users().List(r.Context(), f)
+	if err != nil {
+		writeError(w, err)
+		return
 	}
 	// レスポンス全体を組み立て
 	response := UsersResponseGet{
-		Users: &userList,
+		Users:  userList,
+		Total:  total,
+		Limit:  limit,
+		Offset: offset,
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// CreateUser creates a new user in users_master.
+//
+// It expects a [UsersRequestPost] JSON body and returns the created [User]
+// object with a 201 Created status. Unknown groups or departments are
+// rejected with 422, and a duplicate user_id or email with 409. Users created
+// without a password cannot log in until one is set.
+//
+// Shipper-side callers can only create users of their own shipper, which is
+// also the default shipping_id for them. A group with permissions the caller
+// lacks is rejected with 403 (see [authorizeGroup]).
+func (c *UsersController) CreateUser(w http.ResponseWriter, r *http.Request) {
+	var req UsersRequestPost // 自動生成された型
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+	in := userInput{
+		UserInput: UserInput{
+			GroupID:      req.GroupID,
+			UserID:       req.UserID,
+			Name:         req.Name,
+			Email:        string(req.Email),
+			DepartmentID: req.DepartmentID,
+			ShippingID:   req.ShippingID,
+			ValidFlag:    true,
+		},
+		Password: req.Password,
+	}
+	if req.ValidFlag != nil {
+		in.ValidFlag = *req.ValidFlag
+	}
+	ctx := r.Context()
+	if err := c.validateUser(ctx, 0, &in); err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := authorizeGroup(ctx, c.Store, in.GroupID); err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := in.hashPassword(); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var u User
+	err := c.Store.WithTx(ctx, func(tx Store) error {
+		lastID, err := tx.Users().Create(ctx, in.UserInput)
+		if err != nil {
+			return err
+		}
+		if u, err = tx.Users().Find(ctx, lastID); err != nil {
+			return err
+		}
+		return recordAudit(ctx, tx, AuditCreate, "users_master", lastID, nil, u)
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	metrics.UsersCreated.Inc()
+	w.Header().Set("Location", fmt.Sprintf("%s/users/%d", c.BasePath, u.ID))
+	setETag(w, u.Version)
+	writeJSON(w, http.StatusCreated, u) // 201 Created
+}
+
+// GetUser returns a single user, or 404 when it does not exist or belongs to
+// another shipper.
+func (c *UsersController) GetUser(w http.ResponseWriter, r *http.Request, id ResourceID) {
+	u, err := c.Store.Users().Find(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+	setETag(w, u.Version)
+	writeJSON(w, http.StatusOK, u)
 }
 
-// 3. PUT /users の実装
-func (c *UsersController) PutUsers(w http.ResponseWriter, r *http.Request) {
+// UpdateUser replaces the user identified by id. The password is changed
+// only when present in the body.
+//
+// It returns 404 when no user has the requested ID, and 412 when If-Match
+// does not carry the current ETag.
+func (c *UsersController) UpdateUser(w http.ResponseWriter, r *http.Request, id ResourceID, params UpdateUserParams) {
+	if err := requireIfMatch(params.IfMatch); err != nil {
+		writeError(w, err)
+		return
+	}
 	var req UsersRequestPut // 自動生成された型
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+	in := userInput{
+		UserInput: UserInput{
+			GroupID:      req.GroupID,
+			UserID:       req.UserID,
+			Name:         req.Name,
+			Email:        string(req.Email),
+			DepartmentID: req.DepartmentID,
+			ShippingID:   req.ShippingID,
+			ValidFlag:    req.ValidFlag,
+		},
+		Password: req.Password,
+	}
+	u, err := c.saveUser(r.Context(), id, in, params.IfMatch)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	setETag(w, u.Version)
+	writeJSON(w, http.StatusOK, u)
+}
+
+// PatchUser updates only the fields present in the [UsersRequestPatch] body.
+// Setting valid_flag re-enables or disables the user.
+//
+// It returns 404 when no user has the requested ID, and 412 when If-Match
+// does not carry the current ETag.
+func (c *UsersController) PatchUser(w http.ResponseWriter, r *http.Request, id ResourceID, params PatchUserParams) {
+	if err := requireIfMatch(params.IfMatch); err != nil {
+		writeError(w, err)
+		return
+	}
+	var req UsersRequestPatch // 自動生成された型
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+	current, err := c.Store.Users().Find(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	in := userInput{UserInput: UserInput{
+		GroupID:      current.GroupID,
+		UserID:       current.UserID,
+		Name:         current.Name,
+		Email:        current.Email,
+		DepartmentID: current.DepartmentID,
+		ShippingID:   current.ShippingID,
+		ValidFlag:    current.ValidFlag,
+	}}
+	if req.GroupID != nil {
+		in.GroupID = *req.GroupID
+	}
+	if req.UserID != nil {
+		in.UserID = *req.UserID
+	}
+	if req.Name != nil {
+		in.Name = *req.Name
+	}
+	if req.Email != nil {
+		in.Email = string(*req.Email)
+	}
+	if req.DepartmentID != nil {
+		in.DepartmentID = *req.DepartmentID
+	}
+	if req.ShippingID != nil {
+		in.ShippingID = req.ShippingID
+	}
+	if req.ValidFlag != nil {
+		in.ValidFlag = *req.ValidFlag
+	}
+	in.Password = req.Password
+	u, err := c.saveUser(r.Context(), id, in, params.IfMatch)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	setETag(w, u.Version)
+	writeJSON(w, http.StatusOK, u)
+}
+
+// DeleteUser disables a user by clearing valid_flag and returns 204 No Content.
+// Users are never physically deleted because other records refer to them.
+// All sessions of the user are revoked.
+//
+// It returns 404 when no user has the requested ID, and 412 when If-Match
+// does not carry the current ETag.
+func (c *UsersController) DeleteUser(w http.ResponseWriter, r *http.Request, id ResourceID, params DeleteUserParams) {
+	if err := requireIfMatch(params.IfMatch); err != nil {
+		writeError(w, err)
 		return
 	}
-	c.DB.Exec("UPDATE users SET name = ? WHERE id = ?", req.Name, req.ID)
-	fmt.Fprintf(w, "User %d updated\n", req.ID)
-	response := UsersResponsePut{
-		ID:   req.ID,
-		Name: req.Name,
+	ctx := r.Context()
+	err := c.Store.WithTx(ctx, func(tx Store) error {
+		before, err := lockUser(ctx, tx, id, params.IfMatch)
+		if err != nil {
+			return err
+		}
+		if err := tx.Users().Disable(ctx, id); err != nil {
+			return err
+		}
+		if err := tx.Sessions().RevokeAll(ctx, id, 0); err != nil {
+			return err
+		}
+		// 論理削除のため、変更後の行（valid_flag=false）も残す
+		after, err := tx.Users().Find(ctx, id)
+		if err != nil {
+			return err
+		}
+		return recordAudit(ctx, tx, AuditDelete, "users_master", id, before, after)
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RevokeUserSessions revokes every session of a user, logging them out
+// everywhere, and returns 204 No Content.
+//
+// It returns 404 when no user has the requested ID.
+func (c *UsersController) RevokeUserSessions(w http.ResponseWriter, r *http.Request, id ResourceID) {
+	ctx := r.Context()
+	if _, err := c.Store.Users().Find(ctx, id); err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := c.Store.Sessions().RevokeAll(ctx, id, 0); err != nil {
+		writeError(w, err)
+		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+	w.WriteHeader(http.StatusNoContent)
 }
 
-// 4. DELETE /users の実装
-func (c *UsersController) DeleteUsers(w http.ResponseWriter, r *http.Request) {
-	var req UsersRequestDelete // 自動生成された型
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+// UnlockUser clears a lock caused by failed logins and resets the failure
+// count, returning 204 No Content.
+//
+// It returns 404 when no user has the requested ID.
+func (c *UsersController) UnlockUser(w http.ResponseWriter, r *http.Request, id ResourceID) {
+	ctx := r.Context()
+	err := c.Store.WithTx(ctx, func(tx Store) error {
+		before, err := tx.Users().Find(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := tx.Users().Unlock(ctx, id); err != nil {
+			return err
+		}
+		after, err := tx.Users().Find(ctx, id)
+		if err != nil {
+			return err
+		}
+		return recordAudit(ctx, tx, AuditUpdate, "users_master", id, before, after)
+	})
+	if err != nil {
+		writeError(w, err)
 		return
 	}
-	c.DB.Exec("DELETE FROM users WHERE id = ?", req.ID)
-	fmt.Fprintf(w, "User %d deleted\n", req.ID)
-	response := UsersResponseDelete{
-		ID:   req.ID,
-		Name: "",
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// saveUser validates in and writes it to the user identified by id, whose
+// ETag must be in ifMatch. Moving the user to another group is checked by
+// [authorizeGroup]. Disabling a user or changing their password revokes
+// their sessions.
+func (c *UsersController) saveUser(ctx context.Context, id ResourceID, in userInput, ifMatch *IfMatch) (User, error) {
+	if err := c.validateUser(ctx, id, &in); err != nil {
+		return User{}, err
+	}
+	if err := in.hashPassword(); err != nil {
+		return User{}, err
+	}
+
+	var u User
+	err := c.Store.WithTx(ctx, func(tx Store) error {
+		// 他荷主のユーザは更新対象にならない（404）
+		before, err := lockUser(ctx, tx, id, ifMatch)
+		if err != nil {
+			return err
+		}
+		if in.GroupID != before.GroupID {
+			if err := authorizeGroup(ctx, tx, in.GroupID); err != nil {
+				return err
+			}
+		}
+		if err := tx.Users().Update(ctx, id, in.UserInput); err != nil {
+			return err
+		}
+		if !in.ValidFlag || in.Password != nil {
+			if err := tx.Sessions().RevokeAll(ctx, id, 0); err != nil {
+				return err
+			}
+		}
+		if u, err = tx.Users().Find(ctx, id); err != nil {
+			return err
+		}
+		return recordAudit(ctx, tx, AuditUpdate, "users_master", id, before, u)
+	})
+	return u, err
+}
+
+// lockUser locks a user for the transaction, checks their version against
+// the If-Match header and returns them as they are before the change.
+func lockUser(ctx context.Context, tx Store, id ResourceID, ifMatch *IfMatch) (User, error) {
+	version, err := tx.Users().Lock(ctx, id)
+	if err != nil {
+		return User{}, err
+	}
+	u, err := tx.Users().Find(ctx, id)
+	if err != nil {
+		return User{}, err
+	}
+	return u, checkVersion(ifMatch, version, func() (any, error) {
+		return u, nil
+	})
+}
+
+// authorizeGroup checks that the logged-in user may put a user in the group.
+// Holders of permissions:manage may choose any group; other callers only a
+// group whose permissions they all hold themselves, so that users:write
+// cannot be used to grant oneself or others more permissions. A missing
+// permission is reported as a [ForbiddenError].
+func authorizeGroup(ctx context.Context, store Store, groupID int64) error {
+	au, ok := AuthUserFrom(ctx)
+	if !ok {
+		return &UnauthorizedError{Message: "authentication required"}
+	}
+	own, err := store.Permissions().GroupCodes(ctx, au.GroupID)
+	if err != nil {
+		return err
+	}
+	held := make(map[string]bool, len(own))
+	for _, code := range own {
+		held[code] = true
+	}
+	if held[managePermission] {
+		return nil
+	}
+	codes, err := store.Permissions().GroupCodes(ctx, groupID)
+	if err != nil {
+		return err
+	}
+	for _, code := range codes {
+		if !held[code] {
+			return &ForbiddenError{Permission: code}
+		}
+	}
+	return nil
+}
+
+// hashPassword sets PasswordHash from Password, leaving it nil when no
+// password was sent.
+func (in *userInput) hashPassword() error {
+	if in.Password == nil {
+		return nil
+	}
+	hash, err := hashPassword(*in.Password)
+	if err != nil {
+		return err
+	}
+	in.PasswordHash = &hash
+	return nil
+}
+
+// validateUser checks the references and unique columns of in before it is
+// written, so that clients get a message naming the offending field.
+// id is the user being updated, or 0 on create.
+//
+// Departments and shippers outside the caller's [Scope] are reported as
+// missing. A shipper-side caller's shipper is filled in when
+// in.ShippingID is nil, and a department must belong to the user's shipper.
+//
+// The unique indexes on users_master remain the final guard against
+// concurrent writes; their violations are mapped to 409 by [writeError].
+func (c *UsersController) validateUser(ctx context.Context, id ResourceID, in *userInput) error {
+	if _, err := mail.ParseAddress(in.Email); err != nil {
+		return &ValidationError{Message: fmt.Sprintf("email %q is not a valid address", in.Email)}
+	}
+
+	users := c.Store.Users()
+	if in.ShippingID == nil {
+		in.ShippingID = scopeOf(ctx).ShippingID
+	}
+	refs := []struct {
+		field  string
+		id     *int64
+		exists func(context.Context, int64) (bool, error)
+	}{
+		{"group_id", &in.GroupID, users.GroupExists},
+		{"department_id", &in.DepartmentID, users.DepartmentExists},
+		{"shipping_id", in.ShippingID, users.ShippingExists},
+	}
+	for _, ref := range refs {
+		if ref.id == nil {
+			continue
+		}
+		ok, err := ref.exists(ctx, *ref.id)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return &ValidationError{Message: fmt.Sprintf("%s %d does not exist", ref.field, *ref.id)}
+		}
+	}
+	if in.ShippingID != nil {
+		ok, err := users.DepartmentOfShipping(ctx, in.DepartmentID, *in.ShippingID)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return &ValidationError{Message: fmt.Sprintf("department_id %d does not belong to shipping_id %d",
+				in.DepartmentID, *in.ShippingID)}
+		}
+	}
+
+	uniques := []struct {
+		column, value string
+		taken         func(context.Context, string, int64) (bool, error)
+	}{
+		{"user_id", in.UserID, users.UserIDTaken},
+		{"email", in.Email, users.EmailTaken},
+	}
+	for _, u := range uniques {
+		ok, err := u.taken(ctx, u.value, id)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return &ConflictError{Message: fmt.Sprintf("%s %q is already in use", u.column, u.value)}
+		}
 	}
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(response)
+	return nil
 }
 
 /*
@@ -0,0 +1,207 @@
+package controllers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// userSortColumns maps the sort parameter to a fixed ORDER BY clause so that
+// user input never reaches the SQL text.
+var userSortColumns = map[ListUsersParamsSort]string{
+	UserSortIDAsc:      "u.id ASC",
+	UserSortIDDesc:     "u.id DESC",
+	UserSortUserIDAsc:  "u.user_id ASC",
+	UserSortUserIDDesc: "u.user_id DESC",
+	UserSortNameAsc:    "u.name ASC, u.id ASC",
+	UserSortNameDesc:   "u.name DESC, u.id ASC",
+}
+
+// userColumns and userFrom select users_master rows with the group and
+// department names embedded. Columns match [scanUser]. Queries must add the
+// [Scope] filter of users_master for alias u.
+const (
+	userColumns = `u.id, u.group_id, g.name, u.user_id, u.name, u.email,
+	u.department_id, d.name, u.shipping_id, u.valid_flag, u.totp_enabled, u.locked_until, u.version`
+	userFrom = `FROM users_master u
+INNER JOIN groups_master g ON g.id = u.group_id
+INNER JOIN departments_master d ON d.id = u.department_id`
+	userSelect = "SELECT " + userColumns + "\n" + userFrom
+)
+
+// mysqlUsers is the [UserRepository] of [MySQLStore].
+type mysqlUsers struct {
+	s *MySQLStore
+}
+
+func (r mysqlUsers) List(ctx context.Context, f UserFilter) ([]User, int, error) {
+	var conds []string
+	var args []any
+	if f.Q != "" {
+		pattern := "%" + escapeLike(f.Q) + "%"
+		conds = append(conds, "(u.name LIKE ? OR u.user_id LIKE ? OR u.email LIKE ?)")
+		args = append(args, pattern, pattern, pattern)
+	}
+	if f.GroupID != nil {
+		conds = append(conds, "u.group_id = ?")
+		args = append(args, *f.GroupID)
+	}
+	if f.DepartmentID != nil {
+		conds = append(conds, "u.department_id = ?")
+		args = append(args, *f.DepartmentID)
+	}
+	if f.ValidFlag != nil {
+		conds = append(conds, "u.valid_flag = ?")
+		args = append(args, *f.ValidFlag)
+	}
+	where, args := scopeOf(ctx).where("users_master", "u", conds, args)
+	orderBy := userSortColumns[UserSortIDAsc]
+	if f.Sort != "" {
+		col, ok := userSortColumns[f.Sort]
+		if !ok {
+			return nil, 0, &BadRequestError{Err: fmt.Errorf("unknown sort %q", f.Sort)}
+		}
+		orderBy = col
+	}
+
+	var total int
+	if err := r.s.q.QueryRowContext(ctx, "SELECT COUNT(*) FROM users_master u"+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.s.q.QueryContext(ctx,
+		userSelect+where+" ORDER BY "+orderBy+" LIMIT ? OFFSET ?",
+		append(args, f.Limit, f.Offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	users := []User{}
+	for rows.Next() {
+		u, err := scanUser(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		users = append(users, u)
+	}
+	return users, total, rows.Err()
+}
+
+func (r mysqlUsers) Find(ctx context.Context, id int64) (User, error) {
+	where, args := scopeOf(ctx).where("users_master", "u", []string{"u.id = ?"}, []any{id})
+	u, err := scanUser(r.s.q.QueryRowContext(ctx, userSelect+where, args...))
+	if errors.Is(err, sql.ErrNoRows) {
+		return u, &NotFoundError{Resource: "user", ID: id}
+	}
+	return u, err
+}
+
+func (r mysqlUsers) Create(ctx context.Context, in UserInput) (int64, error) {
+	// one_time_passwd はNOT NULLのため空文字で登録する
+	res, err := r.s.q.ExecContext(ctx,
+		`INSERT INTO users_master (group_id, user_id, name, email, department_id, shipping_id, valid_flag, one_time_passwd, password_hash)
+		VALUES (?, ?, ?, ?, ?, ?, ?, '', ?)`,
+		in.GroupID, in.UserID, in.Name, in.Email, in.DepartmentID, in.ShippingID, in.ValidFlag, nullString(in.PasswordHash))
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+func (r mysqlUsers) Lock(ctx context.Context, id int64) (int64, error) {
+	where, args := scopeOf(ctx).where("users_master", "", []string{"id = ?"}, []any{id})
+	var version int64
+	err := r.s.q.QueryRowContext(ctx, "SELECT version FROM users_master"+where+" FOR UPDATE", args...).Scan(&version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, &NotFoundError{Resource: "user", ID: id}
+	}
+	return version, err
+}
+
+func (r mysqlUsers) Update(ctx context.Context, id int64, in UserInput) error {
+	// パスワード未指定（NULL）の場合は現在のハッシュを維持する
+	// 他荷主のユーザは更新対象にならない（404）
+	where, args := scopeOf(ctx).where("users_master", "", []string{"id = ?"}, []any{id})
+	res, err := r.s.q.ExecContext(ctx,
+		`UPDATE users_master SET group_id = ?, user_id = ?, name = ?, email = ?, department_id = ?, shipping_id = ?,
+		valid_flag = ?, password_hash = COALESCE(?, password_hash), version = version + 1`+where,
+		append([]any{in.GroupID, in.UserID, in.Name, in.Email, in.DepartmentID, in.ShippingID, in.ValidFlag,
+			nullString(in.PasswordHash)}, args...)...)
+	if err != nil {
+		return err
+	}
+	return requireAffected(res, "user", id)
+}
+
+func (r mysqlUsers) Disable(ctx context.Context, id int64) error {
+	return r.update(ctx, id, "valid_flag = false, version = version + 1")
+}
+
+func (r mysqlUsers) Unlock(ctx context.Context, id int64) error {
+	return r.update(ctx, id, "failed_login_count = 0, locked_until = NULL")
+}
+
+// update applies set to the user identified by id.
+func (r mysqlUsers) update(ctx context.Context, id int64, set string) error {
+	where, args := scopeOf(ctx).where("users_master", "", []string{"id = ?"}, []any{id})
+	res, err := r.s.q.ExecContext(ctx, "UPDATE users_master SET "+set+where, args...)
+	if err != nil {
+		return err
+	}
+	return requireAffected(res, "user", id)
+}
+
+func (r mysqlUsers) GroupExists(ctx context.Context, id int64) (bool, error) {
+	return r.refExists(ctx, "groups_master", id)
+}
+
+func (r mysqlUsers) DepartmentExists(ctx context.Context, id int64) (bool, error) {
+	return r.refExists(ctx, "departments_master", id)
+}
+
+func (r mysqlUsers) ShippingExists(ctx context.Context, id int64) (bool, error) {
+	return r.refExists(ctx, "shippings_master", id)
+}
+
+// refExists reports whether table has a row with the id within the scope.
+func (r mysqlUsers) refExists(ctx context.Context, table string, id int64) (bool, error) {
+	where, args := scopeOf(ctx).where(table, "", []string{"id = ?"}, []any{id})
+	return r.s.exists(ctx, "SELECT 1 FROM "+table+where, args...)
+}
+
+func (r mysqlUsers) DepartmentOfShipping(ctx context.Context, departmentID, shippingID int64) (bool, error) {
+	return r.s.exists(ctx, "SELECT 1 FROM departments_master WHERE id = ? AND shipping_id = ?",
+		departmentID, shippingID)
+}
+
+func (r mysqlUsers) UserIDTaken(ctx context.Context, userID string, exceptID int64) (bool, error) {
+	return r.s.exists(ctx, "SELECT 1 FROM users_master WHERE user_id = ? AND id <> ?", userID, exceptID)
+}
+
+func (r mysqlUsers) EmailTaken(ctx context.Context, email string, exceptID int64) (bool, error) {
+	return r.s.exists(ctx, "SELECT 1 FROM users_master WHERE email = ? AND id <> ?", email, exceptID)
+}
+
+// scanUser reads one row selected by [userSelect]. Columns selected before
+// [userColumns] are scanned into prefix. An expired lock is reported as
+// no lock.
+func scanUser(s rowScanner, prefix ...any) (User, error) {
+	var u User
+	err := s.Scan(append(prefix, &u.ID, &u.GroupID, &u.GroupName, &u.UserID, &u.Name, &u.Email,
+		&u.DepartmentID, &u.DepartmentName, &u.ShippingID, &u.ValidFlag, &u.TotpEnabled, &u.LockedUntil, &u.Version)...)
+	if u.LockedUntil != nil && !u.LockedUntil.After(time.Now()) {
+		u.LockedUntil = nil
+	}
+	return u, err
+}
+
+// nullString stores a nil string as NULL.
+func nullString(s *string) sql.NullString {
+	if s == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: *s, Valid: true}
+}
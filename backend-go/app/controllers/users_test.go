@@ -0,0 +1,448 @@
+package controllers
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// 共通ヘルパー: モックDBとコントローラーを準備する
+func setup(t *testing.T) (*UsersController, sqlmock.Sqlmock, func()) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %s", err)
+	}
+
+	ctrl := &UsersController{Store: NewMySQLStore(db)}
+
+	// 終了処理をクロージャで返す
+	teardown := func() {
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unmet expectations: %s", err)
+		}
+		db.Close()
+	}
+
+	return ctrl, mock, teardown
+}
+
+// 共通ヘルパー: JSONボディを持つリクエストを作成する
+func newJSONRequest(method, url string, body interface{}) (*http.Request, *httptest.ResponseRecorder) {
+	var b bytes.Buffer
+	if body != nil {
+		json.NewEncoder(&b).Encode(body)
+	}
+	req := httptest.NewRequest(method, url, &b)
+	req.Header.Set("Content-Type", "application/json")
+	return req, httptest.NewRecorder()
+}
+
+func ptr[T any](v T) *T { return &v }
+
+// 共通ヘルパー: バージョン1の行に対する If-Match
+var ifMatchV1 = ptr[IfMatch](`"1"`)
+
+// 共通ヘルパー: 更新前のユーザの行ロック（バージョン1）と変更前の行
+func expectUserLock(mock sqlmock.Sqlmock, id int64) {
+	mock.ExpectQuery("SELECT version FROM users_master WHERE id = \\? FOR UPDATE").WithArgs(id).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(1))
+	mock.ExpectQuery("SELECT u.id").WithArgs(id).WillReturnRows(userRow(id, "Before"))
+}
+
+// 共通ヘルパー: 同じトランザクションでの監査ログの書き込み
+func expectAudit(mock sqlmock.Sqlmock, action AuditLogAction, table string, id int64) {
+	mock.ExpectExec("INSERT INTO audit_logs").
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), action, table, id, sqlmock.AnyArg(), nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+}
+
+var userRowColumns = []string{"id", "group_id", "group_name", "user_id", "name", "email", "department_id", "department_name", "shipping_id", "valid_flag", "totp_enabled", "locked_until", "version"}
+
+// 共通ヘルパー: 1ユーザ分の検索結果
+func userRow(id int64, name string) *sqlmock.Rows {
+	return sqlmock.NewRows(userRowColumns).
+		AddRow(id, 1, "管理者", fmt.Sprintf("u%d", id), name, fmt.Sprintf("u%d@example.com", id), 2, "総務部", nil, true, false, nil, 1)
+}
+
+// 共通ヘルパー: 参照先・一意性チェックの期待値
+func expectValidUser(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery("SELECT 1 FROM groups_master").WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+	mock.ExpectQuery("SELECT 1 FROM departments_master").WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+	mock.ExpectQuery("SELECT 1 FROM users_master WHERE user_id").WillReturnRows(sqlmock.NewRows([]string{"1"}))
+	mock.ExpectQuery("SELECT 1 FROM users_master WHERE email").WillReturnRows(sqlmock.NewRows([]string{"1"}))
+}
+
+// 共通ヘルパー: グループに付与された権限の検索結果
+func expectGroupCodes(mock sqlmock.Sqlmock, groupID int64, codes ...string) {
+	rows := sqlmock.NewRows([]string{"code"})
+	for _, code := range codes {
+		rows.AddRow(code)
+	}
+	mock.ExpectQuery("SELECT p.code FROM group_permissions").WithArgs(groupID).WillReturnRows(rows)
+}
+
+func newUserPost() UsersRequestPost {
+	return UsersRequestPost{GroupID: 1, UserID: "jdoe", Name: "John Doe", Email: "jdoe@example.com", DepartmentID: 2}
+}
+
+func TestUsersController_CreateUser(t *testing.T) {
+	ctrl, mock, teardown := setup(t)
+	defer teardown()
+
+	// 1. このテスト固有のDB期待値
+	expectValidUser(mock)
+	expectGroupCodes(mock, 1, managePermission, "users:write")
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO users_master").
+		WithArgs(int64(1), "jdoe", "John Doe", "jdoe@example.com", int64(2), nil, true, nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery("SELECT u.id").WithArgs(int64(1)).WillReturnRows(userRow(1, "John Doe"))
+	expectAudit(mock, AuditCreate, "users_master", 1)
+	mock.ExpectCommit()
+
+	// 2. このテスト固有のリクエスト
+	req, w := newJSONRequest("POST", "/users", newUserPost())
+
+	// 3. 実行
+	ctrl.CreateUser(w, withGroup(req, 1))
+
+	// 4. 検証
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if loc := w.Header().Get("Location"); loc != "/users/1" {
+		t.Errorf("Expected Location /users/1, got %q", loc)
+	}
+	var resp User
+	json.NewDecoder(w.Body).Decode(&resp)
+	if resp.GroupName != "管理者" || resp.DepartmentName != "総務部" {
+		t.Errorf("names are not embedded: %+v", resp)
+	}
+}
+
+func TestUsersController_CreateUser_UnknownGroup(t *testing.T) {
+	ctrl, mock, teardown := setup(t)
+	defer teardown()
+
+	mock.ExpectQuery("SELECT 1 FROM groups_master").WithArgs(int64(1)).WillReturnRows(sqlmock.NewRows([]string{"1"}))
+
+	req, w := newJSONRequest("POST", "/users", newUserPost())
+	ctrl.CreateUser(w, req)
+
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected 422, got %d", w.Code)
+	}
+	if !strings.Contains(w.Body.String(), "group_id 1 does not exist") {
+		t.Errorf("unexpected message: %s", w.Body.String())
+	}
+}
+
+func TestUsersController_CreateUser_DuplicateEmail(t *testing.T) {
+	ctrl, mock, teardown := setup(t)
+	defer teardown()
+
+	mock.ExpectQuery("SELECT 1 FROM groups_master").WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+	mock.ExpectQuery("SELECT 1 FROM departments_master").WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+	mock.ExpectQuery("SELECT 1 FROM users_master WHERE user_id").WillReturnRows(sqlmock.NewRows([]string{"1"}))
+	mock.ExpectQuery("SELECT 1 FROM users_master WHERE email").WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+
+	req, w := newJSONRequest("POST", "/users", newUserPost())
+	ctrl.CreateUser(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Errorf("Expected 409, got %d", w.Code)
+	}
+}
+
+func TestUsersController_CreateUser_DuplicateRace(t *testing.T) {
+	ctrl, mock, teardown := setup(t)
+	defer teardown()
+
+	// 事前チェック後に他のリクエストが登録した場合は一意インデックスで弾かれる
+	expectValidUser(mock)
+	expectGroupCodes(mock, 1, managePermission, "users:write")
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO users_master").
+		WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry"})
+	mock.ExpectRollback()
+
+	req, w := newJSONRequest("POST", "/users", newUserPost())
+	ctrl.CreateUser(w, withGroup(req, 1))
+
+	if w.Code != http.StatusConflict {
+		t.Errorf("Expected 409, got %d", w.Code)
+	}
+}
+
+func TestUsersController_ListUsers(t *testing.T) {
+	ctrl, mock, teardown := setup(t)
+	defer teardown()
+
+	// --- DBの準備 ---
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM users_master u WHERE \\(u.name LIKE \\? OR u.user_id LIKE \\? OR u.email LIKE \\?\\) AND u.valid_flag = \\?").
+		WithArgs("%a\\_b%", "%a\\_b%", "%a\\_b%", true).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(12))
+	rows := userRow(2, "Bob").AddRow(1, 1, "管理者", "ualice", "Alice", "alice@example.com", 2, "総務部", nil, true, false, nil, 1)
+	mock.ExpectQuery("ORDER BY u.name DESC, u.id ASC LIMIT \\? OFFSET \\?").
+		WithArgs("%a\\_b%", "%a\\_b%", "%a\\_b%", true, 2, 10).
+		WillReturnRows(rows)
+
+	// --- HTTPの準備 ---
+	req, w := newJSONRequest("GET", "/users", nil)
+	sort := UserSortNameDesc
+
+	// --- 実行 ---
+	ctrl.ListUsers(w, req, ListUsersParams{Limit: ptr(2), Offset: ptr(10), Q: ptr("a_b"), ValidFlag: ptr(true), Sort: &sort})
+
+	// --- 検証 ---
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp UsersResponseGet
+	json.NewDecoder(w.Body).Decode(&resp)
+
+	if len(resp.Users) != 2 || resp.Total != 12 || resp.Limit != 2 || resp.Offset != 10 {
+		t.Errorf("unexpected response %+v", resp)
+	}
+}
+
+func TestUsersController_GetUser_NotFound(t *testing.T) {
+	ctrl, mock, teardown := setup(t)
+	defer teardown()
+
+	mock.ExpectQuery("SELECT u.id").
+		WithArgs(int64(5)).
+		WillReturnRows(sqlmock.NewRows(userRowColumns))
+
+	req, w := newJSONRequest("GET", "/users/5", nil)
+	ctrl.GetUser(w, req, 5)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404, got %d", w.Code)
+	}
+}
+
+func TestUsersController_UpdateUser(t *testing.T) {
+	ctrl, mock, teardown := setup(t)
+	defer teardown()
+
+	var targetID ResourceID = 1
+	newName := "Updated Name"
+
+	// --- DBの準備 ---
+	expectValidUser(mock)
+	mock.ExpectBegin()
+	expectUserLock(mock, targetID)
+	mock.ExpectExec("UPDATE users_master SET group_id = \\?, user_id = \\?, name = \\?").
+		WithArgs(int64(1), "jdoe", newName, "jdoe@example.com", int64(2), nil, false, nil, targetID).
+		WillReturnResult(sqlmock.NewResult(0, 1)) // RowsAffected=1
+	// 無効化したユーザのセッションは失効させる
+	mock.ExpectExec("UPDATE user_sessions SET revoked_at").
+		WithArgs(sqlmock.AnyArg(), targetID, int64(0)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectQuery("SELECT u.id").WithArgs(targetID).WillReturnRows(userRow(1, newName))
+	expectAudit(mock, AuditUpdate, "users_master", targetID)
+	mock.ExpectCommit()
+
+	// --- HTTPの準備 ---
+	req, w := newJSONRequest("PUT", "/users/1", UsersRequestPut{
+		GroupID: 1, UserID: "jdoe", Name: newName, Email: "jdoe@example.com", DepartmentID: 2, ValidFlag: false,
+	})
+
+	// --- 実行 ---
+	ctrl.UpdateUser(w, req, targetID, UpdateUserParams{IfMatch: ifMatchV1})
+
+	// --- 検証 ---
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp UsersResponsePut
+	json.NewDecoder(w.Body).Decode(&resp)
+
+	if resp.Name != newName {
+		t.Errorf("Expected name %s, got %s", newName, resp.Name)
+	}
+}
+
+func TestUsersController_PatchUser_Enable(t *testing.T) {
+	ctrl, mock, teardown := setup(t)
+	defer teardown()
+
+	mock.ExpectQuery("SELECT u.id").WithArgs(int64(3)).WillReturnRows(
+		sqlmock.NewRows(userRowColumns).AddRow(3, 1, "管理者", "ubob", "Bob", "bob@example.com", 2, "総務部", nil, false, false, nil, 1))
+	expectValidUser(mock)
+	mock.ExpectBegin()
+	expectUserLock(mock, 3)
+	mock.ExpectExec("UPDATE users_master SET").
+		WithArgs(int64(1), "ubob", "Bob", "bob@example.com", int64(2), nil, true, nil, int64(3)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("SELECT u.id").WithArgs(int64(3)).WillReturnRows(userRow(3, "Bob"))
+	expectAudit(mock, AuditUpdate, "users_master", 3)
+	mock.ExpectCommit()
+
+	req, w := newJSONRequest("PATCH", "/users/3", UsersRequestPatch{ValidFlag: ptr(true)})
+	ctrl.PatchUser(w, req, 3, PatchUserParams{IfMatch: ifMatchV1})
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestUsersController_PatchUser_SelfEscalation(t *testing.T) {
+	ctrl, mock, teardown := setup(t)
+	defer teardown()
+
+	// users:write だけのグループ 2 のユーザが自分を管理者グループ 1 に移そうとする
+	mock.ExpectQuery("SELECT u.id").WithArgs(int64(1)).WillReturnRows(
+		sqlmock.NewRows(userRowColumns).AddRow(1, 2, "担当者", "u1", "Alice", "u1@example.com", 2, "総務部", nil, true, false, nil, 1))
+	expectValidUser(mock)
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT version FROM users_master WHERE id = \\? FOR UPDATE").WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(1))
+	mock.ExpectQuery("SELECT u.id").WithArgs(int64(1)).WillReturnRows(
+		sqlmock.NewRows(userRowColumns).AddRow(1, 2, "担当者", "u1", "Alice", "u1@example.com", 2, "総務部", nil, true, false, nil, 1))
+	expectGroupCodes(mock, 2, "users:read", "users:write")
+	expectGroupCodes(mock, 1, managePermission, "users:read", "users:write")
+	mock.ExpectRollback()
+
+	req, w := newJSONRequest("PATCH", "/users/1", UsersRequestPatch{GroupID: ptr(int64(1))})
+	ctrl.PatchUser(w, withGroup(req, 2), 1, PatchUserParams{IfMatch: ifMatchV1})
+
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("Expected 403, got %d: %s", w.Code, w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), managePermission) {
+		t.Errorf("unexpected message: %s", w.Body.String())
+	}
+}
+
+func TestUsersController_PatchUser_Password(t *testing.T) {
+	ctrl, mock, teardown := setup(t)
+	defer teardown()
+
+	mock.ExpectQuery("SELECT u.id").WithArgs(int64(3)).WillReturnRows(userRow(3, "Bob"))
+	expectValidUser(mock)
+	mock.ExpectBegin()
+	expectUserLock(mock, 3)
+	mock.ExpectExec("password_hash = COALESCE").
+		WithArgs(int64(1), "u3", "Bob", "u3@example.com", int64(2), nil, true, bcryptOf("new password"), int64(3)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	// パスワード変更時は既存セッションを失効させる
+	mock.ExpectExec("UPDATE user_sessions SET revoked_at").
+		WithArgs(sqlmock.AnyArg(), int64(3), int64(0)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("SELECT u.id").WithArgs(int64(3)).WillReturnRows(userRow(3, "Bob"))
+	expectAudit(mock, AuditUpdate, "users_master", 3)
+	mock.ExpectCommit()
+
+	req, w := newJSONRequest("PATCH", "/users/3", UsersRequestPatch{Password: ptr("new password")})
+	ctrl.PatchUser(w, req, 3, PatchUserParams{IfMatch: ifMatchV1})
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if strings.Contains(w.Body.String(), "password") {
+		t.Errorf("password leaked into the response: %s", w.Body.String())
+	}
+}
+
+func TestUsersController_RevokeUserSessions(t *testing.T) {
+	ctrl, mock, teardown := setup(t)
+	defer teardown()
+
+	mock.ExpectQuery("SELECT u.id").WithArgs(int64(7)).WillReturnRows(userRow(7, "Alice"))
+	mock.ExpectExec("UPDATE user_sessions SET revoked_at").
+		WithArgs(sqlmock.AnyArg(), int64(7), int64(0)).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	req, w := newJSONRequest("DELETE", "/users/7/sessions", nil)
+	ctrl.RevokeUserSessions(w, req, 7)
+
+	if w.Code != http.StatusNoContent {
+		t.Errorf("Expected 204, got %d", w.Code)
+	}
+}
+
+func TestUsersController_DeleteUser(t *testing.T) {
+	ctrl, mock, teardown := setup(t)
+	defer teardown()
+
+	// 物理削除ではなく無効化し、セッションを失効させる
+	mock.ExpectBegin()
+	expectUserLock(mock, 123)
+	mock.ExpectExec("UPDATE users_master SET valid_flag = false, version = version \\+ 1 WHERE id = \\?").
+		WithArgs(int64(123)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE user_sessions SET revoked_at").
+		WithArgs(sqlmock.AnyArg(), int64(123), int64(0)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT u.id").WithArgs(int64(123)).WillReturnRows(userRow(123, "Bob"))
+	expectAudit(mock, AuditDelete, "users_master", 123)
+	mock.ExpectCommit()
+
+	req, w := newJSONRequest("DELETE", "/users/123", nil)
+
+	ctrl.DeleteUser(w, req, 123, DeleteUserParams{IfMatch: ifMatchV1})
+
+	if w.Code != http.StatusNoContent {
+		t.Errorf("Expected 204, got %d", w.Code)
+	}
+}
+
+func TestUsersController_CreateUser_InvalidJSON(t *testing.T) {
+	ctrl, _, teardown := setup(t)
+	defer teardown()
+
+	req := httptest.NewRequest("POST", "/users", bytes.NewBufferString("{invalid"))
+	w := httptest.NewRecorder()
+
+	ctrl.CreateUser(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400, got %d", w.Code)
+	}
+	var resp ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("body is not ErrorResponse JSON: %v", err)
+	}
+	if resp.Code != http.StatusBadRequest {
+		t.Errorf("Expected code 400, got %d", resp.Code)
+	}
+}
+
+func TestUsersController_ListUsers_DBError(t *testing.T) {
+	ctrl, mock, teardown := setup(t)
+	defer teardown()
+
+	mock.ExpectQuery("SELECT COUNT").WillReturnError(errors.New("connection lost"))
+
+	req, w := newJSONRequest("GET", "/users", nil)
+	ctrl.ListUsers(w, req, ListUsersParams{})
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("Expected 500, got %d", w.Code)
+	}
+	if strings.Contains(w.Body.String(), "connection lost") {
+		t.Errorf("internal error leaked to client: %s", w.Body.String())
+	}
+}
+
+// bcryptOf matches a bcrypt hash of password passed as a query argument.
+type bcryptOf string
+
+func (b bcryptOf) Match(v driver.Value) bool {
+	hash, ok := v.(string)
+	return ok && bcrypt.CompareHashAndPassword([]byte(hash), []byte(b)) == nil
+}
@@ -0,0 +1,71 @@
+package holiday
+
+import "time"
+
+// Calendar tells business days from non-working days. By default the
+// weekdays other than national holidays are business days; Overrides
+// changes that for particular days, such as the closures and extra working
+// days of a warehouse.
+//
+// The zero value is the default calendar. Overrides must mark finitely many
+// days as non-working for the searches below to end, which a map always
+// does.
+type Calendar struct {
+	// Overrides maps days to whether they are business days.
+	Overrides map[Date]bool
+}
+
+// IsBusinessDay reports whether d is a business day.
+func (c Calendar) IsBusinessDay(d Date) bool {
+	if working, ok := c.Overrides[d]; ok {
+		return working
+	}
+	if wd := d.Weekday(); wd == time.Saturday || wd == time.Sunday {
+		return false
+	}
+	return !IsHoliday(d)
+}
+
+// NextBusinessDay returns the first business day after d.
+func (c Calendar) NextBusinessDay(d Date) Date {
+	return c.AddBusinessDays(d, 1)
+}
+
+// PrevBusinessDay returns the last business day before d.
+func (c Calendar) PrevBusinessDay(d Date) Date {
+	return c.AddBusinessDays(d, -1)
+}
+
+// AddBusinessDays returns the n-th business day after d, or before d when n
+// is negative. d itself need not be a business day; n = 0 returns d.
+func (c Calendar) AddBusinessDays(d Date, n int) Date {
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+	for n > 0 {
+		d = d.AddDays(step)
+		if c.IsBusinessDay(d) {
+			n--
+		}
+	}
+	return d
+}
+
+// OnOrBefore returns d when it is a business day, and the last business day
+// before it otherwise.
+func (c Calendar) OnOrBefore(d Date) Date {
+	if c.IsBusinessDay(d) {
+		return d
+	}
+	return c.PrevBusinessDay(d)
+}
+
+// OnOrAfter returns d when it is a business day, and the first business day
+// after it otherwise.
+func (c Calendar) OnOrAfter(d Date) Date {
+	if c.IsBusinessDay(d) {
+		return d
+	}
+	return c.NextBusinessDay(d)
+}
@@ -0,0 +1,264 @@
+// Package holiday computes the national holidays of Japan and the business
+// days derived from them.
+//
+// Holidays follow the Act on National Holidays (国民の祝日に関する法律) as
+// amended up to the special measures of 2019–2021, including substitute
+// holidays (振替休日) and citizens' holidays (国民の休日). They are computed
+// by rule, so any year can be asked for; the equinox days are the usual
+// astronomical approximation, exact from 1900 to 2099, and future changes
+// of the law are not known in advance.
+package holiday
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Date is a day of the calendar, without a time of day or a location.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// DateOf returns the day of t in its location.
+func DateOf(t time.Time) Date {
+	y, m, d := t.Date()
+	return Date{y, m, d}
+}
+
+// In returns the start of the day in loc.
+func (d Date) In(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+// AddDays returns the day n days after d, or before it when n is negative.
+func (d Date) AddDays(n int) Date {
+	return DateOf(d.In(time.UTC).AddDate(0, 0, n))
+}
+
+// Weekday returns the day of the week of d.
+func (d Date) Weekday() time.Weekday {
+	return d.In(time.UTC).Weekday()
+}
+
+// Before reports whether d is earlier than e.
+func (d Date) Before(e Date) bool {
+	return d.In(time.UTC).Before(e.In(time.UTC))
+}
+
+// String formats d as YYYY-MM-DD.
+func (d Date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// Holiday is a national holiday.
+type Holiday struct {
+	Date Date
+	// Name is the Japanese name, such as 元日 or 振替休日.
+	Name string
+}
+
+// Substitute and citizens' holidays are named after the rule making them.
+const (
+	SubstituteHoliday = "振替休日"
+	CitizensHoliday   = "国民の休日"
+)
+
+// The act came into force on 1948-07-20; the substitute holiday on
+// 1973-04-12 and the citizens' holiday on 1985-12-27.
+var (
+	actStart        = Date{1948, time.July, 20}
+	substituteStart = Date{1973, time.April, 12}
+	citizensStart   = Date{1985, time.December, 27}
+)
+
+// special holds the holidays set by one-off acts.
+var special = map[Date]string{
+	{1959, time.April, 10}:    "皇太子明仁親王の結婚の儀",
+	{1989, time.February, 24}: "昭和天皇の大喪の礼",
+	{1990, time.November, 12}: "即位礼正殿の儀",
+	{1993, time.June, 9}:      "皇太子徳仁親王の結婚の儀",
+	{2019, time.May, 1}:       "天皇の即位の日",
+	{2019, time.October, 22}:  "即位礼正殿の儀",
+}
+
+// cache holds the holidays computed for each year.
+var cache sync.Map // int -> []Holiday
+
+// Holidays returns the national holidays of year in date order.
+func Holidays(year int) []Holiday {
+	if v, ok := cache.Load(year); ok {
+		return slices.Clone(v.([]Holiday))
+	}
+	names := holidays(year)
+	list := make([]Holiday, 0, len(names))
+	for d, name := range names {
+		list = append(list, Holiday{Date: d, Name: name})
+	}
+	slices.SortFunc(list, func(a, b Holiday) int { return a.Date.In(time.UTC).Compare(b.Date.In(time.UTC)) })
+	cache.Store(year, list)
+	return slices.Clone(list)
+}
+
+// Lookup returns the name of the national holiday on d.
+func Lookup(d Date) (string, bool) {
+	for _, h := range Holidays(d.Year) {
+		if h.Date == d {
+			return h.Name, true
+		}
+	}
+	return "", false
+}
+
+// IsHoliday reports whether d is a national holiday.
+func IsHoliday(d Date) bool {
+	_, ok := Lookup(d)
+	return ok
+}
+
+// holidays computes the holidays of year. The holidays of the act (国民の
+// 祝日) come first, as substitute and citizens' holidays depend on them.
+func holidays(year int) map[Date]string {
+	h := map[Date]string{}
+	add := func(d Date, name string) {
+		if !d.Before(actStart) {
+			h[d] = name
+		}
+	}
+	fixed := func(from, to int, month time.Month, day int, name string) {
+		if year >= from && (to == 0 || year <= to) {
+			add(Date{year, month, day}, name)
+		}
+	}
+	monday := func(from, to int, month time.Month, week int, name string) {
+		if year >= from && (to == 0 || year <= to) {
+			add(nthMonday(year, month, week), name)
+		}
+	}
+
+	fixed(1949, 0, time.January, 1, "元日")
+	fixed(1949, 1999, time.January, 15, "成人の日")
+	monday(2000, 0, time.January, 2, "成人の日")
+	fixed(1967, 0, time.February, 11, "建国記念の日")
+	fixed(2020, 0, time.February, 23, "天皇誕生日")
+	if year >= 1949 {
+		add(Date{year, time.March, vernalEquinox(year)}, "春分の日")
+	}
+	fixed(1949, 1988, time.April, 29, "天皇誕生日")
+	fixed(1989, 2006, time.April, 29, "みどりの日")
+	fixed(2007, 0, time.April, 29, "昭和の日")
+	fixed(1949, 0, time.May, 3, "憲法記念日")
+	fixed(2007, 0, time.May, 4, "みどりの日")
+	fixed(1949, 0, time.May, 5, "こどもの日")
+	fixed(1996, 2002, time.July, 20, "海の日")
+	fixed(1966, 2002, time.September, 15, "敬老の日")
+	monday(2003, 0, time.September, 3, "敬老の日")
+	if year >= 1948 {
+		add(Date{year, time.September, autumnalEquinox(year)}, "秋分の日")
+	}
+	fixed(1966, 1999, time.October, 10, "体育の日")
+	monday(2000, 2019, time.October, 2, "体育の日")
+	fixed(1948, 0, time.November, 3, "文化の日")
+	fixed(1948, 0, time.November, 23, "勤労感謝の日")
+	fixed(1989, 2018, time.December, 23, "天皇誕生日")
+
+	// 東京オリンピック・パラリンピックに伴う2020年・2021年の移動
+	switch year {
+	case 2020:
+		add(Date{year, time.July, 23}, "海の日")
+		add(Date{year, time.July, 24}, "スポーツの日")
+		add(Date{year, time.August, 10}, "山の日")
+	case 2021:
+		add(Date{year, time.July, 22}, "海の日")
+		add(Date{year, time.July, 23}, "スポーツの日")
+		add(Date{year, time.August, 8}, "山の日")
+	default:
+		monday(2003, 0, time.July, 3, "海の日")
+		fixed(2016, 0, time.August, 11, "山の日")
+		monday(2022, 0, time.October, 2, "スポーツの日")
+	}
+
+	for d, name := range special {
+		if d.Year == year {
+			add(d, name)
+		}
+	}
+
+	// 振替休日: 祝日が日曜日なら、その後の最も近い祝日でない日（2006年までは翌日の月曜日のみ）
+	substitutes := map[Date]bool{}
+	for d := range h {
+		if d.Weekday() != time.Sunday || d.Before(substituteStart) {
+			continue
+		}
+		next := d.AddDays(1)
+		for year >= 2007 && h[next] != "" {
+			next = next.AddDays(1)
+		}
+		if h[next] == "" {
+			substitutes[next] = true
+		}
+	}
+	// 国民の休日: 前日と翌日が祝日である祝日でない日（2006年までは日曜日と振替休日を除く）
+	// 年をまたぐことはないため、年内の日だけを調べる
+	citizens := map[Date]bool{}
+	for d := range h {
+		mid := d.AddDays(1)
+		if h[mid] != "" || h[mid.AddDays(1)] == "" || mid.Before(citizensStart) ||
+			year < 2007 && (mid.Weekday() == time.Sunday || substitutes[mid]) {
+			continue
+		}
+		citizens[mid] = true
+	}
+	for d := range substitutes {
+		// 12月31日が日曜日の祝日になることはないため、振替休日は常に同じ年
+		h[d] = SubstituteHoliday
+	}
+	for d := range citizens {
+		if !substitutes[d] {
+			h[d] = CitizensHoliday
+		}
+	}
+	return h
+}
+
+// nthMonday returns the week-th Monday of the month (ハッピーマンデー).
+func nthMonday(year int, month time.Month, week int) Date {
+	first := Date{year, month, 1}
+	offset := (int(time.Monday) - int(first.Weekday()) + 7) % 7
+	return Date{year, month, 1 + offset + 7*(week-1)}
+}
+
+// vernalEquinox and autumnalEquinox return the day of March and September
+// of the equinox in Japan Standard Time.
+func vernalEquinox(year int) int {
+	switch {
+	case year < 1980:
+		return equinox(20.8357, year, 1983)
+	case year < 2100:
+		return equinox(20.8431, year, 1980)
+	default:
+		return equinox(21.8510, year, 1980)
+	}
+}
+
+func autumnalEquinox(year int) int {
+	switch {
+	case year < 1980:
+		return equinox(23.2588, year, 1983)
+	case year < 2100:
+		return equinox(23.2488, year, 1980)
+	default:
+		return equinox(24.2488, year, 1980)
+	}
+}
+
+// equinox evaluates the approximation base + 0.242194(year-1980) -
+// int((year-leap)/4), the drift of the tropical year corrected by the leap
+// days since leap. The division truncates toward zero, as in the published
+// formula.
+func equinox(base float64, year, leap int) int {
+	return int(base + 0.242194*float64(year-1980) - float64((year-leap)/4))
+}
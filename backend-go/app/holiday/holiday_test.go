@@ -0,0 +1,84 @@
+package holiday
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// 内閣府の「国民の祝日」一覧と照合する
+func TestHolidays(t *testing.T) {
+	cases := map[int]string{
+		2019: "01-01 元日,01-14 成人の日,02-11 建国記念の日,03-21 春分の日,04-29 昭和の日,04-30 国民の休日," +
+			"05-01 天皇の即位の日,05-02 国民の休日,05-03 憲法記念日,05-04 みどりの日,05-05 こどもの日,05-06 振替休日," +
+			"07-15 海の日,08-11 山の日,08-12 振替休日,09-16 敬老の日,09-23 秋分の日,10-14 体育の日," +
+			"10-22 即位礼正殿の儀,11-03 文化の日,11-04 振替休日,11-23 勤労感謝の日",
+		2020: "01-01 元日,01-13 成人の日,02-11 建国記念の日,02-23 天皇誕生日,02-24 振替休日,03-20 春分の日," +
+			"04-29 昭和の日,05-03 憲法記念日,05-04 みどりの日,05-05 こどもの日,05-06 振替休日,07-23 海の日," +
+			"07-24 スポーツの日,08-10 山の日,09-21 敬老の日,09-22 秋分の日,11-03 文化の日,11-23 勤労感謝の日",
+		2021: "01-01 元日,01-11 成人の日,02-11 建国記念の日,02-23 天皇誕生日,03-20 春分の日,04-29 昭和の日," +
+			"05-03 憲法記念日,05-04 みどりの日,05-05 こどもの日,07-22 海の日,07-23 スポーツの日,08-08 山の日," +
+			"08-09 振替休日,09-20 敬老の日,09-23 秋分の日,11-03 文化の日,11-23 勤労感謝の日",
+		2026: "01-01 元日,01-12 成人の日,02-11 建国記念の日,02-23 天皇誕生日,03-20 春分の日,04-29 昭和の日," +
+			"05-03 憲法記念日,05-04 みどりの日,05-05 こどもの日,05-06 振替休日,07-20 海の日,08-11 山の日," +
+			"09-21 敬老の日,09-22 国民の休日,09-23 秋分の日,10-12 スポーツの日,11-03 文化の日,11-23 勤労感謝の日",
+		// 振替休日が月曜日のみだった時代と、日曜日の国民の休日がなかった時代
+		2006: "01-01 元日,01-02 振替休日,01-09 成人の日,02-11 建国記念の日,03-21 春分の日,04-29 みどりの日," +
+			"05-03 憲法記念日,05-04 国民の休日,05-05 こどもの日,07-17 海の日,09-18 敬老の日,09-23 秋分の日," +
+			"10-09 体育の日,11-03 文化の日,11-23 勤労感謝の日,12-23 天皇誕生日",
+		1948: "09-23 秋分の日,11-03 文化の日,11-23 勤労感謝の日",
+	}
+	for year, want := range cases {
+		var got []string
+		for _, h := range Holidays(year) {
+			got = append(got, h.Date.String()[5:]+" "+h.Name)
+		}
+		if g := strings.Join(got, ","); g != want {
+			t.Errorf("%d:\n got %s\nwant %s", year, g, want)
+		}
+	}
+}
+
+func TestEquinox(t *testing.T) {
+	cases := []struct {
+		year            int
+		vernal, autumal int
+	}{
+		{1960, 20, 23}, {1979, 21, 24}, {2000, 20, 23}, {2012, 20, 22}, {2025, 20, 23}, {2030, 20, 23}, {2044, 20, 22},
+	}
+	for _, tc := range cases {
+		if v, a := vernalEquinox(tc.year), autumnalEquinox(tc.year); v != tc.vernal || a != tc.autumal {
+			t.Errorf("%d: got %d/%d, want %d/%d", tc.year, v, a, tc.vernal, tc.autumal)
+		}
+	}
+}
+
+func TestCalendar(t *testing.T) {
+	d := func(m time.Month, day int) Date { return Date{2026, m, day} }
+	c := Calendar{Overrides: map[Date]bool{
+		d(time.May, 7): false, // 倉庫の休業日
+		d(time.May, 9): true,  // 土曜の臨時営業日
+	}}
+	cases := []struct {
+		name string
+		got  Date
+		want Date
+	}{
+		{"next over golden week", c.NextBusinessDay(d(time.May, 1)), d(time.May, 8)},
+		{"extra working saturday", c.NextBusinessDay(d(time.May, 8)), d(time.May, 9)},
+		{"add", c.AddBusinessDays(d(time.April, 30), 3), d(time.May, 9)},
+		{"subtract", c.AddBusinessDays(d(time.May, 11), -2), d(time.May, 8)},
+		{"zero", c.AddBusinessDays(d(time.May, 3), 0), d(time.May, 3)},
+		{"prev", c.PrevBusinessDay(d(time.May, 8)), d(time.May, 1)},
+		{"on or before", c.OnOrBefore(d(time.February, 23)), d(time.February, 20)},
+		{"on or after", c.OnOrAfter(d(time.February, 23)), d(time.February, 24)},
+	}
+	for _, tc := range cases {
+		if tc.got != tc.want {
+			t.Errorf("%s: got %v, want %v", tc.name, tc.got, tc.want)
+		}
+	}
+	if (Calendar{}).IsBusinessDay(d(time.May, 6)) || !c.IsBusinessDay(d(time.May, 9)) {
+		t.Error("unexpected business days")
+	}
+}
@@ -0,0 +1,200 @@
+// Package logging configures the structured server log ([log/slog]) and
+// writes one access log line per HTTP request.
+//
+// Every request is given an ID by [RequestID], taken from the X-Request-ID
+// header or generated, and echoed in the response. Records logged with a
+// request context carry the request ID and, when tracing is enabled, the
+// trace and span IDs so that log lines can be joined with the traces.
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RequestIDHeader is the header carrying the request ID in both directions.
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLen bounds the length of request IDs accepted from clients.
+const maxRequestIDLen = 128
+
+// New returns a logger writing to w in the given format ("json" or "text").
+// Records below level are discarded.
+func New(w io.Writer, format string, level slog.Leveler) *slog.Logger {
+	opts := &slog.HandlerOptions{Level: level}
+	var h slog.Handler
+	if format == "text" {
+		h = slog.NewTextHandler(w, opts)
+	} else {
+		h = slog.NewJSONHandler(w, opts)
+	}
+	return slog.New(contextHandler{h})
+}
+
+// contextHandler adds the request and trace IDs found in the context of each
+// record.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, rec slog.Record) error {
+	if id := RequestIDFrom(ctx); id != "" {
+		rec.AddAttrs(slog.String("request_id", id))
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		rec.AddAttrs(
+			slog.String("trace_id", sc.TraceID().String()),
+			slog.String("span_id", sc.SpanID().String()),
+		)
+	}
+	return h.Handler.Handle(ctx, rec)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
+
+type requestIDKey struct{}
+
+// RequestIDFrom returns the ID of the request being served, or "" outside a
+// request.
+func RequestIDFrom(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// RequestID assigns an ID to each request. A well-formed X-Request-ID sent by
+// the client (or a proxy in front of the server) is kept; otherwise a random
+// one is generated. The ID is returned in the X-Request-ID response header.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if !validRequestID(id) {
+			id = newRequestID()
+		}
+		w.Header().Set(RequestIDHeader, id)
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), requestIDKey{}, id)))
+	})
+}
+
+// validRequestID reports whether id is short and made of printable ASCII only,
+// so that it is safe to log and echo.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLen {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// entry collects what the handlers report about the request for its access
+// log line.
+type entry struct {
+	userID int64
+	login  string
+	err    error
+}
+
+type entryKey struct{}
+
+// SetUser records the authenticated user of the request in its access log
+// line. It does nothing outside [AccessLog].
+func SetUser(ctx context.Context, id int64, login string) {
+	if e, ok := ctx.Value(entryKey{}).(*entry); ok {
+		e.userID, e.login = id, login
+	}
+}
+
+// responseWriter lets handlers attach the cause of a failure to the access
+// log line through [RecordError].
+type responseWriter struct {
+	middleware.WrapResponseWriter
+	entry *entry
+}
+
+// Unwrap lets [http.ResponseController] reach the underlying writer.
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.WrapResponseWriter
+}
+
+// Flush sends buffered data to the client, for streaming responses.
+func (w *responseWriter) Flush() {
+	http.NewResponseController(w.WrapResponseWriter).Flush()
+}
+
+// RecordError attaches err to the access log line of the request served by w.
+// It reports false when w does not come from [AccessLog], in which case the
+// caller should log err itself.
+func RecordError(w http.ResponseWriter, err error) bool {
+	lw, ok := w.(*responseWriter)
+	if ok {
+		lw.entry.err = err
+	}
+	return ok
+}
+
+// AccessLog writes one line per request with its method, route pattern,
+// path, status, size, duration and authenticated user. Server errors are
+// logged at the error level with the error recorded by [RecordError].
+//
+// It must be installed on the root chi router, after [RequestID] and the
+// tracing middleware, so that the line carries their IDs.
+func AccessLog(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		e := &entry{}
+		ww := &responseWriter{middleware.NewWrapResponseWriter(w, r.ProtoMajor), e}
+		next.ServeHTTP(ww, r.WithContext(context.WithValue(r.Context(), entryKey{}, e)))
+
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		attrs := []slog.Attr{
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+			slog.Int("status", status),
+			slog.Int("bytes", ww.BytesWritten()),
+			slog.Float64("duration_ms", float64(time.Since(start).Microseconds())/1000),
+			slog.String("remote_addr", r.RemoteAddr),
+		}
+		// ルートパターンはルーティング後に確定する
+		if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+			attrs = append(attrs, slog.String("route", rctx.RoutePattern()))
+		}
+		if e.userID != 0 {
+			attrs = append(attrs, slog.Int64("user_id", e.userID), slog.String("user", e.login))
+		}
+		level := slog.LevelInfo
+		if status >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		if e.err != nil {
+			attrs = append(attrs, slog.String("error", e.err.Error()))
+		}
+		slog.LogAttrs(r.Context(), level, "request", attrs...)
+	})
+}
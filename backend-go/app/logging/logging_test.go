@@ -0,0 +1,121 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// capture replaces the default logger for the duration of the test and
+// returns the buffer the JSON records are written to.
+func capture(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	prev := slog.Default()
+	slog.SetDefault(New(&buf, "json", slog.LevelDebug))
+	t.Cleanup(func() { slog.SetDefault(prev) })
+	return &buf
+}
+
+func newTestRouter(t *testing.T) http.Handler {
+	r := chi.NewRouter()
+	r.Use(RequestID, AccessLog)
+	r.Get("/users/{id}", func(w http.ResponseWriter, r *http.Request) {
+		SetUser(r.Context(), 7, "alice")
+		w.WriteHeader(http.StatusNoContent)
+	})
+	r.Get("/fail", func(w http.ResponseWriter, r *http.Request) {
+		if !RecordError(w, errors.New("db down")) {
+			t.Error("RecordError must accept the access log writer")
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	return r
+}
+
+func decodeLine(t *testing.T, buf *bytes.Buffer) map[string]any {
+	t.Helper()
+	var rec map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+		t.Fatalf("not a single JSON record: %v: %s", err, buf)
+	}
+	return rec
+}
+
+func TestRequestID(t *testing.T) {
+	router := newTestRouter(t)
+
+	// 送られてきたIDはそのまま使う
+	req := httptest.NewRequest("GET", "/users/1", nil)
+	req.Header.Set(RequestIDHeader, "abc-123")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if got := w.Header().Get(RequestIDHeader); got != "abc-123" {
+		t.Errorf("Expected the incoming ID to be kept, got %q", got)
+	}
+
+	// 無い場合や不正な場合は生成する
+	for _, id := range []string{"", "bad id", strings.Repeat("x", maxRequestIDLen+1)} {
+		req := httptest.NewRequest("GET", "/users/1", nil)
+		req.Header.Set(RequestIDHeader, id)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if got := w.Header().Get(RequestIDHeader); len(got) != 32 || got == id {
+			t.Errorf("%q: Expected a generated ID, got %q", id, got)
+		}
+	}
+}
+
+func TestAccessLog(t *testing.T) {
+	buf := capture(t)
+	req := httptest.NewRequest("GET", "/users/1", nil)
+	req.Header.Set(RequestIDHeader, "req-1")
+	newTestRouter(t).ServeHTTP(httptest.NewRecorder(), req)
+
+	rec := decodeLine(t, buf)
+	want := map[string]any{
+		"level":      "INFO",
+		"msg":        "request",
+		"method":     "GET",
+		"path":       "/users/1",
+		"route":      "/users/{id}",
+		"status":     float64(204),
+		"user_id":    float64(7),
+		"user":       "alice",
+		"request_id": "req-1",
+	}
+	for k, v := range want {
+		if rec[k] != v {
+			t.Errorf("%s: Expected %v, got %v", k, v, rec[k])
+		}
+	}
+	if _, ok := rec["duration_ms"]; !ok {
+		t.Error("duration_ms missing")
+	}
+}
+
+func TestAccessLog_Error(t *testing.T) {
+	buf := capture(t)
+	newTestRouter(t).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/fail", nil))
+
+	rec := decodeLine(t, buf)
+	if rec["level"] != "ERROR" || rec["error"] != "db down" || rec["status"] != float64(500) {
+		t.Errorf("unexpected record %v", rec)
+	}
+	if _, ok := rec["user_id"]; ok {
+		t.Error("anonymous requests must not carry a user")
+	}
+}
+
+func TestRecordError_OutsideAccessLog(t *testing.T) {
+	if RecordError(httptest.NewRecorder(), errors.New("x")) {
+		t.Error("RecordError must report false for other writers")
+	}
+}
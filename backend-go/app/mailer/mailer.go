@@ -0,0 +1,131 @@
+// Package mailer sends system e-mails such as password reset links.
+//
+// Delivery is behind the [Sender] interface so that the SMTP transport can be
+// swapped for [Log] during local development or [Capture] in tests.
+package mailer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Message is a plain-text e-mail.
+type Message struct {
+	// From is the sender address. When empty the sender's default is used.
+	From    string
+	To      []string
+	Subject string
+	Body    string
+}
+
+// Sender delivers messages.
+type Sender interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// SMTP delivers messages through an SMTP server. STARTTLS is used when the
+// server offers it; authentication is used when Username is set.
+type SMTP struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	// From is the default sender address.
+	From string
+}
+
+// Send implements [Sender].
+func (s *SMTP) Send(ctx context.Context, msg Message) error {
+	if msg.From == "" {
+		msg.From = s.From
+	}
+	data, err := format(msg, time.Now())
+	if err != nil {
+		return err
+	}
+	var auth smtp.Auth
+	if s.Username != "" {
+		auth = smtp.PlainAuth("", s.Username, s.Password, s.Host)
+	}
+	// net/smtp はコンテキストに対応していないため、キャンセル済みなら送信しない
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	addr := net.JoinHostPort(s.Host, strconv.Itoa(s.Port))
+	if err := smtp.SendMail(addr, auth, msg.From, msg.To, data); err != nil {
+		return fmt.Errorf("mailer: send to %v: %w", msg.To, err)
+	}
+	return nil
+}
+
+// Log writes the recipients and subject of messages to the default [slog]
+// logger instead of sending them. It is meant for local development without
+// an SMTP server. Bodies are not logged because they may carry secrets such
+// as password reset tokens.
+type Log struct{}
+
+// Send implements [Sender].
+func (Log) Send(ctx context.Context, msg Message) error {
+	slog.InfoContext(ctx, "mail", "to", msg.To, "subject", msg.Subject)
+	return nil
+}
+
+// Capture records messages in memory. It is safe for concurrent use.
+type Capture struct {
+	mu       sync.Mutex
+	messages []Message
+	// Err, when set, is returned by Send instead of recording the message.
+	Err error
+}
+
+// Send implements [Sender].
+func (c *Capture) Send(ctx context.Context, msg Message) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.Err != nil {
+		return c.Err
+	}
+	c.messages = append(c.messages, msg)
+	return nil
+}
+
+// Messages returns the messages sent so far.
+func (c *Capture) Messages() []Message {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Message(nil), c.messages...)
+}
+
+// format renders msg as an RFC 5322 message with a UTF-8 body.
+func format(msg Message, now time.Time) ([]byte, error) {
+	if msg.From == "" || len(msg.To) == 0 {
+		return nil, errors.New("mailer: sender and recipients are required")
+	}
+	// ヘッダインジェクション対策
+	for _, v := range append([]string{msg.From, msg.Subject}, msg.To...) {
+		if strings.ContainsAny(v, "\r\n") {
+			return nil, errors.New("mailer: header contains a line break")
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("From: " + msg.From + "\r\n")
+	b.WriteString("To: " + strings.Join(msg.To, ", ") + "\r\n")
+	b.WriteString("Subject: " + mime.BEncoding.Encode("UTF-8", msg.Subject) + "\r\n")
+	b.WriteString("Date: " + now.Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(strings.ReplaceAll(msg.Body, "\r\n", "\n"), "\n", "\r\n"))
+	return []byte(b.String()), nil
+}
@@ -0,0 +1,60 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormat(t *testing.T) {
+	data, err := format(Message{
+		From:    "noreply@example.com",
+		To:      []string{"a@example.com", "b@example.com"},
+		Subject: "パスワード再設定",
+		Body:    "1行目\n2行目",
+	}, time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := string(data)
+	for _, want := range []string{
+		"To: a@example.com, b@example.com\r\n",
+		"Subject: =?UTF-8?b?",
+		"Content-Type: text/plain; charset=UTF-8\r\n",
+		"\r\n\r\n1行目\r\n2行目",
+	} {
+		if !strings.Contains(s, want) {
+			t.Errorf("missing %q in\n%s", want, s)
+		}
+	}
+}
+
+func TestFormat_HeaderInjection(t *testing.T) {
+	_, err := format(Message{From: "noreply@example.com", To: []string{"a@example.com\r\nBcc: x@example.com"}}, time.Now())
+	if err == nil {
+		t.Error("expected error for line break in header")
+	}
+}
+
+func TestCapture(t *testing.T) {
+	var c Capture
+	c.Send(context.Background(), Message{To: []string{"a@example.com"}, Subject: "hi"})
+	if msgs := c.Messages(); len(msgs) != 1 || msgs[0].Subject != "hi" {
+		t.Errorf("unexpected messages %+v", msgs)
+	}
+}
+
+func TestLog_OmitsBody(t *testing.T) {
+	var buf bytes.Buffer
+	defer slog.SetDefault(slog.Default())
+	slog.SetDefault(slog.New(slog.NewTextHandler(&buf, nil)))
+
+	// 本文の再設定トークンはログに残さない
+	Log{}.Send(context.Background(), Message{To: []string{"a@example.com"}, Subject: "パスワード再設定", Body: "token=secret-token"})
+	if out := buf.String(); !strings.Contains(out, "a@example.com") || strings.Contains(out, "secret-token") {
+		t.Errorf("unexpected log %q", out)
+	}
+}
@@ -1,13 +1,29 @@
 package main
 
 import (
+	"backend-go/config"
 	"backend-go/controllers"
+	"backend-go/logging"
+	"backend-go/mailer"
+	"backend-go/metrics"
+	"backend-go/schema"
+	"backend-go/tracing"
+	"backend-go/web"
+	"context"
 	"database/sql"
-	"fmt"
-	"log"
+	"errors"
+	"io/fs"
+	"log/slog"
 	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
 	//middleware "github.com/deepmap/oapi-codegen/pkg/chi-middleware"
 	middleware "github.com/deepmap/oapi-codegen/pkg/chi-middleware"
 	"github.com/go-chi/chi/v5"
@@ -15,43 +31,272 @@ import (
 )
 
 func main() {
-	dsn := "root:rootpassword@tcp(db:3306)/app_db"
-	db, err := sql.Open("mysql", dsn) // DB接続
+	// 設定の読み込み（環境変数 > CONFIG_FILE > デフォルト）
+	cfg, err := config.Load(os.Getenv("CONFIG_FILE"))
 	if err != nil {
-		log.Fatal(err)
+		fatal("failed to load config", err)
+	}
+	level, _ := cfg.Log.SlogLevel() // Validate 済み
+	slog.SetDefault(logging.New(os.Stderr, cfg.Log.Format, level))
+
+	// HTTP リクエストと SQL をスパンとして記録する
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Trace)
+	if err != nil {
+		fatal("failed to set up tracing", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			slog.Error("failed to flush spans", "error", err)
+		}
+	}()
+
+	db, err := openDB(cfg.DB) // DB接続
+	if err != nil {
+		fatal("failed to connect to the database", err)
 	}
 	defer db.Close()
+	// 接続プールの統計を /metrics に出す
+	if err := metrics.RegisterDB(db, cfg.DB.DatabaseName()); err != nil {
+		fatal("failed to register database metrics", err)
+	}
+
+	// 1. OpenAPI定義のロード
+	swagger, err := controllers.GetSwagger()
+	if err != nil {
+		fatal("failed to load OpenAPI spec", err)
+	}
+
+	// マスタメンテナンス API のテーブル定義を schema.yaml から読み込む
+	sch, err := schema.Load(cfg.Schema.File)
+	if err != nil {
+		fatal("failed to load schema", err)
+	}
+	tables, err := controllers.NewMasterTables(sch)
+	if err != nil {
+		fatal("failed to load master tables", err)
+	}
+
+	// イベントの変更を /events/stream の接続に配る
+	broker := &controllers.EventBroker{History: cfg.Calendar.StreamHistory}
+	handler, err := newRouter(db, swagger, cfg, metrics.Mailer(newMailer(cfg.Mail)), tables, broker)
+	if err != nil {
+		fatal("failed to build routes", err)
+	}
+
+	srv := &http.Server{
+		Addr:              cfg.Server.Addr(),
+		Handler:           handler,
+		ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+		ReadTimeout:       cfg.Server.ReadTimeout,
+		WriteTimeout:      cfg.Server.WriteTimeout,
+		IdleTimeout:       cfg.Server.IdleTimeout,
+	}
+
+	// 接続し続ける /events/stream は、停止時に閉じないと終了を待たせる
+	srv.RegisterOnShutdown(broker.Close)
+
+	// SIGTERM / SIGINT で graceful shutdown する
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	serveErr := make(chan error, 1)
+	go func() {
+		slog.Info("server starting", "addr", srv.Addr)
+		serveErr <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
+		if !errors.Is(err, http.ErrServerClosed) {
+			fatal("server failed", err)
+		}
+	case <-ctx.Done():
+		slog.Info("shutting down")
+	}
+
+	// 処理中のリクエストを待ってから停止する
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		slog.Error("graceful shutdown failed", "error", err)
+	}
+	slog.Info("server stopped")
+}
+
+// fatal logs err and exits. Deferred functions do not run.
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
+}
+
+// openDB opens the connection pool and waits until the database answers.
+func openDB(cfg config.DBConfig) (*sql.DB, error) {
+	db, err := tracing.OpenDB(cfg.FormatDSN())
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
 
 	// 接続確認（DBコンテナが立ち上がるまで少し待機が必要な場合があります）
-	for i := 0; i < 10; i++ {
-		err = db.Ping()
-		if err == nil {
-			break
+	for i := 0; i < cfg.ConnectRetries; i++ {
+		if err = db.Ping(); err == nil {
+			return db, nil
 		}
-		fmt.Println("DBに接続中...")
-		time.Sleep(2 * time.Second)
+		slog.Info("DBに接続中...", "error", err)
+		time.Sleep(cfg.ConnectInterval)
+	}
+	db.Close()
+	return nil, err
+}
+
+// newMailer returns the mail transport selected by cfg.Driver.
+func newMailer(cfg config.MailConfig) mailer.Sender {
+	if cfg.Driver == "smtp" {
+		return &mailer.SMTP{
+			Host:     cfg.Host,
+			Port:     cfg.Port,
+			Username: cfg.Username,
+			Password: cfg.Password,
+			From:     cfg.From,
+		}
+	}
+	return mailer.Log{}
+}
+
+// newRouter builds the HTTP routes. The API is served under
+// cfg.Server.APIPrefix and the embedded frontend on the other paths. Probes
+// and /metrics stay at the root, and they and the PHP-compatible
+// {prefix}/{name}.php are registered outside the OpenAPI request validator,
+// which rejects paths missing from the spec.
+// It fails when an x-permission of the spec is malformed.
+func newRouter(db *sql.DB, swagger *openapi3.T, cfg *config.Config, mail mailer.Sender, tables map[string]*controllers.MasterTable, broker *controllers.EventBroker) (http.Handler, error) {
+	// 操作ごとに必要な権限（x-permission）を仕様書から読み取る
+	required, err := controllers.RequiredPermissions(swagger)
+	if err != nil {
+		return nil, err
 	}
 
-	// 1. OpenAPI定義のロード
-	swagger, _ := controllers.GetSwagger()
 	// 2. ルーター（Chiなど）の設定
 	r := chi.NewRouter()
-	// 3. ★ここでバリデーションを挟む
-	// これにより各メソッド内で「型チェック」を書く必要がなくなります
-	r.Use(middleware.OapiRequestValidator(swagger))
-	// 4. ハンドラーの登録 (自動生成された関数を使用)
-	userCtrl := &controllers.UsersController{DB: db}
-	controllers.HandlerFromMux(userCtrl, r)
+	// リクエストIDの付与 → トレース開始 → リクエスト数・レイテンシの記録 → アクセスログ
+	// の順に動く。アクセスログは最後に置き、ハンドラがエラーを記録できるようにする
+	r.Use(logging.RequestID, tracing.Middleware, metrics.Middleware, logging.AccessLog)
+	r.NotFound(controllers.NotFoundHandler)
+	r.MethodNotAllowed(controllers.MethodNotAllowedHandler)
 
-	http.ListenAndServe(":8080", r)
+	health := &controllers.HealthController{DB: db}
+	r.Get("/healthz", health.Healthz)
+	r.Get("/readyz", health.Readyz)
+	r.Handle("/metrics", metrics.Handler())
+
+	// 画面（埋め込んだ Vite のビルド）は API 以外のパスで返す
+	frontend, err := web.Handler(web.Dist(), http.HandlerFunc(controllers.NotFoundHandler))
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		slog.Warn("frontend is not embedded, serving the API only", "error", err)
+	case err != nil:
+		return nil, err
+	default:
+		r.Method(http.MethodGet, "/*", frontend)
+		r.Method(http.MethodHead, "/*", frontend)
+	}
+
+	// PHP の /api/{name}.php をそのまま呼べるようにする。移行済みの名前は
+	// 仕様書の操作として r に投げ直すため、認証・権限・バリデーションはそちらで行われる
+	prefix := cfg.Server.APIPrefix
+	compat := &controllers.PHPCompatController{
+		API:         r,
+		Routes:      controllers.CompatRoutes,
+		AllowOrigin: cfg.Compat.AllowOrigin,
+		BasePath:    prefix,
+	}
+	if cfg.Compat.PHPURL != "" {
+		php, err := url.Parse(cfg.Compat.PHPURL) // Validate 済み
+		if err != nil {
+			return nil, err
+		}
+		compat.PHP = httputil.NewSingleHostReverseProxy(php)
+	}
 
-	// コントローラーをインスタンス化してDBを渡す
-	/*
-		userCtrl := &controllers.UsersController{DB: db}
+	// 仕様書のパスは prefix からの相対パスとして照合させる
+	swagger.Servers = openapi3.Servers{{URL: prefix}}
+	r.Route(prefix, func(r chi.Router) {
+		// API のパスでは画面を返さず、JSON のエラーにする
+		r.NotFound(controllers.NotFoundHandler)
+		r.MethodNotAllowed(controllers.MethodNotAllowedHandler)
+		// 一括取込のファイルが最も大きいため、その上限をすべてのリクエストに適用する
+		r.Use(controllers.LimitRequestBody(int64(cfg.Import.MaxBytes)))
+		r.Handle("/{name}.php", compat)
 
-		http.HandleFunc("/users", userCtrl.HandleUser)
+		r.Group(func(r chi.Router) {
+			// 3. ★ここでバリデーションを挟む
+			// これにより各メソッド内で「型チェック」を書く必要がなくなります
+			// バリデーションエラーも ErrorResponse 形式で返す
+			// 認証が必要な操作はトークンの有無もここで確認する（401）
+			r.Use(middleware.OapiRequestValidatorWithOptions(swagger, &middleware.Options{
+				ErrorHandler: controllers.ValidatorErrorHandler,
+				Options: openapi3filter.Options{
+					AuthenticationFunc: controllers.CheckCredentials,
+				},
+				// Servers は相対パスだけなので Host は照合されない
+				SilenceServersWarning: true,
+			}))
+			// 4. ハンドラーの登録 (自動生成された関数を使用)
+			// コントローラは Store（リポジトリのインターフェース）だけに依存する
+			store := controllers.NewMySQLStore(db)
+			authCtrl := &controllers.AuthController{
+				Store:            store,
+				SessionTTL:       cfg.Auth.SessionTTL,
+				CookieSecure:     cfg.Auth.CookieSecure,
+				MaxFailedLogins:  cfg.Auth.MaxFailedLogins,
+				LockoutDuration:  cfg.Auth.LockoutDuration,
+				TOTPIssuer:       cfg.Auth.TOTPIssuer,
+				PasswordResetTTL: cfg.Auth.PasswordResetTTL,
+				PasswordResetURL: cfg.Auth.PasswordResetURL,
+				Mailer:           mail,
+				MailFrom:         cfg.Mail.From,
+			}
+			permCtrl := &controllers.PermissionsController{Store: store, Required: required, BasePath: prefix}
+			server := &controllers.Server{
+				AuditController:        &controllers.AuditController{Store: store},
+				AuthController:         authCtrl,
+				BusinessDaysController: &controllers.BusinessDaysController{Store: store},
+				CalendarFeedController: &controllers.CalendarFeedController{
+					Store:   store,
+					BaseURL: cfg.Calendar.FeedBaseURL,
+				},
+				EventsController: &controllers.EventsController{
+					Store:     store,
+					Broker:    broker,
+					Heartbeat: cfg.Calendar.StreamHeartbeat,
+					BasePath:  prefix,
+				},
+				ImportController: &controllers.ImportController{
+					Store:     store,
+					Tables:    tables,
+					AsyncRows: cfg.Import.AsyncRows,
+					ChunkSize: cfg.Import.ChunkSize,
+					JobTTL:    cfg.Import.JobTTL,
+					BasePath:  prefix,
+				},
+				MastersController:     &controllers.MastersController{Store: store, Tables: tables},
+				PermissionsController: permCtrl,
+				UsersController:       &controllers.UsersController{Store: store, BasePath: prefix},
+			}
+			controllers.HandlerWithOptions(server, controllers.ChiServerOptions{
+				BaseRouter:       r,
+				ErrorHandlerFunc: controllers.ParamErrorHandler,
+				// 後ろに書いたものほど外側で先に動く:
+				// セッションを検証してログインユーザをコンテキストに格納し、次に x-permission を確認する
+				Middlewares: []controllers.MiddlewareFunc{permCtrl.Authorize, authCtrl.Authenticate},
+			})
+		})
+	})
 
-		fmt.Println("Server starting on :8080...")
-	*/
-	log.Fatal(http.ListenAndServe(":8080", nil))
+	return r, nil
 }
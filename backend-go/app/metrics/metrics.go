@@ -0,0 +1,153 @@
+// Package metrics exports Prometheus metrics of the API server at /metrics.
+//
+// HTTP requests are counted and timed by [Middleware], labelled with the chi
+// route pattern (e.g. /users/{id}) rather than the raw path so that the
+// number of series stays bounded. Connection pool statistics are added with
+// [RegisterDB], and the domain counters are incremented by the code that
+// performs the operation.
+package metrics
+
+import (
+	"backend-go/mailer"
+	"context"
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// namespace prefixes the name of every metric defined here.
+const namespace = "backend"
+
+// unmatchedRoute labels requests that matched no route, such as scans of
+// random paths, so that they share a single series.
+const unmatchedRoute = "unmatched"
+
+// Registry holds every metric served by [Handler]. The Go runtime and process
+// collectors are registered with it.
+var Registry = prometheus.NewRegistry()
+
+// HTTP metrics recorded by [Middleware].
+var (
+	requestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "Number of HTTP requests by method, route pattern and status code.",
+	}, []string{"method", "route", "status"})
+
+	requestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of HTTP requests by method and route pattern.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	requestsInFlight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_in_flight",
+		Help:      "Number of HTTP requests being served.",
+	})
+)
+
+// Domain counters.
+var (
+	// UsersCreated counts users registered through the API.
+	UsersCreated = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "users_created_total",
+		Help:      "Number of users created.",
+	})
+
+	// EmailsSent counts e-mails handed to the mail transport by result
+	// ("success" or "error"). It is incremented by [Mailer].
+	EmailsSent = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "emails_sent_total",
+		Help:      "Number of e-mails sent by result.",
+	}, []string{"result"})
+
+	// EventStreams is the number of clients connected to /events/stream.
+	EventStreams = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "event_streams",
+		Help:      "Number of open event streams.",
+	})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		requestsTotal, requestDuration, requestsInFlight,
+		UsersCreated, EmailsSent, EventStreams,
+	)
+	// 一度も発生していない結果も0として出力する
+	for _, result := range []string{"success", "error"} {
+		EmailsSent.WithLabelValues(result)
+	}
+}
+
+// RegisterDB exports the connection pool statistics ([sql.DBStats]) of db,
+// labelled with dbName.
+func RegisterDB(db *sql.DB, dbName string) error {
+	return Registry.Register(collectors.NewDBStatsCollector(db, dbName))
+}
+
+// Handler serves the metrics in the Prometheus exposition format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
+
+// Middleware records the count, latency and concurrency of the requests it
+// wraps. It must be installed on the root chi router so that the route
+// pattern is complete when the request finishes.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestsInFlight.Inc()
+		defer requestsInFlight.Dec()
+
+		start := time.Now()
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r)
+
+		// ルートパターンはルーティング後に確定する
+		route := unmatchedRoute
+		if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+			route = rctx.RoutePattern()
+		}
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		requestsTotal.WithLabelValues(r.Method, route, strconv.Itoa(status)).Inc()
+		requestDuration.WithLabelValues(r.Method, route).Observe(time.Since(start).Seconds())
+	})
+}
+
+// Mailer wraps s so that every delivery is counted in [EmailsSent].
+func Mailer(s mailer.Sender) mailer.Sender {
+	return countingSender{s}
+}
+
+type countingSender struct {
+	mailer.Sender
+}
+
+func (s countingSender) Send(ctx context.Context, msg mailer.Message) error {
+	err := s.Sender.Send(ctx, msg)
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	EmailsSent.WithLabelValues(result).Inc()
+	return err
+}
@@ -0,0 +1,117 @@
+package metrics
+
+import (
+	"backend-go/mailer"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func newTestRouter() http.Handler {
+	r := chi.NewRouter()
+	r.Use(Middleware)
+	r.Get("/users/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if testutil.ToFloat64(requestsInFlight) != 1 {
+			http.Error(w, "in-flight gauge not incremented", http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	r.Get("/ok", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	})
+	return r
+}
+
+func TestMiddleware_RoutePattern(t *testing.T) {
+	router := newTestRouter()
+	before := testutil.ToFloat64(requestsTotal.WithLabelValues("GET", "/users/{id}", "204"))
+
+	// パスが違っても同じルートパターンに集計される
+	for _, path := range []string{"/users/1", "/users/2"} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		if w.Code != http.StatusNoContent {
+			t.Fatalf("%s: got %d: %s", path, w.Code, w.Body.String())
+		}
+	}
+
+	if got := testutil.ToFloat64(requestsTotal.WithLabelValues("GET", "/users/{id}", "204")) - before; got != 2 {
+		t.Errorf("Expected 2 requests, got %v", got)
+	}
+	if got := testutil.ToFloat64(requestsInFlight); got != 0 {
+		t.Errorf("in-flight gauge must return to 0, got %v", got)
+	}
+	if n := testutil.CollectAndCount(requestDuration, "backend_http_request_duration_seconds"); n == 0 {
+		t.Error("no latency observed")
+	}
+}
+
+func TestMiddleware_ImplicitStatus(t *testing.T) {
+	router := newTestRouter()
+	before := testutil.ToFloat64(requestsTotal.WithLabelValues("GET", "/ok", "200"))
+
+	// WriteHeader を呼ばないハンドラは 200 として数える
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/ok", nil))
+
+	if got := testutil.ToFloat64(requestsTotal.WithLabelValues("GET", "/ok", "200")) - before; got != 1 {
+		t.Errorf("Expected 1 request, got %v", got)
+	}
+}
+
+func TestMiddleware_Unmatched(t *testing.T) {
+	router := newTestRouter()
+	before := testutil.ToFloat64(requestsTotal.WithLabelValues("GET", unmatchedRoute, "404"))
+
+	for _, path := range []string{"/wp-login.php", "/.env"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", path, nil))
+	}
+
+	if got := testutil.ToFloat64(requestsTotal.WithLabelValues("GET", unmatchedRoute, "404")) - before; got != 2 {
+		t.Errorf("Expected 2 unmatched requests, got %v", got)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	UsersCreated.Inc()
+
+	w := httptest.NewRecorder()
+	Handler().ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+
+	body := w.Body.String()
+	for _, name := range []string{
+		"backend_users_created_total",
+		`backend_emails_sent_total{result="error"}`,
+		"go_goroutines",
+	} {
+		if !strings.Contains(body, name) {
+			t.Errorf("%s missing from the exposition", name)
+		}
+	}
+}
+
+func TestMailer(t *testing.T) {
+	sent := testutil.ToFloat64(EmailsSent.WithLabelValues("success"))
+	failed := testutil.ToFloat64(EmailsSent.WithLabelValues("error"))
+
+	msg := mailer.Message{To: []string{"alice@example.com"}, Subject: "test"}
+	if err := Mailer(&mailer.Capture{}).Send(context.Background(), msg); err != nil {
+		t.Fatal(err)
+	}
+	if err := Mailer(&mailer.Capture{Err: errors.New("smtp down")}).Send(context.Background(), msg); err == nil {
+		t.Fatal("the transport error must be returned")
+	}
+
+	if got := testutil.ToFloat64(EmailsSent.WithLabelValues("success")) - sent; got != 1 {
+		t.Errorf("Expected 1 sent, got %v", got)
+	}
+	if got := testutil.ToFloat64(EmailsSent.WithLabelValues("error")) - failed; got != 1 {
+		t.Errorf("Expected 1 failed, got %v", got)
+	}
+}
@@ -0,0 +1,386 @@
+// Package rrule parses and expands the recurrence rules (RRULE) of RFC 5545.
+//
+// Only the rules of events recurring at most once a day are supported: FREQ
+// is DAILY, WEEKLY, MONTHLY or YEARLY, combined with INTERVAL, COUNT, UNTIL,
+// BYDAY (with ordinals such as 2MO or -1FR for monthly and yearly rules),
+// BYMONTHDAY, BYMONTH and WKST. Other rule parts are rejected rather than
+// ignored, so that a stored rule always means what it says.
+package rrule
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency is the FREQ of a rule.
+type Frequency string
+
+// The supported frequencies.
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+	Yearly  Frequency = "YEARLY"
+)
+
+// WeekdayNum is a BYDAY value: a weekday, optionally the N-th of the month
+// or year (negative from the end).
+type WeekdayNum struct {
+	// N is 0 for every such weekday.
+	N       int
+	Weekday time.Weekday
+}
+
+// Rule is a parsed RRULE.
+type Rule struct {
+	Freq     Frequency
+	Interval int
+	// Count limits the number of occurrences, 0 for no limit.
+	Count int
+	// Until is the last moment an occurrence may start, inclusive. A date
+	// without a time is the end of that day.
+	Until      *time.Time
+	ByDay      []WeekdayNum
+	ByMonthDay []int
+	ByMonth    []time.Month
+	// WeekStart is the first day of the week, Monday unless WKST says
+	// otherwise. It only matters for weekly rules with an interval.
+	WeekStart time.Weekday
+}
+
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+// weekdayNames are the codes of time.Weekday values.
+var weekdayNames = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// Parse parses the value of an RRULE property, with or without the
+// "RRULE:" prefix. Times of UNTIL without a "Z" suffix are taken in loc.
+func Parse(s string, loc *time.Location) (Rule, error) {
+	r := Rule{Interval: 1, WeekStart: time.Monday}
+	s = strings.TrimPrefix(strings.TrimSpace(s), "RRULE:")
+	if s == "" {
+		return r, errors.New("rrule: empty rule")
+	}
+	seen := map[string]bool{}
+	for part := range strings.SplitSeq(s, ";") {
+		name, value, ok := strings.Cut(part, "=")
+		name = strings.ToUpper(name)
+		if !ok || value == "" {
+			return r, fmt.Errorf("rrule: invalid part %q", part)
+		}
+		if seen[name] {
+			return r, fmt.Errorf("rrule: %s is given twice", name)
+		}
+		seen[name] = true
+		var err error
+		switch name {
+		case "FREQ":
+			r.Freq = Frequency(strings.ToUpper(value))
+			if !slices.Contains([]Frequency{Daily, Weekly, Monthly, Yearly}, r.Freq) {
+				err = fmt.Errorf("unsupported frequency %q", value)
+			}
+		case "INTERVAL":
+			r.Interval, err = positive(value)
+		case "COUNT":
+			r.Count, err = positive(value)
+		case "UNTIL":
+			var until time.Time
+			until, err = parseUntil(value, loc)
+			r.Until = &until
+		case "BYDAY":
+			r.ByDay, err = parseByDay(value)
+		case "BYMONTHDAY":
+			r.ByMonthDay, err = parseInts(value, 1, 31, true)
+		case "BYMONTH":
+			var months []int
+			months, err = parseInts(value, 1, 12, false)
+			for _, m := range months {
+				r.ByMonth = append(r.ByMonth, time.Month(m))
+			}
+		case "WKST":
+			var ok bool
+			if r.WeekStart, ok = weekdays[strings.ToUpper(value)]; !ok {
+				err = fmt.Errorf("invalid weekday %q", value)
+			}
+		default:
+			err = errors.New("is not supported")
+		}
+		if err != nil {
+			return r, fmt.Errorf("rrule: %s: %w", name, err)
+		}
+	}
+	if r.Freq == "" {
+		return r, errors.New("rrule: FREQ is required")
+	}
+	if r.Count > 0 && r.Until != nil {
+		return r, errors.New("rrule: COUNT and UNTIL must not be given together")
+	}
+	for _, d := range r.ByDay {
+		if d.N != 0 && r.Freq != Monthly && r.Freq != Yearly {
+			return r, errors.New("rrule: BYDAY with an ordinal needs FREQ=MONTHLY or YEARLY")
+		}
+	}
+	if len(r.ByMonthDay) > 0 && r.Freq == Weekly {
+		return r, errors.New("rrule: BYMONTHDAY cannot be used with FREQ=WEEKLY")
+	}
+	return r, nil
+}
+
+func positive(s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("%q is not a positive integer", s)
+	}
+	return n, nil
+}
+
+func parseUntil(s string, loc *time.Location) (time.Time, error) {
+	if t, err := time.ParseInLocation("20060102", s, loc); err == nil {
+		return t.AddDate(0, 0, 1).Add(-time.Second), nil
+	}
+	if t, err := time.Parse("20060102T150405Z", s); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("20060102T150405", s, loc); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("%q is not a date or a date-time", s)
+}
+
+func parseByDay(s string) ([]WeekdayNum, error) {
+	var days []WeekdayNum
+	for v := range strings.SplitSeq(s, ",") {
+		v = strings.ToUpper(v)
+		if len(v) < 2 {
+			return nil, fmt.Errorf("invalid weekday %q", v)
+		}
+		wd, ok := weekdays[v[len(v)-2:]]
+		if !ok {
+			return nil, fmt.Errorf("invalid weekday %q", v)
+		}
+		d := WeekdayNum{Weekday: wd}
+		if n := v[:len(v)-2]; n != "" {
+			var err error
+			if d.N, err = strconv.Atoi(n); err != nil || d.N == 0 || d.N < -53 || d.N > 53 {
+				return nil, fmt.Errorf("invalid weekday %q", v)
+			}
+		}
+		days = append(days, d)
+	}
+	return days, nil
+}
+
+// parseInts parses a list of integers in [min, max], or in [-max, -min] as
+// well when negative is set.
+func parseInts(s string, min, max int, negative bool) ([]int, error) {
+	var out []int
+	for v := range strings.SplitSeq(s, ",") {
+		n, err := strconv.Atoi(v)
+		abs := n
+		if negative && n < 0 {
+			abs = -n
+		}
+		if err != nil || abs < min || abs > max || n < 0 && !negative {
+			return nil, fmt.Errorf("%q is out of range", v)
+		}
+		out = append(out, n)
+	}
+	return out, nil
+}
+
+// String formats r as an RRULE value, with parts in a fixed order. UNTIL is
+// formatted in UTC.
+func (r Rule) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, d := range r.ByDay {
+			days[i] = weekdayNames[d.Weekday]
+			if d.N != 0 {
+				days[i] = strconv.Itoa(d.N) + days[i]
+			}
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		parts = append(parts, "BYMONTHDAY="+joinInts(r.ByMonthDay))
+	}
+	if len(r.ByMonth) > 0 {
+		months := make([]int, len(r.ByMonth))
+		for i, m := range r.ByMonth {
+			months[i] = int(m)
+		}
+		parts = append(parts, "BYMONTH="+joinInts(months))
+	}
+	if r.WeekStart != time.Monday {
+		parts = append(parts, "WKST="+weekdayNames[r.WeekStart])
+	}
+	return strings.Join(parts, ";")
+}
+
+func joinInts(ns []int) string {
+	s := make([]string, len(ns))
+	for i, n := range ns {
+		s[i] = strconv.Itoa(n)
+	}
+	return strings.Join(s, ",")
+}
+
+// Before returns the starts of the occurrences of the series starting at
+// dtstart that are earlier than end, in order. dtstart is always the first
+// occurrence; the others start at its time of day, in its location.
+func (r Rule) Before(dtstart, end time.Time) []time.Time {
+	return r.Between(dtstart, dtstart, end)
+}
+
+// Between returns the starts of the occurrences of the series starting at
+// dtstart that are in [start, end), in order.
+//
+// The days up to end are examined one by one, so end bounds the work even
+// for rules that never match. Without COUNT the walk begins at the day of
+// start instead of dtstart, and it stops once UNTIL or COUNT is reached.
+func (r Rule) Between(dtstart, start, end time.Time) []time.Time {
+	if r.Until != nil && r.Until.Before(start) {
+		return nil
+	}
+	var out []time.Time
+	loc := dtstart.Location()
+	y, m, d := dtstart.Date()
+	hh, mm, ss := dtstart.Clock()
+	first := 0
+	if r.Count == 0 && start.After(dtstart) {
+		// COUNT がなければ開始日より前の回を数える必要はない
+		first = days(dtstart, start.In(loc))
+	}
+	n := 0
+	for i := first; ; i++ {
+		t := time.Date(y, m, d+i, hh, mm, ss, dtstart.Nanosecond(), loc)
+		if !t.Before(end) || r.Until != nil && t.After(*r.Until) || r.Count > 0 && n == r.Count {
+			return out
+		}
+		if i == 0 || r.matches(dtstart, t) {
+			n++
+			if !t.Before(start) {
+				out = append(out, t)
+			}
+		}
+	}
+}
+
+// matches reports whether the day of t is an occurrence of the series
+// starting at dtstart.
+func (r Rule) matches(dtstart, t time.Time) bool {
+	if !r.inPeriod(dtstart, t) {
+		return false
+	}
+	if len(r.ByMonth) > 0 && !slices.Contains(r.ByMonth, t.Month()) {
+		return false
+	}
+	if len(r.ByMonthDay) > 0 && !r.matchesMonthDay(t) {
+		return false
+	}
+	if len(r.ByDay) > 0 {
+		return r.matchesDay(t)
+	}
+	// BYxxx がなければ開始日から決まる
+	switch r.Freq {
+	case Weekly:
+		return t.Weekday() == dtstart.Weekday()
+	case Monthly:
+		return len(r.ByMonthDay) > 0 || t.Day() == dtstart.Day()
+	case Yearly:
+		if len(r.ByMonthDay) > 0 {
+			return true
+		}
+		return (len(r.ByMonth) > 0 || t.Month() == dtstart.Month()) && t.Day() == dtstart.Day()
+	}
+	return true
+}
+
+// inPeriod reports whether t falls in a period of the frequency selected by
+// the interval, counted from the period of dtstart.
+func (r Rule) inPeriod(dtstart, t time.Time) bool {
+	if r.Interval == 1 {
+		return true
+	}
+	var n int
+	switch r.Freq {
+	case Daily:
+		n = days(dtstart, t)
+	case Weekly:
+		n = days(weekStart(dtstart, r.WeekStart), weekStart(t, r.WeekStart)) / 7
+	case Monthly:
+		n = (t.Year()-dtstart.Year())*12 + int(t.Month()-dtstart.Month())
+	case Yearly:
+		n = t.Year() - dtstart.Year()
+	}
+	return n%r.Interval == 0
+}
+
+func (r Rule) matchesMonthDay(t time.Time) bool {
+	last := daysIn(t.Year(), t.Month())
+	for _, d := range r.ByMonthDay {
+		if d == t.Day() || d < 0 && last+1+d == t.Day() {
+			return true
+		}
+	}
+	return false
+}
+
+func (r Rule) matchesDay(t time.Time) bool {
+	for _, d := range r.ByDay {
+		if d.Weekday != t.Weekday() {
+			continue
+		}
+		if d.N == 0 {
+			return true
+		}
+		// 序数は月ごと（MONTHLY、または BYMONTH のある YEARLY）か年ごと
+		day, last := t.Day(), daysIn(t.Year(), t.Month())
+		if r.Freq == Yearly && len(r.ByMonth) == 0 {
+			day, last = t.YearDay(), daysIn(t.Year(), 0)
+		}
+		if d.N > 0 && (day-1)/7+1 == d.N || d.N < 0 && -((last-day)/7+1) == d.N {
+			return true
+		}
+	}
+	return false
+}
+
+// daysIn returns the number of days of the month, or of the year when month
+// is 0.
+func daysIn(year int, month time.Month) int {
+	if month == 0 {
+		return time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC).YearDay()
+	}
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// days returns the number of calendar days from a to b.
+func days(a, b time.Time) int {
+	ua := time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	ub := time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+	// Duration は約292年で飽和するため Unix 秒で日数を数える
+	return int((ub.Unix() - ua.Unix()) / (24 * 60 * 60))
+}
+
+// weekStart returns the first day of the week of t.
+func weekStart(t time.Time, wkst time.Weekday) time.Time {
+	return t.AddDate(0, 0, -((int(t.Weekday()) - int(wkst) + 7) % 7))
+}
@@ -0,0 +1,121 @@
+package rrule
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+var tokyo = time.FixedZone("Asia/Tokyo", 9*60*60)
+
+func dates(ts []time.Time) string {
+	var s []string
+	for _, t := range ts {
+		s = append(s, t.Format("2006-01-02"))
+	}
+	return strings.Join(s, " ")
+}
+
+func TestBefore(t *testing.T) {
+	start := time.Date(2026, 1, 31, 9, 30, 0, 0, tokyo)
+	end := time.Date(2026, 7, 1, 0, 0, 0, 0, tokyo)
+	cases := []struct {
+		rule, want string
+	}{
+		{"FREQ=MONTHLY", "2026-01-31 2026-03-31 2026-05-31"},
+		{"FREQ=MONTHLY;BYMONTHDAY=-1", "2026-01-31 2026-02-28 2026-03-31 2026-04-30 2026-05-31 2026-06-30"},
+		{"FREQ=MONTHLY;BYDAY=-1FR;COUNT=3", "2026-01-31 2026-02-27 2026-03-27"},
+		{"FREQ=MONTHLY;INTERVAL=2;BYDAY=2MO", "2026-01-31 2026-03-09 2026-05-11"},
+		{"FREQ=WEEKLY;BYDAY=TU,TH;UNTIL=20260210", "2026-01-31 2026-02-03 2026-02-05 2026-02-10"},
+		{"FREQ=WEEKLY;INTERVAL=8", "2026-01-31 2026-03-28 2026-05-23"},
+		{"FREQ=DAILY;INTERVAL=30;COUNT=4", "2026-01-31 2026-03-02 2026-04-01 2026-05-01"},
+		{"FREQ=YEARLY;BYMONTH=3,6;BYMONTHDAY=1", "2026-01-31 2026-03-01 2026-06-01"},
+		{"FREQ=YEARLY", "2026-01-31"},
+		{"FREQ=DAILY;UNTIL=20260201T003000Z", "2026-01-31 2026-02-01"},
+		{"FREQ=MONTHLY;BYMONTHDAY=30;BYMONTH=2", "2026-01-31"},
+	}
+	for _, tc := range cases {
+		r, err := Parse(tc.rule, tokyo)
+		if err != nil {
+			t.Errorf("%s: %v", tc.rule, err)
+			continue
+		}
+		got := r.Before(start, end)
+		if dates(got) != tc.want {
+			t.Errorf("%s: expected %s, got %s", tc.rule, tc.want, dates(got))
+		}
+		for _, o := range got {
+			if h, m, _ := o.Clock(); h != 9 || m != 30 {
+				t.Errorf("%s: unexpected time %v", tc.rule, o)
+			}
+		}
+	}
+}
+
+func TestBetween(t *testing.T) {
+	start := time.Date(2026, 1, 31, 9, 30, 0, 0, tokyo)
+	from := time.Date(2026, 3, 31, 9, 30, 0, 0, tokyo)
+	end := time.Date(2026, 7, 1, 0, 0, 0, 0, tokyo)
+	cases := []struct {
+		rule, want string
+	}{
+		{"FREQ=MONTHLY", "2026-03-31 2026-05-31"},
+		{"FREQ=MONTHLY;BYDAY=-1FR;COUNT=4", "2026-04-24"},
+		{"FREQ=WEEKLY;INTERVAL=8", "2026-05-23"},
+		{"FREQ=WEEKLY;BYDAY=TU,TH;UNTIL=20260210", ""},
+		{"FREQ=DAILY;INTERVAL=30", "2026-04-01 2026-05-01 2026-05-31 2026-06-30"},
+	}
+	for _, tc := range cases {
+		r, err := Parse(tc.rule, tokyo)
+		if err != nil {
+			t.Errorf("%s: %v", tc.rule, err)
+			continue
+		}
+		if got := dates(r.Between(start, from, end)); got != tc.want {
+			t.Errorf("%s: expected %s, got %s", tc.rule, tc.want, got)
+		}
+	}
+
+	// 開始日から遠い期間でも期間の日数分だけ調べる
+	r, _ := Parse("FREQ=DAILY", tokyo)
+	far := time.Date(9999, 12, 30, 0, 0, 0, 0, tokyo)
+	if got := dates(r.Between(start, far, far.AddDate(0, 0, 2))); got != "9999-12-30 9999-12-31" {
+		t.Errorf("unexpected occurrences %s", got)
+	}
+
+	// 292年を超えても INTERVAL の周期がずれない
+	far = time.Date(9999, 12, 14, 0, 0, 0, 0, tokyo)
+	for rule, want := range map[string]string{
+		"FREQ=DAILY;INTERVAL=2":  "9999-12-15 9999-12-17 9999-12-19 9999-12-21 9999-12-23 9999-12-25 9999-12-27 9999-12-29",
+		"FREQ=WEEKLY;INTERVAL=2": "9999-12-25",
+	} {
+		r, _ := Parse(rule, tokyo)
+		if got := dates(r.Between(start, far, far.AddDate(0, 0, 17))); got != want {
+			t.Errorf("%s: expected %s, got %s", rule, want, got)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	r, err := Parse("RRULE:freq=weekly;interval=2;byday=mo,-1fr;wkst=su", tokyo)
+	if err == nil {
+		t.Errorf("Expected an error for an ordinal in a weekly rule, got %v", r)
+	}
+	r, err = Parse("FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR;WKST=SU", tokyo)
+	if err != nil || r.String() != "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR;WKST=SU" {
+		t.Errorf("unexpected %v, %v", r, err)
+	}
+	r, err = Parse("FREQ=MONTHLY;UNTIL=20261231;BYDAY=-1FR", tokyo)
+	if err != nil || r.String() != "FREQ=MONTHLY;UNTIL=20261231T145959Z;BYDAY=-1FR" {
+		t.Errorf("unexpected %v, %v", r, err)
+	}
+	for _, s := range []string{
+		"", "FREQ=HOURLY", "INTERVAL=2", "FREQ=DAILY;COUNT=0", "FREQ=DAILY;COUNT=2;UNTIL=20260101",
+		"FREQ=DAILY;BYSETPOS=1", "FREQ=MONTHLY;BYMONTHDAY=32", "FREQ=MONTHLY;BYDAY=XX", "FREQ=DAILY;FREQ=DAILY",
+		"FREQ=WEEKLY;BYMONTHDAY=1", "FREQ=YEARLY;BYMONTH=0",
+	} {
+		if _, err := Parse(s, tokyo); err == nil {
+			t.Errorf("Expected an error for %q", s)
+		}
+	}
+}
@@ -0,0 +1,339 @@
+// Package schema reads the table definitions of docs/schema.yaml, the
+// source of schema.sql, the ER diagram and the table documents, so that the
+// server can serve and validate tables without code written for each one.
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Schema is the database described by schema.yaml.
+type Schema struct {
+	Tables []Table `yaml:"tables"`
+}
+
+// Table is a table of schema.yaml.
+type Table struct {
+	Name    string   `yaml:"name"`
+	Comment string   `yaml:"comment"`
+	Columns []Column `yaml:"columns"`
+	Indexes []Index  `yaml:"indexes"`
+}
+
+// Column is a column of a [Table].
+type Column struct {
+	Name string `yaml:"name"`
+	// Type is the MySQL column type, such as varchar(100) or decimal(10,2).
+	Type          string `yaml:"type"`
+	PK            bool   `yaml:"pk"`
+	NotNull       bool   `yaml:"not_null"`
+	AutoIncrement bool   `yaml:"auto_increment"`
+	Default       any    `yaml:"default"`
+	// Comment is the Japanese name of the column.
+	Comment string `yaml:"comment"`
+	FK      *FK    `yaml:"fk"`
+
+	kind   Kind
+	length int
+	scale  int
+}
+
+// FK is the column referenced by a foreign key.
+type FK struct {
+	Table  string `yaml:"table"`
+	Column string `yaml:"column"`
+}
+
+// Index is a secondary index of a [Table].
+type Index struct {
+	Name    string   `yaml:"name"`
+	Columns []string `yaml:"columns"`
+	Unique  bool     `yaml:"unique"`
+}
+
+// Kind classifies column types by the values they hold.
+type Kind string
+
+const (
+	Integer  Kind = "integer"
+	Decimal  Kind = "decimal"
+	String   Kind = "string"
+	Text     Kind = "text"
+	Boolean  Kind = "boolean"
+	Date     Kind = "date"
+	DateTime Kind = "datetime"
+	Binary   Kind = "binary"
+)
+
+// kinds maps the MySQL type names used in schema.yaml to their [Kind].
+var kinds = map[string]Kind{
+	"tinyint":    Integer,
+	"smallint":   Integer,
+	"mediumint":  Integer,
+	"int":        Integer,
+	"integer":    Integer,
+	"bigint":     Integer,
+	"decimal":    Decimal,
+	"numeric":    Decimal,
+	"char":       String,
+	"varchar":    String,
+	"tinytext":   Text,
+	"text":       Text,
+	"mediumtext": Text,
+	"longtext":   Text,
+	"boolean":    Boolean,
+	"bool":       Boolean,
+	"date":       Date,
+	"datetime":   DateTime,
+	"timestamp":  DateTime,
+	"tinyblob":   Binary,
+	"blob":       Binary,
+	"mediumblob": Binary,
+	"longblob":   Binary,
+	"binary":     Binary,
+	"varbinary":  Binary,
+}
+
+// textBytes is the maximum size in bytes of the text types.
+var textBytes = map[string]int{
+	"tinytext":   255,
+	"text":       65535,
+	"mediumtext": 16777215,
+	"longtext":   math.MaxInt32, // 4GB まで入るが、リクエストの上限として扱う
+}
+
+// integerBits is the size of the integer types.
+var integerBits = map[string]int{
+	"tinyint":   8,
+	"smallint":  16,
+	"mediumint": 24,
+	"int":       32,
+	"integer":   32,
+	"bigint":    64,
+}
+
+var typePattern = regexp.MustCompile(`^([a-z]+)(?:\((\d+)(?:,\s*(\d+))?\))?`)
+
+// Load reads the schema.yaml at path.
+func Load(path string) (*Schema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("schema: %w", err)
+	}
+	s, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("schema: %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Parse reads schema.yaml from data. It fails on a column type it does not
+// know.
+func Parse(data []byte) (*Schema, error) {
+	var s Schema
+	if err := yaml.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+	for i := range s.Tables {
+		t := &s.Tables[i]
+		for j := range t.Columns {
+			if err := t.Columns[j].parseType(); err != nil {
+				return nil, fmt.Errorf("%s.%s: %w", t.Name, t.Columns[j].Name, err)
+			}
+		}
+	}
+	return &s, nil
+}
+
+// Table returns the table with the name.
+func (s *Schema) Table(name string) (*Table, bool) {
+	for i := range s.Tables {
+		if s.Tables[i].Name == name {
+			return &s.Tables[i], true
+		}
+	}
+	return nil, false
+}
+
+// Column returns the column with the name.
+func (t *Table) Column(name string) (*Column, bool) {
+	for i := range t.Columns {
+		if t.Columns[i].Name == name {
+			return &t.Columns[i], true
+		}
+	}
+	return nil, false
+}
+
+func (c *Column) parseType() error {
+	m := typePattern.FindStringSubmatch(strings.ToLower(c.Type))
+	if m == nil {
+		return fmt.Errorf("unknown type %q", c.Type)
+	}
+	kind, ok := kinds[m[1]]
+	if !ok {
+		return fmt.Errorf("unknown type %q", c.Type)
+	}
+	c.kind = kind
+	c.length, _ = strconv.Atoi(m[2])
+	c.scale, _ = strconv.Atoi(m[3])
+	switch kind {
+	case Text:
+		c.length = textBytes[m[1]]
+	case Integer:
+		// int(11) の表示幅は値の範囲に影響しないため、範囲はビット数で持つ
+		c.length = integerBits[m[1]]
+	case Decimal:
+		if c.length == 0 {
+			c.length = 10 // MySQL の既定の精度
+		}
+	}
+	return nil
+}
+
+// Kind returns the kind of the column type.
+func (c *Column) Kind() Kind {
+	return c.kind
+}
+
+// Length returns the maximum number of characters of a [String] column, the
+// maximum number of bytes of a [Text] column, the precision of a [Decimal]
+// column and the number of bits of an [Integer] column.
+func (c *Column) Length() int {
+	return c.length
+}
+
+// Scale returns the number of fractional digits of a [Decimal] column.
+func (c *Column) Scale() int {
+	return c.scale
+}
+
+// Required reports whether a value must be given when a row is inserted.
+func (c *Column) Required() bool {
+	return c.NotNull && c.Default == nil && !c.AutoIncrement
+}
+
+// DefaultValue returns the default of the column as a value of
+// [Column.Convert], or nil when it has none or the default is an expression
+// such as CURRENT_TIMESTAMP.
+func (c *Column) DefaultValue() any {
+	if c.Default == nil {
+		return nil
+	}
+	switch d := c.Default.(type) {
+	case string:
+		if c.kind == DateTime || c.kind == Date {
+			if _, err := c.Convert(d); err != nil {
+				return nil
+			}
+		}
+	case int:
+		if c.kind == Boolean {
+			return d != 0 // MySQL の boolean は tinyint(1)
+		}
+	}
+	return c.Default
+}
+
+// Layouts accepted by [Column.Convert] for [Date] and [DateTime] columns.
+const (
+	DateLayout     = time.DateOnly
+	DateTimeLayout = time.DateTime
+)
+
+var decimalPattern = regexp.MustCompile(`^-?(\d+)(?:\.(\d+))?$`)
+
+// Convert checks a value decoded from JSON with [json.Decoder.UseNumber]
+// against the column type and returns it as a database/sql argument. The
+// error describes the problem without naming the column.
+//
+// Dates are given as "2006-01-02" and date-times as RFC 3339 or
+// "2006-01-02 15:04:05" (Asia/Tokyo is assumed by the connection).
+func (c *Column) Convert(v any) (any, error) {
+	if v == nil {
+		if c.NotNull {
+			return nil, fmt.Errorf("must not be null")
+		}
+		return nil, nil
+	}
+	switch c.kind {
+	case Integer:
+		n, ok := v.(json.Number)
+		if !ok {
+			return nil, fmt.Errorf("must be an integer")
+		}
+		i, err := strconv.ParseInt(n.String(), 10, c.length)
+		if err != nil {
+			return nil, fmt.Errorf("must be an integer of %d bits", c.length)
+		}
+		return i, nil
+	case Decimal:
+		var s string
+		switch v := v.(type) {
+		case json.Number:
+			s = v.String()
+		case string:
+			s = v
+		default:
+			return nil, fmt.Errorf("must be a number")
+		}
+		m := decimalPattern.FindStringSubmatch(s)
+		if m == nil {
+			return nil, fmt.Errorf("must be a number")
+		}
+		if len(strings.TrimLeft(m[1], "0")) > c.length-c.scale || len(m[2]) > c.scale {
+			return nil, fmt.Errorf("must have at most %d integer and %d fractional digits", c.length-c.scale, c.scale)
+		}
+		return s, nil
+	case String, Text:
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("must be a string")
+		}
+		if c.kind == String && utf8.RuneCountInString(s) > c.length {
+			return nil, fmt.Errorf("must be at most %d characters", c.length)
+		}
+		if c.kind == Text && len(s) > c.length {
+			return nil, fmt.Errorf("must be at most %d bytes", c.length)
+		}
+		return s, nil
+	case Boolean:
+		b, ok := v.(bool)
+		if !ok {
+			return nil, fmt.Errorf("must be a boolean")
+		}
+		return b, nil
+	case Date:
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("must be a date (YYYY-MM-DD)")
+		}
+		if _, err := time.Parse(DateLayout, s); err != nil {
+			return nil, fmt.Errorf("must be a date (YYYY-MM-DD)")
+		}
+		return s, nil
+	case DateTime:
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("must be a date-time")
+		}
+		if t, err := time.Parse(time.RFC3339, s); err == nil {
+			return t, nil
+		}
+		if _, err := time.Parse(DateTimeLayout, s); err == nil {
+			return s, nil
+		}
+		return nil, fmt.Errorf("must be a date-time (RFC 3339 or YYYY-MM-DD hh:mm:ss)")
+	}
+	return nil, fmt.Errorf("cannot be written")
+}
@@ -0,0 +1,65 @@
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	s, err := Parse([]byte(`
+tables:
+  - name: t
+    columns:
+      - {name: id, type: bigint, pk: true, not_null: true, auto_increment: true}
+      - {name: price, type: "decimal(10,2)"}
+      - {name: name, type: varchar(5), not_null: true}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tbl, _ := s.Table("t")
+	price, _ := tbl.Column("price")
+	if price.Kind() != Decimal || price.Length() != 10 || price.Scale() != 2 {
+		t.Errorf("unexpected column: %+v", price)
+	}
+	if _, err := Parse([]byte("tables: [{name: t, columns: [{name: a, type: geometry}]}]")); err == nil {
+		t.Error("Expected an error for an unknown type")
+	}
+}
+
+func TestColumn_Convert(t *testing.T) {
+	col := func(typ string, notNull bool) *Column {
+		c := &Column{Type: typ, NotNull: notNull}
+		if err := c.parseType(); err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+	cases := []struct {
+		c  *Column
+		v  any
+		ok bool
+	}{
+		{col("int", false), json.Number("2147483647"), true},
+		{col("int", false), json.Number("2147483648"), false},
+		{col("int", false), json.Number("1.5"), false},
+		{col("decimal(5,2)", false), json.Number("123.45"), true},
+		{col("decimal(5,2)", false), json.Number("1234.5"), false},
+		{col("decimal(5,2)", false), json.Number("1.234"), false},
+		{col("varchar(3)", false), "あいう", true},
+		{col("varchar(3)", false), "あいうえ", false},
+		{col("varchar(3)", true), nil, false},
+		{col("varchar(3)", false), nil, true},
+		{col("boolean", false), true, true},
+		{col("date", false), "2024-02-30", false},
+		{col("date", false), "2024-02-29", true},
+		{col("datetime", false), "2024-02-29 10:00:00", true},
+		{col("datetime", false), "2024-02-29T10:00:00+09:00", true},
+		{col("longblob", false), "x", false},
+	}
+	for _, tc := range cases {
+		if _, err := tc.c.Convert(tc.v); (err == nil) != tc.ok {
+			t.Errorf("%s %v: got %v", tc.c.Type, tc.v, err)
+		}
+	}
+}
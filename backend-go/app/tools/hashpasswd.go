@@ -0,0 +1,37 @@
+//go:build ignore
+
+// hashpasswd は users_master.password_hash に登録する bcrypt ハッシュを出力する。
+// 初期管理者のパスワード設定など、APIにログインできない状態での利用を想定している。
+//
+//	cd app/tools && go run hashpasswd.go
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func main() {
+	fmt.Fprint(os.Stderr, "password: ")
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	password := strings.TrimRight(line, "\r\n")
+	if len(password) < 8 || len(password) > 72 {
+		fmt.Fprintln(os.Stderr, "password must be 8 to 72 bytes")
+		os.Exit(1)
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	fmt.Println(string(hash))
+}
@@ -0,0 +1,109 @@
+// Package web serves the frontend (the Vite production build of frontend/)
+// embedded in the binary, so that the server alone runs the application.
+//
+// The build is copied into web/dist before go build:
+//
+//	cd frontend && npm ci && npm run build
+//	cp -r frontend/dist/. backend-go/app/web/dist/
+//
+// Without it the binary still builds, and [Dist] has no index.html.
+package web
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+)
+
+// IndexFile is the page returned for the routes of the React router.
+const IndexFile = "index.html"
+
+// assetsDir holds the files Vite names after their content hash, which
+// therefore never change.
+const assetsDir = "assets/"
+
+const (
+	// immutableCache is sent for hashed assets.
+	immutableCache = "public, max-age=31536000, immutable"
+	// revalidateCache is sent for the other files, whose URLs do not change
+	// between builds. Browsers check their ETag before using them.
+	revalidateCache = "no-cache"
+)
+
+//go:embed all:dist
+var dist embed.FS
+
+// Dist returns the embedded build.
+func Dist() fs.FS {
+	sub, err := fs.Sub(dist, "dist")
+	if err != nil {
+		panic(err) // dist は常に埋め込まれる
+	}
+	return sub
+}
+
+// file is a file of the build with its ETag.
+type file struct {
+	data []byte
+	etag string
+}
+
+// Handler serves the files of fsys for GET and HEAD requests. A path without
+// a file extension that is not a file is a route of the React router and is
+// answered with [IndexFile]; other missing files are passed to notFound.
+//
+// Hashed assets are cached for a year and the other files are revalidated
+// by ETag on every use, so that a deployment is picked up on the next page
+// load.
+func Handler(fsys fs.FS, notFound http.Handler) (http.Handler, error) {
+	files := map[string]*file{}
+	err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		// dist を空でも埋め込めるように置いている .gitkeep などは配信しない
+		if err != nil || d.IsDir() || strings.HasPrefix(d.Name(), ".") {
+			return err
+		}
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+		sum := sha256.Sum256(data)
+		files[name] = &file{data: data, etag: `"` + hex.EncodeToString(sum[:16]) + `"`}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if files[IndexFile] == nil {
+		return nil, fmt.Errorf("web: %s is not built: %w", IndexFile, fs.ErrNotExist)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimPrefix(path.Clean("/"+r.URL.Path), "/")
+		f, ok := files[name]
+		switch {
+		case ok:
+		case name == "" || path.Ext(name) == "":
+			// クライアント側のルート（/schedule など）は index.html を返し、React Router に任せる
+			name, f = IndexFile, files[IndexFile]
+		default:
+			notFound.ServeHTTP(w, r)
+			return
+		}
+
+		if strings.HasPrefix(name, assetsDir) {
+			w.Header().Set("Cache-Control", immutableCache)
+		} else {
+			w.Header().Set("Cache-Control", revalidateCache)
+		}
+		w.Header().Set("ETag", f.etag)
+		// If-None-Match が一致すれば 304 を返す
+		http.ServeContent(w, r, name, time.Time{}, bytes.NewReader(f.data))
+	}), nil
+}
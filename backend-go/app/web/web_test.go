@@ -0,0 +1,57 @@
+package web
+
+import (
+	"errors"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+)
+
+func TestHandler(t *testing.T) {
+	fsys := fstest.MapFS{
+		"index.html":          {Data: []byte("<!doctype html>")},
+		"vite.svg":            {Data: []byte("<svg/>")},
+		"assets/index-ab1.js": {Data: []byte("console.log(1)")},
+		".gitkeep":            {},
+	}
+	h, err := Handler(fsys, http.NotFoundHandler())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		path, body, cache string
+		code              int
+	}{
+		{"/", "<!doctype html>", "no-cache", http.StatusOK},
+		{"/schedule", "<!doctype html>", "no-cache", http.StatusOK},
+		{"/master/", "<!doctype html>", "no-cache", http.StatusOK},
+		{"/vite.svg", "<svg/>", "no-cache", http.StatusOK},
+		{"/assets/index-ab1.js", "console.log(1)", "public, max-age=31536000, immutable", http.StatusOK},
+		{"/assets/index-old.js", "404 page not found\n", "", http.StatusNotFound},
+		{"/.gitkeep", "404 page not found\n", "", http.StatusNotFound},
+		{"/../index.html", "<!doctype html>", "no-cache", http.StatusOK},
+	} {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("GET", tc.path, nil))
+		if w.Code != tc.code || w.Body.String() != tc.body || w.Header().Get("Cache-Control") != tc.cache {
+			t.Errorf("%s: unexpected %d %q %q", tc.path, w.Code, w.Header().Get("Cache-Control"), w.Body)
+		}
+	}
+
+	// ETag が一致すれば 304
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/about", nil))
+	req := httptest.NewRequest("GET", "/login", nil)
+	req.Header.Set("If-None-Match", w.Header().Get("ETag"))
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	if w.Code != http.StatusNotModified {
+		t.Errorf("Expected 304, got %d", w.Code)
+	}
+
+	if _, err := Handler(fstest.MapFS{".gitkeep": {}}, http.NotFoundHandler()); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Expected fs.ErrNotExist without a build, got %v", err)
+	}
+}
@@ -1,8 +1,11 @@
+//go:build ignore
+
 package main
 
 import (
 	"fmt"
 	"os"
+	"regexp"
 	"strings"
 
 	"gopkg.in/yaml.v3"
@@ -14,6 +17,7 @@ type Database struct {
 		Version float64 `yaml:"version"`
 	} `yaml:"database"`
 	Tables []Table `yaml:"tables"`
+	Views  []View  `yaml:"views"`
 }
 
 type Table struct {
@@ -34,6 +38,14 @@ type Column struct {
 	FK            *FK         `yaml:"fk"`
 }
 
+// View はレポート等で利用するSQLビューの定義
+type View struct {
+	Name    string   `yaml:"name"`
+	Comment string   `yaml:"comment"`
+	Select  string   `yaml:"select"`
+	Columns []Column `yaml:"columns"`
+}
+
 type FK struct {
 	Table  string `yaml:"table"`
 	Column string `yaml:"column"`
@@ -109,6 +121,24 @@ func generatePlantUML(db Database) {
 		sb.WriteString("}\n\n")
 	}
 
+	// ビュー
+	for _, view := range db.Views {
+		sb.WriteString(fmt.Sprintf(
+			"entity %s as \"%s\\n[%s]\" <<view>> {\n",
+			view.Name,
+			view.Name,
+			view.Comment,
+		))
+		for _, col := range view.Columns {
+			line := "  " + col.Name + " : " + col.Type
+			if col.Comment != "" {
+				line += "  // " + col.Comment
+			}
+			sb.WriteString(line + "\n")
+		}
+		sb.WriteString("}\n\n")
+	}
+
 	// リレーション
 	for _, table := range db.Tables {
 		for _, col := range table.Columns {
@@ -120,6 +150,13 @@ func generatePlantUML(db Database) {
 		}
 	}
 
+	// ビューの参照元（点線）
+	for _, view := range db.Views {
+		for _, name := range viewDependencies(db, view) {
+			sb.WriteString(fmt.Sprintf("%s ..> %s\n", view.Name, name))
+		}
+	}
+
 	sb.WriteString("\n@enduml")
 
 	os.WriteFile("../../docs/schema_er.puml", []byte(sb.String()), 0644)
@@ -211,5 +248,56 @@ func generateMarkdown(db Database) {
 		}
 	}
 
+	// =========================
+	// ★ ビュー出力処理
+	// =========================
+	for _, view := range db.Views {
+
+		sb.WriteString(fmt.Sprintf("## %s（%s）［ビュー］\n\n", view.Name, view.Comment))
+		sb.WriteString("| カラム名 | 型 | 説明 |\n")
+		sb.WriteString("|----------|----|------|\n")
+
+		for _, col := range view.Columns {
+			sb.WriteString(fmt.Sprintf("| %s | %s | %s |\n",
+				col.Name,
+				col.Type,
+				col.Comment,
+			))
+		}
+
+		sb.WriteString("\n")
+
+		if deps := viewDependencies(db, view); len(deps) > 0 {
+			sb.WriteString("- 参照元: " + strings.Join(deps, ", ") + "\n\n")
+		}
+
+		sb.WriteString("```sql\n")
+		sb.WriteString(strings.TrimRight(view.Select, " \n"))
+		sb.WriteString("\n```\n\n")
+	}
+
 	os.WriteFile("../../docs/schema.md", []byte(sb.String()), 0644)
 }
+
+// viewDependencies はビューのSELECT本文が参照しているテーブル・ビュー名を返す
+func viewDependencies(db Database, view View) []string {
+
+	var names []string
+	for _, table := range db.Tables {
+		names = append(names, table.Name)
+	}
+	for _, v := range db.Views {
+		if v.Name != view.Name {
+			names = append(names, v.Name)
+		}
+	}
+
+	var deps []string
+	for _, name := range names {
+		re := regexp.MustCompile("(^|[^A-Za-z0-9_])`?" + regexp.QuoteMeta(name) + "`?($|[^A-Za-z0-9_])")
+		if re.MatchString(view.Select) {
+			deps = append(deps, name)
+		}
+	}
+	return deps
+}
@@ -1,8 +1,11 @@
+//go:build ignore
+
 package main
 
 import (
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/xuri/excelize/v2"
@@ -15,6 +18,7 @@ type Database struct {
 		Version float64 `yaml:"version"`
 	} `yaml:"database"`
 	Tables []Table `yaml:"tables"`
+	Views  []View  `yaml:"views"`
 }
 
 type Table struct {
@@ -36,6 +40,14 @@ type Column struct {
 	FK            *FK         `yaml:"fk"`
 }
 
+// View はレポート等で利用するSQLビューの定義
+type View struct {
+	Name    string   `yaml:"name"`
+	Comment string   `yaml:"comment"`
+	Select  string   `yaml:"select"`
+	Columns []Column `yaml:"columns"`
+}
+
 type FK struct {
 	Table  string `yaml:"table"`
 	Column string `yaml:"column"`
@@ -255,6 +267,64 @@ func generateExcel(db Database) {
 		}
 	}
 
+	// ===== ビュー定義 =====
+	for _, view := range db.Views {
+
+		sheet := view.Name
+		f.NewSheet(sheet)
+
+		f.SetCellValue(sheet, "A1", "ビュー名")
+		f.SetCellValue(sheet, "B1", view.Name)
+		f.SetCellValue(sheet, "A2", "コメント")
+		f.SetCellValue(sheet, "B2", view.Comment)
+
+		startRow := 4
+
+		headers := []string{"No", "カラム名", "型", "コメント"}
+
+		for col, val := range headers {
+			cell, _ := excelize.CoordinatesToCellName(col+1, startRow)
+			f.SetCellValue(sheet, cell, val)
+		}
+
+		headerStyle, _ := f.NewStyle(&excelize.Style{
+			Font:      &excelize.Font{Bold: true},
+			Alignment: &excelize.Alignment{Horizontal: "center"},
+			Border: []excelize.Border{
+				{Type: "left", Style: 1},
+				{Type: "right", Style: 1},
+				{Type: "top", Style: 1},
+				{Type: "bottom", Style: 1},
+			},
+		})
+		f.SetCellStyle(sheet, "A4", "D4", headerStyle)
+
+		for i, col := range view.Columns {
+
+			r := startRow + i + 1
+
+			f.SetCellValue(sheet, fmt.Sprintf("A%d", r), i+1)
+			f.SetCellValue(sheet, fmt.Sprintf("B%d", r), col.Name)
+			f.SetCellValue(sheet, fmt.Sprintf("C%d", r), col.Type)
+			f.SetCellValue(sheet, fmt.Sprintf("D%d", r), col.Comment)
+		}
+
+		f.SetColWidth(sheet, "A", "D", 18)
+
+		// SELECT本文
+		selectRow := startRow + len(view.Columns) + 3
+		f.SetCellValue(sheet, fmt.Sprintf("A%d", selectRow), "SELECT文")
+
+		wrapStyle, _ := f.NewStyle(&excelize.Style{
+			Alignment: &excelize.Alignment{WrapText: true, Vertical: "top"},
+		})
+		cell := fmt.Sprintf("A%d", selectRow+1)
+		f.SetCellValue(sheet, cell, view.Select)
+		f.MergeCell(sheet, cell, fmt.Sprintf("D%d", selectRow+1))
+		f.SetCellStyle(sheet, cell, cell, wrapStyle)
+		f.SetRowHeight(sheet, selectRow+1, float64(15*(len(strings.Split(view.Select, "\n"))+1)))
+	}
+
 	fileName := fmt.Sprintf("../../docs/DB仕様書_%s.xlsx",
 		time.Now().Format("20060102"))
 
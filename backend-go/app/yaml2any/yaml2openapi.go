@@ -1,3 +1,5 @@
+//go:build ignore
+
 package main
 
 import (
@@ -31,9 +33,17 @@ type Table struct {
 	Columns []Column `yaml:"columns"`
 }
 
+// View はレポート等で利用するSQLビューの定義
+type View struct {
+	Name    string   `yaml:"name"`
+	Comment string   `yaml:"comment"`
+	Columns []Column `yaml:"columns"`
+}
+
 type DBSpec struct {
 	Database Database `yaml:"database"`
 	Tables   []Table  `yaml:"tables"`
+	Views    []View   `yaml:"views"`
 }
 
 // ===== OpenAPI構造 =====
@@ -60,6 +70,32 @@ type Schema struct {
 	Properties  map[string]Property `yaml:"properties"`
 	Required    []string            `yaml:"required,omitempty"`
 	XTableName  string              `yaml:"x-table-name,omitempty"`
+	XViewName   string              `yaml:"x-view-name,omitempty"`
+	XReadOnly   bool                `yaml:"x-read-only,omitempty"`
+}
+
+// ===== パス（ビューの一覧取得用） =====
+
+type Operation struct {
+	Tags        []string            `yaml:"tags,omitempty"`
+	Summary     string              `yaml:"summary"`
+	OperationID string              `yaml:"operationId"`
+	Responses   map[string]Response `yaml:"responses"`
+}
+
+type Response struct {
+	Description string               `yaml:"description"`
+	Content     map[string]MediaType `yaml:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema SchemaRef `yaml:"schema"`
+}
+
+type SchemaRef struct {
+	Type  string     `yaml:"type,omitempty"`
+	Items *SchemaRef `yaml:"items,omitempty"`
+	Ref   string     `yaml:"$ref,omitempty"`
 }
 
 type Property struct {
@@ -116,6 +152,14 @@ func toSchemaName(name string) string {
 	return strings.Title(name)
 }
 
+func toCamelCase(name string) string {
+	var sb strings.Builder
+	for _, part := range strings.Split(name, "_") {
+		sb.WriteString(strings.Title(part))
+	}
+	return sb.String()
+}
+
 // ===== メイン処理 =====
 
 func main() {
@@ -175,6 +219,54 @@ func main() {
 		openapi.Components.Schemas[toSchemaName(table.Name)] = schema
 	}
 
+	// ビューは読み取り専用の一覧取得エンドポイントとして公開する
+	for _, view := range db.Views {
+
+		schema := Schema{
+			Type:        "object",
+			Description: view.Comment,
+			Properties:  make(map[string]Property),
+			XViewName:   view.Name,
+			XReadOnly:   true,
+		}
+
+		for _, col := range view.Columns {
+
+			t, f, maxLen := convertType(col.Type)
+
+			schema.Properties[col.Name] = Property{
+				Type:        t,
+				Format:      f,
+				MaxLength:   maxLen,
+				Description: col.Comment,
+			}
+		}
+
+		schemaName := toSchemaName(view.Name)
+		openapi.Components.Schemas[schemaName] = schema
+
+		openapi.Paths["/views/"+view.Name] = map[string]Operation{
+			"get": {
+				Tags:        []string{"ビュー"},
+				Summary:     view.Comment + " 一覧取得",
+				OperationID: "list" + toCamelCase(view.Name),
+				Responses: map[string]Response{
+					"200": {
+						Description: "OK",
+						Content: map[string]MediaType{
+							"application/json": {
+								Schema: SchemaRef{
+									Type:  "array",
+									Items: &SchemaRef{Ref: "#/components/schemas/" + schemaName},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
 	out, err := yaml.Marshal(openapi)
 	if err != nil {
 		panic(err)
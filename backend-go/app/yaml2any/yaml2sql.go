@@ -1,8 +1,11 @@
+//go:build ignore
+
 package main
 
 import (
 	"fmt"
 	"os"
+	"regexp"
 	"strings"
 
 	"gopkg.in/yaml.v3"
@@ -14,6 +17,7 @@ type Database struct {
 		Version float64 `yaml:"version"`
 	} `yaml:"database"`
 	Tables []Table `yaml:"tables"`
+	Views  []View  `yaml:"views"`
 }
 
 type Table struct {
@@ -40,6 +44,14 @@ type FK struct {
 	Column string `yaml:"column"`
 }
 
+// View はレポート等で利用するSQLビューの定義
+type View struct {
+	Name    string   `yaml:"name"`
+	Comment string   `yaml:"comment"`
+	Select  string   `yaml:"select"`
+	Columns []Column `yaml:"columns"`
+}
+
 type Index struct {
 	Name     string   `yaml:"name"`
 	Columns  []string `yaml:"columns"`
@@ -192,6 +204,10 @@ func generateSQL(db Database) {
 				for _, col := range table.Columns {
 
 					val := "NULL"
+					if col.Default != nil {
+						// 省略した列は既定値にする（NOT NULL の version など）
+						val = "DEFAULT"
+					}
 
 					if v, ok := row[col.Name]; ok {
 						val = formatValue(v)
@@ -212,11 +228,88 @@ func generateSQL(db Database) {
 		}
 	}
 
+	// ==========================
+	// ★ VIEW生成（依存順）
+	// ==========================
+	views, err := sortViews(db.Views)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, view := range views {
+
+		if view.Comment != "" {
+			sb.WriteString(fmt.Sprintf("-- %s\n", view.Comment))
+		}
+
+		var colNames []string
+		for _, col := range view.Columns {
+			colNames = append(colNames, "`"+col.Name+"`")
+		}
+
+		sb.WriteString(fmt.Sprintf("DROP VIEW IF EXISTS `%s`;\n", view.Name))
+		sb.WriteString(fmt.Sprintf("CREATE VIEW `%s` (%s) AS\n", view.Name, strings.Join(colNames, ", ")))
+		sb.WriteString(strings.TrimRight(view.Select, " \n"))
+		sb.WriteString(";\n\n")
+	}
+
 	sb.WriteString("SET FOREIGN_KEY_CHECKS = 1;\n")
 
 	os.WriteFile("../../docs/schema.sql", []byte(sb.String()), 0644)
 }
 
+// sortViews はSELECT本文から参照している他のビューを検出し、
+// 参照先が先に作成されるよう並べ替える。循環参照はエラーとする。
+func sortViews(views []View) ([]View, error) {
+
+	byName := make(map[string]View)
+	for _, v := range views {
+		byName[v.Name] = v
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int)
+	var sorted []View
+
+	var visit func(v View) error
+	visit = func(v View) error {
+		switch state[v.Name] {
+		case visiting:
+			return fmt.Errorf("view %s: circular dependency", v.Name)
+		case done:
+			return nil
+		}
+		state[v.Name] = visiting
+		for _, dep := range views {
+			if dep.Name != v.Name && referencesName(v.Select, dep.Name) {
+				if err := visit(byName[dep.Name]); err != nil {
+					return err
+				}
+			}
+		}
+		state[v.Name] = done
+		sorted = append(sorted, v)
+		return nil
+	}
+
+	for _, v := range views {
+		if err := visit(v); err != nil {
+			return nil, err
+		}
+	}
+	return sorted, nil
+}
+
+// referencesName はSQL本文がテーブル名・ビュー名を識別子として含むかを判定する
+func referencesName(sql, name string) bool {
+	re := regexp.MustCompile("(^|[^A-Za-z0-9_])`?" + regexp.QuoteMeta(name) + "`?($|[^A-Za-z0-9_])")
+	return re.MatchString(sql)
+}
+
 func formatColumns(cols []string) string {
 	var quoted []string
 	for _, c := range cols {
@@ -228,6 +321,10 @@ func formatColumns(cols []string) string {
 func formatDefault(def interface{}) string {
 	switch v := def.(type) {
 	case string:
+		// 関数呼び出しはクォートしない
+		if strings.EqualFold(v, "CURRENT_TIMESTAMP") {
+			return "CURRENT_TIMESTAMP"
+		}
 		return "'" + v + "'"
 	case bool:
 		if v {
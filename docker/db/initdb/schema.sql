@@ -4,99 +4,120 @@ DROP TABLE IF EXISTS `account_types_master`;
 CREATE TABLE `account_types_master` (
   `id` bigint AUTO_INCREMENT NOT NULL COMMENT 'ID',
   `name` varchar(100) COMMENT '種別名',
+  `version` bigint NOT NULL DEFAULT 1 COMMENT 'バージョン（更新のたびに加算）',
   PRIMARY KEY (`id`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='口座種別マスタ';
 
 -- seed data for account_types_master
-INSERT INTO `account_types_master` (`id`, `name`) VALUES
-  (1, '普通預金'),
-  (2, '当座預金');
+INSERT INTO `account_types_master` (`id`, `name`, `version`) VALUES
+  (1, '普通預金', DEFAULT),
+  (2, '当座預金', DEFAULT);
 
 DROP TABLE IF EXISTS `billing_days_master`;
 CREATE TABLE `billing_days_master` (
   `id` bigint AUTO_INCREMENT NOT NULL COMMENT 'ID',
   `name` varchar(100) NOT NULL COMMENT '請求日名',
   `day` integer NOT NULL COMMENT '請求日',
+  `version` bigint NOT NULL DEFAULT 1 COMMENT 'バージョン（更新のたびに加算）',
   PRIMARY KEY (`id`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='請求日マスタ';
 
 -- seed data for billing_days_master
-INSERT INTO `billing_days_master` (`id`, `name`, `day`) VALUES
-  (1, '31日', 31);
+INSERT INTO `billing_days_master` (`id`, `name`, `day`, `version`) VALUES
+  (1, '31日', 31, DEFAULT);
 
 DROP TABLE IF EXISTS `billing_months_master`;
 CREATE TABLE `billing_months_master` (
   `id` bigint AUTO_INCREMENT NOT NULL COMMENT 'ID',
   `name` varchar(100) NOT NULL COMMENT '請求月',
+  `version` bigint NOT NULL DEFAULT 1 COMMENT 'バージョン（更新のたびに加算）',
   PRIMARY KEY (`id`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='請求月マスタ';
 
 -- seed data for billing_months_master
-INSERT INTO `billing_months_master` (`id`, `name`) VALUES
-  (1, '当月');
+INSERT INTO `billing_months_master` (`id`, `name`, `version`) VALUES
+  (1, '当月', DEFAULT);
+
+DROP TABLE IF EXISTS `business_calendars_master`;
+CREATE TABLE `business_calendars_master` (
+  `id` bigint AUTO_INCREMENT NOT NULL COMMENT 'ID',
+  `warehouse_code` varchar(100) COMMENT '倉庫コード（NULL は全倉庫共通）',
+  `calendar_date` date NOT NULL COMMENT '日付',
+  `working` boolean NOT NULL DEFAULT false COMMENT '営業日か（false は休業日、true は臨時営業日）',
+  `name` varchar(100) NOT NULL COMMENT '名称（年末年始休業など）',
+  `version` bigint NOT NULL DEFAULT 1 COMMENT 'バージョン（更新のたびに加算）',
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `uq_business_calendars_master_warehouse_code_calendar_date` (`warehouse_code`, `calendar_date`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='営業日カレンダーマスタ（土日・祝日と異なる倉庫の休業日・臨時営業日）';
 
 DROP TABLE IF EXISTS `closing_dates_master`;
 CREATE TABLE `closing_dates_master` (
   `id` bigint AUTO_INCREMENT NOT NULL COMMENT 'ID',
   `name` varchar(100) NOT NULL COMMENT '締日名',
   `day` integer NOT NULL COMMENT '締日',
+  `version` bigint NOT NULL DEFAULT 1 COMMENT 'バージョン（更新のたびに加算）',
   PRIMARY KEY (`id`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='締日マスタ';
 
 -- seed data for closing_dates_master
-INSERT INTO `closing_dates_master` (`id`, `name`, `day`) VALUES
-  (1, '31日', 31);
+INSERT INTO `closing_dates_master` (`id`, `name`, `day`, `version`) VALUES
+  (1, '31日', 31, DEFAULT);
 
 DROP TABLE IF EXISTS `collaborations_master`;
 CREATE TABLE `collaborations_master` (
   `id` bigint AUTO_INCREMENT NOT NULL COMMENT 'ID',
   `name` varchar(100) NOT NULL COMMENT '連携種別名',
+  `version` bigint NOT NULL DEFAULT 1 COMMENT 'バージョン（更新のたびに加算）',
   PRIMARY KEY (`id`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='連携種別マスタ';
 
 -- seed data for collaborations_master
-INSERT INTO `collaborations_master` (`id`, `name`) VALUES
-  (1, 'JSON');
+INSERT INTO `collaborations_master` (`id`, `name`, `version`) VALUES
+  (1, 'JSON', DEFAULT);
 
 DROP TABLE IF EXISTS `consumption_tax_shows_master`;
 CREATE TABLE `consumption_tax_shows_master` (
   `id` bigint AUTO_INCREMENT NOT NULL COMMENT 'ID',
   `name` varchar(100) COMMENT '表示形式名',
+  `version` bigint NOT NULL DEFAULT 1 COMMENT 'バージョン（更新のたびに加算）',
   PRIMARY KEY (`id`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='消費税表示形式マスタ';
 
 -- seed data for consumption_tax_shows_master
-INSERT INTO `consumption_tax_shows_master` (`id`, `name`) VALUES
-  (1, '内税'),
-  (2, '外税');
+INSERT INTO `consumption_tax_shows_master` (`id`, `name`, `version`) VALUES
+  (1, '内税', DEFAULT),
+  (2, '外税', DEFAULT);
 
 DROP TABLE IF EXISTS `fare_aggregations_master`;
 CREATE TABLE `fare_aggregations_master` (
   `id` bigint AUTO_INCREMENT NOT NULL COMMENT 'ID',
   `name` varchar(100) NOT NULL COMMENT '集約名',
+  `version` bigint NOT NULL DEFAULT 1 COMMENT 'バージョン（更新のたびに加算）',
   PRIMARY KEY (`id`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='運賃集約マスタ';
 
 -- seed data for fare_aggregations_master
-INSERT INTO `fare_aggregations_master` (`id`, `name`) VALUES
-  (1, '入庫出庫別');
+INSERT INTO `fare_aggregations_master` (`id`, `name`, `version`) VALUES
+  (1, '入庫出庫別', DEFAULT);
 
 DROP TABLE IF EXISTS `kinds_master`;
 CREATE TABLE `kinds_master` (
   `id` bigint AUTO_INCREMENT NOT NULL COMMENT 'ID',
   `name` varchar(100) NOT NULL COMMENT '種別名',
+  `version` bigint NOT NULL DEFAULT 1 COMMENT 'バージョン（更新のたびに加算）',
   PRIMARY KEY (`id`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='種別マスタ';
 
 -- seed data for kinds_master
-INSERT INTO `kinds_master` (`id`, `name`) VALUES
-  (1, '請求書');
+INSERT INTO `kinds_master` (`id`, `name`, `version`) VALUES
+  (1, '請求書', DEFAULT);
 
 DROP TABLE IF EXISTS `locations_master`;
 CREATE TABLE `locations_master` (
   `id` bigint AUTO_INCREMENT NOT NULL COMMENT 'ID',
   `code` varchar(100) NOT NULL COMMENT 'ロケーションコード⇒何桁？★',
   `warehouse` varchar(100) NOT NULL COMMENT '倉庫',
+  `version` bigint NOT NULL DEFAULT 1 COMMENT 'バージョン（更新のたびに加算）',
   PRIMARY KEY (`id`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='ロケーションマスタ';
 
@@ -104,20 +125,22 @@ DROP TABLE IF EXISTS `roundings_master`;
 CREATE TABLE `roundings_master` (
   `id` bigint AUTO_INCREMENT NOT NULL COMMENT 'ID',
   `name` varchar(100) NOT NULL COMMENT '処理名',
+  `version` bigint NOT NULL DEFAULT 1 COMMENT 'バージョン（更新のたびに加算）',
   PRIMARY KEY (`id`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='端数処理マスタ';
 
 -- seed data for roundings_master
-INSERT INTO `roundings_master` (`id`, `name`) VALUES
-  (1, '切上げ'),
-  (2, '四捨五入'),
-  (3, '切捨て');
+INSERT INTO `roundings_master` (`id`, `name`, `version`) VALUES
+  (1, '切上げ', DEFAULT),
+  (2, '四捨五入', DEFAULT),
+  (3, '切捨て', DEFAULT);
 
 DROP TABLE IF EXISTS `shippings_master`;
 CREATE TABLE `shippings_master` (
   `id` bigint AUTO_INCREMENT NOT NULL COMMENT 'ID',
   `code` varchar(100) NOT NULL COMMENT '荷主コード⇒何桁？★別マスタが必要？',
   `name` varchar(100) NOT NULL COMMENT '荷主名',
+  `version` bigint NOT NULL DEFAULT 1 COMMENT 'バージョン（更新のたびに加算）',
   PRIMARY KEY (`id`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='荷主マスタ';
 
@@ -134,6 +157,7 @@ CREATE TABLE `departments_master` (
   `order_selection_category` varchar(100) NOT NULL COMMENT 'オーダー選択区分⇒マスタが必要？★',
   `channels` varchar(100) NOT NULL COMMENT '取扱チャンネル⇒マスタが必要？★',
   `expense_claims` varchar(100) NOT NULL COMMENT '経費請求⇒マスタが必要？★',
+  `version` bigint NOT NULL DEFAULT 1 COMMENT 'バージョン（更新のたびに加算）',
   PRIMARY KEY (`id`),
   CONSTRAINT `fk_departments_master_shipping_id` FOREIGN KEY (`shipping_id`) REFERENCES `shippings_master`(`id`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='部門マスタ';
@@ -148,6 +172,7 @@ CREATE TABLE `approval_flows_master` (
   `name_4` varchar(100) NOT NULL COMMENT '承認者４',
   `name_5` varchar(100) NOT NULL COMMENT '承認者５',
   `valid_flag` boolean NOT NULL DEFAULT true COMMENT '有効無効',
+  `version` bigint NOT NULL DEFAULT 1 COMMENT 'バージョン（更新のたびに加算）',
   PRIMARY KEY (`id`),
   CONSTRAINT `fk_approval_flows_master_department_id` FOREIGN KEY (`department_id`) REFERENCES `departments_master`(`id`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='承認フローマスタ';
@@ -167,6 +192,7 @@ CREATE TABLE `billings_master` (
   `consumption_tax_show_id` bigint NOT NULL COMMENT '消費税',
   `rounding_id` bigint NOT NULL COMMENT '端数処理(円未満)',
   `fare_aggregation_id` bigint NOT NULL COMMENT '運賃集約',
+  `version` bigint NOT NULL DEFAULT 1 COMMENT 'バージョン（更新のたびに加算）',
   PRIMARY KEY (`id`),
   CONSTRAINT `fk_billings_master_shipping_id` FOREIGN KEY (`shipping_id`) REFERENCES `shippings_master`(`id`),
   CONSTRAINT `fk_billings_master_closing_date_id` FOREIGN KEY (`closing_date_id`) REFERENCES `closing_dates_master`(`id`),
@@ -186,21 +212,23 @@ CREATE TABLE `consumption_tax_rates_master` (
   `tax_rate` decimal(5,2) NOT NULL COMMENT '税率',
   `effective_start_date` date NOT NULL COMMENT '有効開始日',
   `remarks` varchar(100) COMMENT '備考',
+  `version` bigint NOT NULL DEFAULT 1 COMMENT 'バージョン（更新のたびに加算）',
   PRIMARY KEY (`id`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='消費税率保守マスタ';
 
 -- seed data for consumption_tax_rates_master
-INSERT INTO `consumption_tax_rates_master` (`id`, `code`, `tax_rate`, `effective_start_date`, `remarks`) VALUES
-  (1, '01', 3, '1989-04-01', '3%'),
-  (2, '02', 5, '1907-04-01', '5%'),
-  (3, '03', 8, '2014-04-01', '8%'),
-  (4, '04', 10, '2019-10-01', '10%');
+INSERT INTO `consumption_tax_rates_master` (`id`, `code`, `tax_rate`, `effective_start_date`, `remarks`, `version`) VALUES
+  (1, '01', 3, '1989-04-01', '3%', DEFAULT),
+  (2, '02', 5, '1907-04-01', '5%', DEFAULT),
+  (3, '03', 8, '2014-04-01', '8%', DEFAULT),
+  (4, '04', 10, '2019-10-01', '10%', DEFAULT);
 
 DROP TABLE IF EXISTS `customers_master`;
 CREATE TABLE `customers_master` (
   `id` bigint AUTO_INCREMENT NOT NULL COMMENT 'ID',
   `code` varchar(100) NOT NULL COMMENT '利用者コード',
   `class` varchar(100) NOT NULL COMMENT '分類⇒分類マスタが必要★',
+  `version` bigint NOT NULL DEFAULT 1 COMMENT 'バージョン（更新のたびに加算）',
   PRIMARY KEY (`id`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='利用者マスタ';
 
@@ -215,6 +243,7 @@ CREATE TABLE `customers_info_master` (
   `country` varchar(100) NOT NULL COMMENT '市',
   `address_1` varchar(100) NOT NULL COMMENT '住所１',
   `address_2` varchar(100) COMMENT '住所２',
+  `version` bigint NOT NULL DEFAULT 1 COMMENT 'バージョン（更新のたびに加算）',
   PRIMARY KEY (`id`),
   CONSTRAINT `fk_customers_info_master_user_id` FOREIGN KEY (`user_id`) REFERENCES `customers_master`(`id`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='利用者情報マスタ';
@@ -228,6 +257,7 @@ CREATE TABLE `delivery_companys_master` (
   `kubun` boolean NOT NULL DEFAULT true COMMENT '自車・備車区分⇒マスタが必要？★',
   `tel` varchar(100) COMMENT '電話番号',
   `package_tracking_url` varchar(100) COMMENT '荷物追跡用URL',
+  `version` bigint NOT NULL DEFAULT 1 COMMENT 'バージョン（更新のたびに加算）',
   PRIMARY KEY (`id`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='配送業者マスタ';
 
@@ -238,6 +268,7 @@ CREATE TABLE `entry_and_exit_fees_master` (
   `name` varchar(100) NOT NULL COMMENT '名称',
   `cost` bigint NOT NULL COMMENT '費用',
   `remarks` varchar(100) COMMENT '備考',
+  `version` bigint NOT NULL DEFAULT 1 COMMENT 'バージョン（更新のたびに加算）',
   PRIMARY KEY (`id`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='入出庫料金マスタ';
 
@@ -249,6 +280,7 @@ CREATE TABLE `external_collaborations_master` (
   `collaboration_id` bigint NOT NULL COMMENT '連携種別',
   `valid_flag` boolean NOT NULL DEFAULT true COMMENT '有効無効',
   `form` text COMMENT 'フォーム',
+  `version` bigint NOT NULL DEFAULT 1 COMMENT 'バージョン（更新のたびに加算）',
   PRIMARY KEY (`id`),
   CONSTRAINT `fk_external_collaborations_master_kind_id` FOREIGN KEY (`kind_id`) REFERENCES `kinds_master`(`id`),
   CONSTRAINT `fk_external_collaborations_master_collaboration_id` FOREIGN KEY (`collaboration_id`) REFERENCES `collaborations_master`(`id`)
@@ -258,6 +290,7 @@ DROP TABLE IF EXISTS `groups_master`;
 CREATE TABLE `groups_master` (
   `id` bigint AUTO_INCREMENT NOT NULL COMMENT 'ID',
   `name` varchar(100) NOT NULL COMMENT 'グループ名',
+  `version` bigint NOT NULL DEFAULT 1 COMMENT 'バージョン（更新のたびに加算）',
   PRIMARY KEY (`id`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='グループマスタ';
 
@@ -332,6 +365,7 @@ CREATE TABLE `items_master` (
   `packing_style_height` decimal(10,2) COMMENT '荷姿　高',
   `packing_style_weight` decimal(10,2) COMMENT '荷姿　重量',
   `packing_style_volume` decimal(10,2) COMMENT '荷姿　容積',
+  `version` bigint NOT NULL DEFAULT 1 COMMENT 'バージョン（更新のたびに加算）',
   PRIMARY KEY (`id`),
   CONSTRAINT `fk_items_master_department_id` FOREIGN KEY (`department_id`) REFERENCES `departments_master`(`id`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='アイテムマスタ⇒QRコードについて未？★';
@@ -343,6 +377,7 @@ CREATE TABLE `mobile_devices_master` (
   `mac_address` varchar(100) NOT NULL COMMENT 'MACアドレス',
   `valid_flag` boolean NOT NULL DEFAULT true COMMENT '有効無効',
   `remarks` varchar(100) COMMENT '備考',
+  `version` bigint NOT NULL DEFAULT 1 COMMENT 'バージョン（更新のたびに加算）',
   PRIMARY KEY (`id`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='モバイル端末マスタ';
 
@@ -352,14 +387,15 @@ CREATE TABLE `order_deadlines_master` (
   `name` varchar(200) NOT NULL COMMENT '時刻種別名',
   `time` varchar(100) NOT NULL COMMENT '時刻',
   `valid_flag` boolean NOT NULL DEFAULT true COMMENT '有効無効',
+  `version` bigint NOT NULL DEFAULT 1 COMMENT 'バージョン（更新のたびに加算）',
   PRIMARY KEY (`id`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='受注締切時刻保守マスタ';
 
 -- seed data for order_deadlines_master
-INSERT INTO `order_deadlines_master` (`id`, `name`, `time`, `valid_flag`) VALUES
-  (1, '受注締切時刻', '12:00', true),
-  (2, 'オーダーエントリ中の猶予時刻（受注締切時刻の5分後）', '12:05', true),
-  (3, '緊急出庫基準時刻', '48:00', true);
+INSERT INTO `order_deadlines_master` (`id`, `name`, `time`, `valid_flag`, `version`) VALUES
+  (1, '受注締切時刻', '12:00', true, DEFAULT),
+  (2, 'オーダーエントリ中の猶予時刻（受注締切時刻の5分後）', '12:05', true, DEFAULT),
+  (3, '緊急出庫基準時刻', '48:00', true, DEFAULT);
 
 DROP TABLE IF EXISTS `packing_sizes_master`;
 CREATE TABLE `packing_sizes_master` (
@@ -368,6 +404,7 @@ CREATE TABLE `packing_sizes_master` (
   `name` varchar(100) NOT NULL COMMENT '名称',
   `remarks` varchar(100) COMMENT '備考',
   `order` int NOT NULL COMMENT '順序',
+  `version` bigint NOT NULL DEFAULT 1 COMMENT 'バージョン（更新のたびに加算）',
   PRIMARY KEY (`id`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='梱包サイズマスタ';
 
@@ -378,6 +415,7 @@ CREATE TABLE `product_categories_master` (
   `name` varchar(100) NOT NULL COMMENT '名称',
   `remarks` varchar(100) COMMENT '備考',
   `order` int NOT NULL COMMENT '順序',
+  `version` bigint NOT NULL DEFAULT 1 COMMENT 'バージョン（更新のたびに加算）',
   PRIMARY KEY (`id`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='商品カテゴリマスタ';
 
@@ -388,6 +426,7 @@ CREATE TABLE `product_units_master` (
   `name` varchar(100) NOT NULL COMMENT '名称',
   `remarks` varchar(100) COMMENT '備考',
   `order` integer NOT NULL COMMENT '順序',
+  `version` bigint NOT NULL DEFAULT 1 COMMENT 'バージョン（更新のたびに加算）',
   PRIMARY KEY (`id`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='商品単位マスタ';
 
@@ -398,6 +437,7 @@ CREATE TABLE `return_and_repair_units_master` (
   `name` varchar(100) NOT NULL COMMENT '名称',
   `remarks` varchar(100) COMMENT '備考',
   `order` int NOT NULL COMMENT '順序',
+  `version` bigint NOT NULL DEFAULT 1 COMMENT 'バージョン（更新のたびに加算）',
   PRIMARY KEY (`id`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='返却入庫補修単位マスタ';
 
@@ -409,6 +449,7 @@ CREATE TABLE `services_useds_master` (
   `amount` bigint NOT NULL COMMENT '金額',
   `activation_time` timestamp NOT NULL COMMENT '有効化日時',
   `invalidation_time` timestamp NOT NULL COMMENT '無効化日時',
+  `version` bigint NOT NULL DEFAULT 1 COMMENT 'バージョン（更新のたびに加算）',
   PRIMARY KEY (`id`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='利用サービスマスタ⇒1-17-21の定義が未★';
 
@@ -425,6 +466,7 @@ CREATE TABLE `set_items_master` (
   `comment_4` varchar(100) COMMENT 'コメント４',
   `comment_5` varchar(100) COMMENT 'コメント５',
   `remarks` varchar(100) COMMENT '備考',
+  `version` bigint NOT NULL DEFAULT 1 COMMENT 'バージョン（更新のたびに加算）',
   PRIMARY KEY (`id`),
   CONSTRAINT `fk_set_items_master_department_id` FOREIGN KEY (`department_id`) REFERENCES `departments_master`(`id`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='セットアイテムマスタ';
@@ -435,6 +477,7 @@ CREATE TABLE `set_items_product_units_master` (
   `set_item_id` bigint NOT NULL COMMENT 'アイテムID',
   `product_unit_id` bigint NOT NULL COMMENT '商品ID⇒何桁？★',
   `quantity` integer NOT NULL COMMENT '数量',
+  `version` bigint NOT NULL DEFAULT 1 COMMENT 'バージョン（更新のたびに加算）',
   PRIMARY KEY (`id`),
   CONSTRAINT `fk_set_items_product_units_master_set_item_id` FOREIGN KEY (`set_item_id`) REFERENCES `set_items_master`(`id`),
   CONSTRAINT `fk_set_items_product_units_master_product_unit_id` FOREIGN KEY (`product_unit_id`) REFERENCES `product_units_master`(`id`)
@@ -443,6 +486,7 @@ CREATE TABLE `set_items_product_units_master` (
 DROP TABLE IF EXISTS `shipping_fees_master`;
 CREATE TABLE `shipping_fees_master` (
   `id` bigint AUTO_INCREMENT NOT NULL COMMENT 'ID',
+  `version` bigint NOT NULL DEFAULT 1 COMMENT 'バージョン（更新のたびに加算）',
   PRIMARY KEY (`id`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='配送料金マスタ⇒1-17-9の内容が不明？★';
 
@@ -462,6 +506,7 @@ CREATE TABLE `stores_master` (
   `fax` varchar(20) NOT NULL COMMENT 'FAX番号',
   `designated_delivery_company` varchar(100) COMMENT '指定配送業者名',
   `email` varchar(100) COMMENT '店舗メールアドレス',
+  `version` bigint NOT NULL DEFAULT 1 COMMENT 'バージョン（更新のたびに加算）',
   PRIMARY KEY (`id`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='店舗マスタ';
 
@@ -474,9 +519,23 @@ CREATE TABLE `system_mail_settings_master` (
   `email_from` varchar(100) NOT NULL COMMENT '送信元メールアドレス',
   `remarks` text COMMENT '備考',
   `valid_flag` boolean NOT NULL DEFAULT true COMMENT '有効無効',
-  PRIMARY KEY (`id`)
+  `version` bigint NOT NULL DEFAULT 1 COMMENT 'バージョン（更新のたびに加算）',
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `uq_system_mail_settings_master_code` (`code`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='システムメール設定マスタ';
 
+-- seed data for system_mail_settings_master
+INSERT INTO `system_mail_settings_master` (`id`, `code`, `title`, `body`, `email_from`, `remarks`, `valid_flag`, `version`) VALUES
+  (1, 'password_reset', 'パスワード再設定のご案内', '{name} 様
+
+パスワード再設定のリクエストを受け付けました。
+以下のURLから {expires_at} までに新しいパスワードを設定してください。
+
+{url}
+
+このメールに心当たりがない場合は破棄してください。
+', 'noreply@example.com', 'パスワード再設定メール。{name} {url} {expires_at} を置換する', true, DEFAULT);
+
 DROP TABLE IF EXISTS `users_master`;
 CREATE TABLE `users_master` (
   `id` bigint AUTO_INCREMENT NOT NULL COMMENT 'ID',
@@ -485,11 +544,217 @@ CREATE TABLE `users_master` (
   `name` varchar(100) NOT NULL COMMENT 'ユーザ名',
   `email` varchar(100) NOT NULL COMMENT 'メールアドレス',
   `department_id` bigint NOT NULL COMMENT '所属ID',
+  `shipping_id` bigint COMMENT '荷主ID（荷主側ユーザのみ。NULL は倉庫側ユーザで全荷主のデータを扱える）',
   `valid_flag` boolean NOT NULL DEFAULT true COMMENT '有効無効',
-  `one_time_passwd` varchar(100) NOT NULL COMMENT 'ワンタイムパスワード',
+  `one_time_passwd` varchar(100) NOT NULL COMMENT 'ワンタイムパスワード（TOTPシークレット、Base32。空なら未登録）',
+  `password_hash` varchar(100) COMMENT 'パスワード（bcryptハッシュ、未設定ならログイン不可）',
+  `totp_enabled` boolean NOT NULL DEFAULT false COMMENT '二要素認証有効（登録確認済み）',
+  `totp_last_step` bigint COMMENT '最後に使用したTOTPの時間ステップ（再利用防止）',
+  `failed_login_count` int NOT NULL DEFAULT 0 COMMENT '連続ログイン失敗回数',
+  `locked_until` datetime COMMENT 'アカウントロック解除日時',
+  `version` bigint NOT NULL DEFAULT 1 COMMENT 'バージョン（更新のたびに加算）',
   PRIMARY KEY (`id`),
   CONSTRAINT `fk_users_master_group_id` FOREIGN KEY (`group_id`) REFERENCES `groups_master`(`id`),
-  CONSTRAINT `fk_users_master_department_id` FOREIGN KEY (`department_id`) REFERENCES `departments_master`(`id`)
+  CONSTRAINT `fk_users_master_department_id` FOREIGN KEY (`department_id`) REFERENCES `departments_master`(`id`),
+  CONSTRAINT `fk_users_master_shipping_id` FOREIGN KEY (`shipping_id`) REFERENCES `shippings_master`(`id`),
+  UNIQUE INDEX `uq_users_master_user_id` (`user_id`),
+  UNIQUE INDEX `uq_users_master_email` (`email`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='ユーザマスタ⇒1-17-3について検討未？★';
 
+DROP TABLE IF EXISTS `user_sessions`;
+CREATE TABLE `user_sessions` (
+  `id` bigint AUTO_INCREMENT NOT NULL COMMENT 'ID',
+  `user_id` bigint NOT NULL COMMENT 'ユーザマスタID',
+  `token_hash` char(64) NOT NULL COMMENT 'セッショントークンのSHA-256（平文は保存しない）',
+  `user_agent` varchar(255) COMMENT 'User-Agent',
+  `ip_address` varchar(45) COMMENT '接続元IPアドレス',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'ログイン日時',
+  `expires_at` datetime NOT NULL COMMENT '有効期限',
+  `revoked_at` datetime COMMENT '失効日時（ログアウト・強制失効）',
+  PRIMARY KEY (`id`),
+  CONSTRAINT `fk_user_sessions_user_id` FOREIGN KEY (`user_id`) REFERENCES `users_master`(`id`),
+  UNIQUE INDEX `uq_user_sessions_token_hash` (`token_hash`),
+  INDEX `idx_user_sessions_user_id` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='ログインセッション';
+
+DROP TABLE IF EXISTS `user_recovery_codes`;
+CREATE TABLE `user_recovery_codes` (
+  `id` bigint AUTO_INCREMENT NOT NULL COMMENT 'ID',
+  `user_id` bigint NOT NULL COMMENT 'ユーザマスタID',
+  `code_hash` char(64) NOT NULL COMMENT 'リカバリーコードのSHA-256',
+  `used_at` datetime COMMENT '使用日時',
+  PRIMARY KEY (`id`),
+  CONSTRAINT `fk_user_recovery_codes_user_id` FOREIGN KEY (`user_id`) REFERENCES `users_master`(`id`),
+  UNIQUE INDEX `uq_user_recovery_codes_user_id_code_hash` (`user_id`, `code_hash`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='二要素認証リカバリーコード';
+
+DROP TABLE IF EXISTS `password_reset_tokens`;
+CREATE TABLE `password_reset_tokens` (
+  `id` bigint AUTO_INCREMENT NOT NULL COMMENT 'ID',
+  `user_id` bigint NOT NULL COMMENT 'ユーザマスタID',
+  `token_hash` char(64) NOT NULL COMMENT 'トークンのSHA-256（平文はメールでのみ送付）',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '発行日時',
+  `expires_at` datetime NOT NULL COMMENT '有効期限',
+  `used_at` datetime COMMENT '使用日時（使用済みトークンは再利用不可）',
+  PRIMARY KEY (`id`),
+  CONSTRAINT `fk_password_reset_tokens_user_id` FOREIGN KEY (`user_id`) REFERENCES `users_master`(`id`),
+  UNIQUE INDEX `uq_password_reset_tokens_token_hash` (`token_hash`),
+  INDEX `idx_password_reset_tokens_user_id` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='パスワード再設定トークン';
+
+DROP TABLE IF EXISTS `calendar_feed_tokens`;
+CREATE TABLE `calendar_feed_tokens` (
+  `id` bigint AUTO_INCREMENT NOT NULL COMMENT 'ID',
+  `user_id` bigint NOT NULL COMMENT 'ユーザマスタID',
+  `token_hash` char(64) NOT NULL COMMENT 'トークンのSHA-256（平文は発行時にのみ返す）',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '発行日時',
+  PRIMARY KEY (`id`),
+  CONSTRAINT `fk_calendar_feed_tokens_user_id` FOREIGN KEY (`user_id`) REFERENCES `users_master`(`id`),
+  UNIQUE INDEX `uq_calendar_feed_tokens_token_hash` (`token_hash`),
+  UNIQUE INDEX `uq_calendar_feed_tokens_user_id` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='カレンダー配信（iCalendar）トークン';
+
+DROP TABLE IF EXISTS `permissions_master`;
+CREATE TABLE `permissions_master` (
+  `id` bigint AUTO_INCREMENT NOT NULL COMMENT 'ID',
+  `code` varchar(100) NOT NULL COMMENT '権限コード（リソース:操作）',
+  `name` varchar(100) NOT NULL COMMENT '権限名',
+  `version` bigint NOT NULL DEFAULT 1 COMMENT 'バージョン（更新のたびに加算）',
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `uq_permissions_master_code` (`code`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='権限マスタ（API仕様書の x-permission と対応）';
+
+-- seed data for permissions_master
+INSERT INTO `permissions_master` (`id`, `code`, `name`, `version`) VALUES
+  (1, 'users:read', 'ユーザ参照', DEFAULT),
+  (2, 'users:write', 'ユーザ登録・更新', DEFAULT),
+  (3, 'permissions:manage', 'グループ権限管理', DEFAULT),
+  (4, 'items:read', 'アイテム参照', DEFAULT),
+  (5, 'items:write', 'アイテム登録・更新', DEFAULT),
+  (6, 'billing:read', '請求参照', DEFAULT),
+  (7, 'billing:write', '請求登録・更新', DEFAULT),
+  (8, 'approval:approve', '承認', DEFAULT),
+  (9, 'masters:read', 'マスタ参照', DEFAULT),
+  (10, 'masters:write', 'マスタ登録・更新', DEFAULT),
+  (11, 'audit:read', '監査ログ参照', DEFAULT),
+  (12, 'events:read', 'カレンダー参照', DEFAULT),
+  (13, 'events:write', 'カレンダー登録・更新', DEFAULT);
+
+DROP TABLE IF EXISTS `group_permissions`;
+CREATE TABLE `group_permissions` (
+  `id` bigint AUTO_INCREMENT NOT NULL COMMENT 'ID',
+  `group_id` bigint NOT NULL COMMENT 'グループマスタID',
+  `permission_id` bigint NOT NULL COMMENT '権限マスタID',
+  PRIMARY KEY (`id`),
+  CONSTRAINT `fk_group_permissions_group_id` FOREIGN KEY (`group_id`) REFERENCES `groups_master`(`id`),
+  CONSTRAINT `fk_group_permissions_permission_id` FOREIGN KEY (`permission_id`) REFERENCES `permissions_master`(`id`),
+  UNIQUE INDEX `uq_group_permissions_group_id_permission_id` (`group_id`, `permission_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='グループ権限';
+
+DROP TABLE IF EXISTS `audit_logs`;
+CREATE TABLE `audit_logs` (
+  `id` bigint AUTO_INCREMENT NOT NULL COMMENT 'ID',
+  `occurred_at` datetime NOT NULL COMMENT '操作日時',
+  `user_id` bigint COMMENT '操作ユーザのユーザマスタID',
+  `user_login` varchar(100) COMMENT '操作時のユーザID（ユーザ変更後も残す）',
+  `shipping_id` bigint COMMENT '操作ユーザの荷主ID（倉庫側ユーザは NULL）',
+  `action` varchar(10) NOT NULL COMMENT '操作（create / update / delete）',
+  `table_name` varchar(64) NOT NULL COMMENT 'テーブル名',
+  `record_id` bigint NOT NULL COMMENT '対象行のID',
+  `changes` mediumtext NOT NULL COMMENT '変更内容（列ごとの変更前・変更後の JSON）',
+  `request_id` varchar(128) COMMENT 'リクエストID（X-Request-ID）',
+  PRIMARY KEY (`id`),
+  CONSTRAINT `fk_audit_logs_user_id` FOREIGN KEY (`user_id`) REFERENCES `users_master`(`id`),
+  CONSTRAINT `fk_audit_logs_shipping_id` FOREIGN KEY (`shipping_id`) REFERENCES `shippings_master`(`id`),
+  INDEX `idx_audit_logs_table_name_record_id` (`table_name`, `record_id`),
+  INDEX `idx_audit_logs_user_id` (`user_id`),
+  INDEX `idx_audit_logs_occurred_at` (`occurred_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='監査ログ（API による登録・更新・削除の記録）';
+
+DROP TABLE IF EXISTS `events`;
+CREATE TABLE `events` (
+  `id` bigint AUTO_INCREMENT NOT NULL COMMENT 'ID',
+  `shipping_id` bigint COMMENT '対象の荷主ID（NULL は全荷主共通）',
+  `title` varchar(200) NOT NULL COMMENT '件名',
+  `start_at` datetime NOT NULL COMMENT '開始日時',
+  `end_at` datetime COMMENT '終了日時（NULL は開始日時のみ）',
+  `all_day` boolean NOT NULL DEFAULT false COMMENT '終日',
+  `status` varchar(10) NOT NULL COMMENT '状態（error / warning / wait / info / done / 空欄）',
+  `kind` varchar(10) NOT NULL COMMENT '区分（all / shipper / warehouse / partner）',
+  `description` text COMMENT '詳細',
+  `rrule` varchar(255) COMMENT '繰り返しの規則（RFC 5545 の RRULE。NULL は繰り返さない）',
+  `exdates` text COMMENT '繰り返しの除外日（YYYY-MM-DD のカンマ区切り）',
+  `business_day` varchar(10) NOT NULL DEFAULT 'none' COMMENT '繰り返しが休業日に当たるとき（none=そのまま / skip=除く / previous=前営業日 / next=翌営業日）',
+  `created_by` bigint COMMENT '登録ユーザのユーザマスタID',
+  `version` bigint NOT NULL DEFAULT 1 COMMENT 'バージョン（更新のたびに加算）',
+  PRIMARY KEY (`id`),
+  CONSTRAINT `fk_events_shipping_id` FOREIGN KEY (`shipping_id`) REFERENCES `shippings_master`(`id`),
+  CONSTRAINT `fk_events_created_by` FOREIGN KEY (`created_by`) REFERENCES `users_master`(`id`),
+  INDEX `idx_events_start_at` (`start_at`),
+  INDEX `idx_events_shipping_id` (`shipping_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='カレンダーのイベント（画面から登録する予定・連絡）';
+
+DROP TABLE IF EXISTS `event_overrides`;
+CREATE TABLE `event_overrides` (
+  `id` bigint AUTO_INCREMENT NOT NULL COMMENT 'ID',
+  `event_id` bigint NOT NULL COMMENT 'イベントID',
+  `recurrence_date` date NOT NULL COMMENT '変更する回の本来の日付',
+  `title` varchar(200) COMMENT '件名',
+  `start_at` datetime COMMENT '開始日時',
+  `end_at` datetime COMMENT '終了日時',
+  `status` varchar(10) COMMENT '状態',
+  `description` text COMMENT '詳細',
+  PRIMARY KEY (`id`),
+  CONSTRAINT `fk_event_overrides_event_id` FOREIGN KEY (`event_id`) REFERENCES `events`(`id`),
+  UNIQUE INDEX `uq_event_overrides_event_id_recurrence_date` (`event_id`, `recurrence_date`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='繰り返しイベントの回ごとの変更（NULL の列は繰り返しイベントの値のまま）';
+
+-- 部門荷主ビュー
+DROP VIEW IF EXISTS `departments_shippings_view`;
+CREATE VIEW `departments_shippings_view` (`department_id`, `department_code`, `department_name`, `shipping_id`, `shipping_code`, `shipping_name`) AS
+SELECT
+  d.id AS department_id,
+  d.code AS department_code,
+  d.name AS department_name,
+  s.id AS shipping_id,
+  s.code AS shipping_code,
+  s.name AS shipping_name
+FROM departments_master d
+INNER JOIN shippings_master s ON s.id = d.shipping_id;
+
+-- 荷主別アイテム集計ビュー
+DROP VIEW IF EXISTS `shipping_items_view`;
+CREATE VIEW `shipping_items_view` (`shipping_id`, `shipping_name`, `item_count`, `total_quantity`) AS
+SELECT
+  v.shipping_id,
+  v.shipping_name,
+  COUNT(i.id) AS item_count,
+  COALESCE(SUM(i.quantity), 0) AS total_quantity
+FROM departments_shippings_view v
+LEFT JOIN items_master i ON i.department_id = v.department_id
+GROUP BY v.shipping_id, v.shipping_name;
+
+-- 請求サマリビュー
+DROP VIEW IF EXISTS `billing_summaries_view`;
+CREATE VIEW `billing_summaries_view` (`billing_id`, `shipping_name`, `closing_day`, `billing_month_name`, `billing_day`, `billing_department_name`, `account_type_name`, `consumption_tax_show_name`, `rounding_name`) AS
+SELECT
+  b.id AS billing_id,
+  s.name AS shipping_name,
+  c.day AS closing_day,
+  m.name AS billing_month_name,
+  bd.day AS billing_day,
+  v.department_name AS billing_department_name,
+  a.name AS account_type_name,
+  t.name AS consumption_tax_show_name,
+  r.name AS rounding_name
+FROM billings_master b
+INNER JOIN shippings_master s ON s.id = b.shipping_id
+INNER JOIN closing_dates_master c ON c.id = b.closing_date_id
+INNER JOIN billing_months_master m ON m.id = b.billing_date_kind
+INNER JOIN billing_days_master bd ON bd.id = b.billing_date_id
+INNER JOIN departments_shippings_view v ON v.department_id = b.billing_department_id
+INNER JOIN account_types_master a ON a.id = b.account_type
+INNER JOIN consumption_tax_shows_master t ON t.id = b.consumption_tax_show_id
+INNER JOIN roundings_master r ON r.id = b.rounding_id;
+
 SET FOREIGN_KEY_CHECKS = 1;